@@ -0,0 +1,82 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/gitflow/tui/internal/cli"
+	"github.com/gitflow/tui/internal/config"
+	"github.com/gitflow/tui/internal/git"
+	"github.com/gitflow/tui/internal/ui"
+)
+
+// Build information, set by the Makefile through -ldflags -X
+var (
+	Version   = "dev"
+	BuildTime = "unknown"
+	Commit    = "unknown"
+)
+
+func main() {
+	// Subcommands such as "config list" run without the TUI
+	if handled, err := cli.Run(os.Args[1:], os.Stdout); handled {
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "gitflow-tui:", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	showVersion := flag.Bool("version", false, "print the version and exit")
+	cwd := flag.String("cwd", "", "start in `dir` instead of the current directory")
+	flag.Usage = usage
+	flag.Parse()
+
+	if *showVersion {
+		fmt.Printf("GitFlow TUI %s (%s, built %s) - Open Source Git Management\n", Version, Commit, BuildTime)
+		fmt.Println("Supports: Neovim | VSCode | Terminal")
+		return
+	}
+	if *cwd != "" {
+		if err := os.Chdir(*cwd); err != nil {
+			fmt.Fprintln(os.Stderr, "gitflow-tui:", err)
+			os.Exit(1)
+		}
+	}
+
+	// Repository overrides apply on top of the global config
+	repoPath := ""
+	if repo, err := git.FindRepository("."); err == nil {
+		repoPath = repo.Path
+	}
+	eff, err := config.LoadLayered(repoPath)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "gitflow-tui: config:", err)
+		os.Exit(1)
+	}
+
+	ui.Version = Version
+	var opts []tea.ProgramOption
+	if eff.Config.MouseEnabled {
+		opts = append(opts, tea.WithMouseCellMotion())
+	}
+	if _, err := tea.NewProgram(ui.New(eff.Config), opts...).Run(); err != nil {
+		fmt.Fprintln(os.Stderr, "gitflow-tui:", err)
+		os.Exit(1)
+	}
+}
+
+// usage lists the flags and the subcommands
+func usage() {
+	out := flag.CommandLine.Output()
+	fmt.Fprintln(out, "Usage: gitflow-tui [flags]")
+	fmt.Fprintln(out, "       gitflow-tui <command> [args]")
+	fmt.Fprintln(out, "\nFlags:")
+	flag.PrintDefaults()
+	fmt.Fprintln(out, "\nCommands:")
+	for _, sub := range cli.Subcommands() {
+		fmt.Fprintf(out, "  %-10s %s\n", sub.Name, sub.Description)
+	}
+}
@@ -2,6 +2,7 @@ package auth
 
 import (
 	"bufio"
+	"context"
 	"encoding/json"
 	"fmt"
 	"os"
@@ -9,7 +10,9 @@ import (
 	"path/filepath"
 	"strings"
 	"syscall"
+	"time"
 
+	"github.com/gitflow/tui/internal/remoteurl"
 	"golang.org/x/term"
 )
 
@@ -224,51 +227,89 @@ func (m *Manager) ConfigureToken(host, token string) error {
 	return m.AddCredential(cred)
 }
 
-// GetAuthForRemote returns authentication for a remote URL
-func (m *Manager) GetAuthForRemote(remoteURL string) (*Credential, error) {
-	// Parse remote URL to extract host
-	host := extractHostFromURL(remoteURL)
-	if host == "" {
-		return nil, fmt.Errorf("could not extract host from URL")
+// GetAuthForRemote returns authentication for a remote URL of the
+// repository at repoPath
+func (m *Manager) GetAuthForRemote(repoPath, remoteURL string) (*Credential, error) {
+	// Apply the repository's url.*.insteadOf rewrites before extracting the host
+	u, err := remoteurl.Resolve(repoPath, remoteURL, false)
+	if err != nil {
+		return nil, fmt.Errorf("could not extract host from URL: %w", err)
 	}
-
-	return m.GetCredential(host)
-}
-
-// extractHostFromURL extracts host from Git URL
-func extractHostFromURL(url string) string {
-	// Handle SSH format: git@github.com:user/repo.git
-	if strings.HasPrefix(url, "git@") {
-		parts := strings.Split(url, ":")
-		if len(parts) >= 2 {
-			host := strings.TrimPrefix(parts[0], "git@")
-			return host
-		}
+	if u.IsLocal() {
+		return nil, fmt.Errorf("local remote %s needs no credentials", remoteURL)
 	}
 
-	// Handle HTTPS format: https://github.com/user/repo.git
-	if strings.HasPrefix(url, "https://") {
-		url = strings.TrimPrefix(url, "https://")
-		parts := strings.Split(url, "/")
-		if len(parts) >= 1 {
-			return parts[0]
+	// Credentials for a non-default port take precedence
+	if u.Port != 0 {
+		if cred, err := m.GetCredential(u.HostPort()); err == nil {
+			return cred, nil
 		}
 	}
 
-	return ""
+	return m.GetCredential(u.Host)
 }
 
-// TestAuth tests authentication with a remote
-func (m *Manager) TestAuth(remoteURL string) error {
-	// Try to fetch from remote
-	cmd := exec.Command("git", "ls-remote", remoteURL)
+// testAuthTimeout bounds how long TestAuth waits for a remote
+const testAuthTimeout = 15 * time.Second
+
+// TestAuth tests authentication with a remote of the repository at
+// repoPath, whose url.*.insteadOf rules apply
+func (m *Manager) TestAuth(repoPath, remoteURL string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), testAuthTimeout)
+	defer cancel()
+
+	// Try to fetch from remote, failing instead of prompting for credentials
+	// or host key confirmation
+	cmd := exec.CommandContext(ctx, "git", "ls-remote", remoteURL)
+	cmd.Dir = repoPath
+	cmd.Env = append(os.Environ(), "GIT_TERMINAL_PROMPT=0", "GIT_SSH_COMMAND="+batchSSHCommand(sshCommand(repoPath)))
 	output, err := cmd.CombinedOutput()
+	if ctx.Err() == context.DeadlineExceeded {
+		return fmt.Errorf("no response from remote within %s", testAuthTimeout)
+	}
 	if err != nil {
 		return fmt.Errorf("authentication failed: %s", string(output))
 	}
 	return nil
 }
 
+// sshCommand returns the ssh command git uses for the repository at
+// repoPath, in git's order: GIT_SSH_COMMAND, core.sshCommand, GIT_SSH
+func sshCommand(repoPath string) string {
+	if command := os.Getenv("GIT_SSH_COMMAND"); command != "" {
+		return command
+	}
+	cmd := exec.Command("git", "config", "core.sshCommand")
+	cmd.Dir = repoPath
+	if out, err := cmd.Output(); err == nil && strings.TrimSpace(string(out)) != "" {
+		return strings.TrimSpace(string(out))
+	}
+	if program := os.Getenv("GIT_SSH"); program != "" {
+		return "'" + strings.ReplaceAll(program, "'", `'\''`) + "'"
+	}
+	return "ssh"
+}
+
+// batchSSHCommand adds BatchMode to an OpenSSH command, so it fails
+// instead of asking for a passphrase or host key confirmation, keeping the
+// user's own options. Other ssh clients are returned unchanged
+func batchSSHCommand(command string) string {
+	command = strings.TrimSpace(command)
+	if command == "" {
+		return command
+	}
+	program := strings.Fields(command)[0]
+	if q := command[0]; q == '\'' || q == '"' {
+		if i := strings.IndexByte(command[1:], q); i >= 0 {
+			program = command[1 : i+1]
+		}
+	}
+	if strings.TrimSuffix(filepath.Base(program), ".exe") != "ssh" {
+		return command
+	}
+	return command + " -o BatchMode=yes"
+}
+
 // SetupGitCredentialHelper sets up Git credential helper
 func (m *Manager) SetupGitCredentialHelper() error {
 	// Configure Git to use the credential helper
@@ -0,0 +1,59 @@
+package auth
+
+import (
+	"os/exec"
+	"testing"
+)
+
+func TestBatchSSHCommand(t *testing.T) {
+	tests := []struct {
+		command string
+		want    string
+	}{
+		{"ssh", "ssh -o BatchMode=yes"},
+		{"ssh -i ~/.ssh/work -p 2222", "ssh -i ~/.ssh/work -p 2222 -o BatchMode=yes"},
+		{"/usr/bin/ssh -o ProxyCommand='nc -X 5 %h %p'", "/usr/bin/ssh -o ProxyCommand='nc -X 5 %h %p' -o BatchMode=yes"},
+		{"'/opt/my tools/ssh'", "'/opt/my tools/ssh' -o BatchMode=yes"},
+		{"ssh.exe -v", "ssh.exe -v -o BatchMode=yes"},
+		{"plink -batch", "plink -batch"},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		if got := batchSSHCommand(tt.command); got != tt.want {
+			t.Errorf("batchSSHCommand(%q) = %q, want %q", tt.command, got, tt.want)
+		}
+	}
+}
+
+func TestSSHCommand(t *testing.T) {
+	t.Setenv("GIT_CONFIG_GLOBAL", "/dev/null")
+	t.Setenv("GIT_CONFIG_NOSYSTEM", "1")
+	t.Setenv("GIT_SSH_COMMAND", "")
+	t.Setenv("GIT_SSH", "")
+
+	dir := t.TempDir()
+	if got := sshCommand(dir); got != "ssh" {
+		t.Errorf("sshCommand() = %q, want ssh", got)
+	}
+
+	t.Setenv("GIT_SSH", "/opt/ssh")
+	if got := sshCommand(dir); got != "'/opt/ssh'" {
+		t.Errorf("sshCommand() = %q, want quoted GIT_SSH", got)
+	}
+
+	for _, args := range [][]string{{"init", "-q"}, {"config", "core.sshCommand", "ssh -p 2222"}} {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v: %s", args, err, out)
+		}
+	}
+	if got := sshCommand(dir); got != "ssh -p 2222" {
+		t.Errorf("sshCommand() = %q, want core.sshCommand", got)
+	}
+
+	t.Setenv("GIT_SSH_COMMAND", "ssh -i key")
+	if got := sshCommand(dir); got != "ssh -i key" {
+		t.Errorf("sshCommand() = %q, want GIT_SSH_COMMAND", got)
+	}
+}
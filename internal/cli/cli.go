@@ -0,0 +1,398 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/gitflow/tui/internal/config"
+	"github.com/gitflow/tui/internal/git"
+	"github.com/gitflow/tui/internal/lint"
+	"github.com/gitflow/tui/internal/plugin"
+	"github.com/gitflow/tui/internal/release"
+	"github.com/gitflow/tui/pkg/graph"
+)
+
+// Subcommand represents a non-interactive CLI subcommand
+type Subcommand struct {
+	Name        string
+	Description string
+	Run         func(args []string, out io.Writer) error
+}
+
+// Subcommands returns all available subcommands
+func Subcommands() []Subcommand {
+	return []Subcommand{
+		{
+			Name:        "config",
+			Description: "Inspect the effective configuration",
+			Run:         runConfig,
+		},
+		{
+			Name:        "changelog",
+			Description: "Generate a changelog from commit history",
+			Run:         runChangelog,
+		},
+		{
+			Name:        "lint",
+			Description: "Check commit messages against the commit rules",
+			Run:         runLint,
+		},
+		{
+			Name:        "log",
+			Description: "List commits matching search filters",
+			Run:         runLog,
+		},
+		{
+			Name:        "plugins",
+			Description: "List installed plugins",
+			Run:         runPlugins,
+		},
+	}
+}
+
+// Run dispatches args to a subcommand. It returns false when args do not
+// name a subcommand, in which case the caller should start the TUI.
+func Run(args []string, out io.Writer) (bool, error) {
+	if len(args) == 0 {
+		return false, nil
+	}
+
+	for _, sub := range Subcommands() {
+		if sub.Name == args[0] {
+			return true, sub.Run(args[1:], out)
+		}
+	}
+	return false, nil
+}
+
+// repoPath returns the enclosing repository path or "" outside a repository
+func repoPath() string {
+	repo, err := git.FindRepository(".")
+	if err != nil {
+		return ""
+	}
+	return repo.Path
+}
+
+// runConfig handles "config list [--show-origin]" and "config migrate"
+func runConfig(args []string, out io.Writer) error {
+	if len(args) == 1 && args[0] == "migrate" {
+		return runConfigMigrate(out)
+	}
+	if len(args) == 0 || args[0] != "list" {
+		return fmt.Errorf("usage: gitflow-tui config list [--show-origin] | config migrate")
+	}
+
+	showOrigin := false
+	for _, arg := range args[1:] {
+		switch arg {
+		case "--show-origin":
+			showOrigin = true
+		default:
+			return fmt.Errorf("unknown flag: %s", arg)
+		}
+	}
+
+	eff, err := config.LoadLayered(repoPath())
+	if err != nil {
+		return err
+	}
+
+	entries, err := eff.Entries()
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(out, 0, 4, 2, ' ', 0)
+	for _, e := range entries {
+		if showOrigin {
+			fmt.Fprintf(w, "%s\t%s=%s\n", e.Origin, e.Key, e.Value)
+		} else {
+			fmt.Fprintf(w, "%s=%s\n", e.Key, e.Value)
+		}
+	}
+	return w.Flush()
+}
+
+// runConfigMigrate rewrites a config file written by an older version
+func runConfigMigrate(out io.Writer) error {
+	path, migrated, err := config.MigrateFile()
+	if err != nil {
+		return err
+	}
+	if !migrated {
+		fmt.Fprintf(out, "%s is up to date\n", path)
+		return nil
+	}
+	fmt.Fprintf(out, "Migrated %s to version %d\n", path, config.SchemaVersion)
+	return nil
+}
+
+// runPlugins handles "plugins list", starting each plugin to report what
+// it registers
+func runPlugins(args []string, out io.Writer) error {
+	if len(args) == 0 || args[0] != "list" {
+		return fmt.Errorf("usage: gitflow-tui plugins list")
+	}
+
+	path := repoPath()
+	g := git.New(path)
+	mgr, errs := plugin.Load(config.PluginsDir(), path, plugin.GitHost{Git: func() *git.Git { return g }})
+	defer mgr.Close()
+
+	w := tabwriter.NewWriter(out, 0, 4, 2, ' ', 0)
+	for _, p := range mgr.Plugins() {
+		var commands, tabs []string
+		for _, c := range p.Manifest.Commands {
+			commands = append(commands, c.Name)
+		}
+		for _, t := range p.Manifest.Tabs {
+			tabs = append(tabs, t.Title)
+		}
+		fmt.Fprintf(w, "%s\t%s\tcommands: %s\ttabs: %s\tevents: %s\n", p.Name(), p.Manifest.Version,
+			strings.Join(commands, ", "), strings.Join(tabs, ", "), strings.Join(p.Manifest.Events, ", "))
+	}
+	for _, err := range errs {
+		fmt.Fprintf(w, "error\t%s\n", err)
+	}
+	return w.Flush()
+}
+
+// runLog handles "log [FILTERS] [RANGE] [-- PATH...]", printing one
+// commit per line or, with --graph, the commit graph
+func runLog(args []string, out io.Writer) error {
+	usage := fmt.Errorf("usage: gitflow-tui log [--grep REGEX] [--author REGEX] [--since DATE] [--until DATE] " +
+		"[-S TEXT | -G REGEX] [--first-parent] [--no-merges] [-n N] [--graph] [RANGE] [-- PATH...]")
+
+	var filter git.LogFilter
+	showGraph := false
+	for i := 0; i < len(args); i++ {
+		var target *string
+		switch args[i] {
+		case "--grep":
+			target = &filter.Message
+		case "--author":
+			target = &filter.Author
+		case "--since":
+			target = &filter.Since
+		case "--until":
+			target = &filter.Until
+		case "-S", "-G":
+			if filter.Pickaxe != "" {
+				return usage
+			}
+			filter.PickaxeRegex = args[i] == "-G"
+			target = &filter.Pickaxe
+		case "-n":
+			if i+1 >= len(args) {
+				return usage
+			}
+			i++
+			n, err := strconv.Atoi(args[i])
+			if err != nil || n < 0 {
+				return usage
+			}
+			filter.Limit = n
+			continue
+		case "--first-parent":
+			filter.FirstParent = true
+			continue
+		case "--no-merges":
+			filter.NoMerges = true
+			continue
+		case "--graph":
+			showGraph = true
+			continue
+		case "--":
+			filter.Paths = args[i+1:]
+			i = len(args)
+			continue
+		default:
+			if strings.HasPrefix(args[i], "-") || filter.Range != "" {
+				return usage
+			}
+			filter.Range = args[i]
+			continue
+		}
+		if i+1 >= len(args) {
+			return usage
+		}
+		i++
+		*target = args[i]
+	}
+
+	path := repoPath()
+	if path == "" {
+		return fmt.Errorf("not a git repository")
+	}
+	commits, err := git.New(path).FilterCommits(filter)
+	if err != nil {
+		return err
+	}
+
+	if showGraph {
+		if len(commits) == 0 {
+			return nil
+		}
+		_, err = fmt.Fprintln(out, graph.New(commits, graph.Unicode).Render())
+		return err
+	}
+	w := tabwriter.NewWriter(out, 0, 4, 2, ' ', 0)
+	for _, c := range commits {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", c.ShortHash, c.Date.Format("2006-01-02"), c.Author, c.Message)
+	}
+	return w.Flush()
+}
+
+// runChangelog handles "changelog [--from REF] [--to REF] [--format FORMAT]"
+func runChangelog(args []string, out io.Writer) error {
+	usage := fmt.Errorf("usage: gitflow-tui changelog [--from REF] [--to REF] [--format markdown|json|keepachangelog]")
+
+	var from, to, format string
+	for i := 0; i < len(args); i++ {
+		var target *string
+		switch args[i] {
+		case "--from":
+			target = &from
+		case "--to":
+			target = &to
+		case "--format":
+			target = &format
+		default:
+			return usage
+		}
+		if i+1 >= len(args) {
+			return usage
+		}
+		i++
+		*target = args[i]
+	}
+
+	path := repoPath()
+	if path == "" {
+		return fmt.Errorf("not a git repository")
+	}
+	changelog, err := release.Generate(git.New(path), from, to)
+	if err != nil {
+		return err
+	}
+	text, err := changelog.Render(format)
+	if err != nil {
+		return err
+	}
+	_, err = io.WriteString(out, text)
+	return err
+}
+
+// runLint handles "lint [--from REF] [--to REF] [--edit FILE]". Without
+// --from only the commit at --to (HEAD) is checked; --edit checks a
+// message file, as a commit-msg hook would.
+func runLint(args []string, out io.Writer) error {
+	usage := fmt.Errorf("usage: gitflow-tui lint [--from REF] [--to REF] [--edit FILE]")
+
+	var from, to, edit string
+	for i := 0; i < len(args); i++ {
+		var target *string
+		switch args[i] {
+		case "--from":
+			target = &from
+		case "--to":
+			target = &to
+		case "--edit":
+			target = &edit
+		default:
+			return usage
+		}
+		if i+1 >= len(args) {
+			return usage
+		}
+		i++
+		*target = args[i]
+	}
+
+	path := repoPath()
+	eff, err := config.LoadLayered(path)
+	if err != nil {
+		return err
+	}
+	rules, err := lint.Load(path, eff.Config.Commit)
+	if err != nil {
+		return err
+	}
+	for _, name := range rules.Ignored {
+		fmt.Fprintf(out, "ignoring unsupported rule or preset %s\n", name)
+	}
+
+	type entry struct{ label, message string }
+	var entries []entry
+	if edit != "" {
+		data, err := os.ReadFile(edit)
+		if err != nil {
+			return err
+		}
+		entries = append(entries, entry{edit, stripComments(string(data))})
+	} else {
+		if path == "" {
+			return fmt.Errorf("not a git repository")
+		}
+		g := git.New(path)
+		if to == "" {
+			to = "HEAD"
+		}
+		if from == "" {
+			from = to + "~1"
+			if _, err := g.Execute("rev-parse", "--verify", "--quiet", from); err != nil {
+				from = "" // Root commit
+			}
+		}
+		commits, err := g.CommitsBetween(from, to)
+		if err != nil {
+			return err
+		}
+		for _, c := range commits {
+			message := c.Message
+			if c.Body != "" {
+				message += "\n\n" + c.Body
+			}
+			entries = append(entries, entry{c.ShortHash + " " + c.Message, message})
+		}
+	}
+
+	failed := 0
+	for _, e := range entries {
+		violations := lint.Lint(e.message, rules.Rules)
+		if len(violations) == 0 {
+			continue
+		}
+		fmt.Fprintln(out, e.label)
+		for _, v := range violations {
+			fmt.Fprintf(out, "  %s\n", v)
+			if v.Level == lint.LevelError {
+				failed++
+			}
+		}
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d problem(s) in %d message(s)", failed, len(entries))
+	}
+	return nil
+}
+
+// stripComments removes the "#" lines git adds to message files
+func stripComments(message string) string {
+	var lines []string
+	for _, line := range strings.Split(message, "\n") {
+		if strings.HasPrefix(line, "# ------------------------ >8") {
+			break
+		}
+		if !strings.HasPrefix(line, "#") {
+			lines = append(lines, line)
+		}
+	}
+	return strings.TrimSpace(strings.Join(lines, "\n"))
+}
@@ -1,69 +1,130 @@
 package config
 
 import (
-	"encoding/json"
+	"fmt"
 	"os"
 	"path/filepath"
 )
 
 // ThemeColors defines the color palette
 type ThemeColors struct {
-	Primary     string // Green
-	Secondary   string // Teal
-	Tertiary    string // Blue
-	Accent      string // Firozi/Cyan
-	Highlight   string // Orange
-	Background  string
-	Foreground  string
-	Success     string
-	Warning     string
-	Error       string
-	Muted       string
-	Border      string
+	Primary    string `json:"primary" yaml:"primary" toml:"primary"`       // Green
+	Secondary  string `json:"secondary" yaml:"secondary" toml:"secondary"` // Teal
+	Tertiary   string `json:"tertiary" yaml:"tertiary" toml:"tertiary"`    // Blue
+	Accent     string `json:"accent" yaml:"accent" toml:"accent"`          // Firozi/Cyan
+	Highlight  string `json:"highlight" yaml:"highlight" toml:"highlight"` // Orange
+	Background string `json:"background" yaml:"background" toml:"background"`
+	Foreground string `json:"foreground" yaml:"foreground" toml:"foreground"`
+	Success    string `json:"success" yaml:"success" toml:"success"`
+	Warning    string `json:"warning" yaml:"warning" toml:"warning"`
+	Error      string `json:"error" yaml:"error" toml:"error"`
+	Muted      string `json:"muted" yaml:"muted" toml:"muted"`
+	Border     string `json:"border" yaml:"border" toml:"border"`
 }
 
 // Default theme colors - Green Teal Blue Firozi Orange
 type Theme struct {
-	Name   string
-	Colors ThemeColors
+	Name   string      `json:"name" yaml:"name" toml:"name"`
+	Colors ThemeColors `json:"colors" yaml:"colors" toml:"colors"`
+
+	// Optional palettes for terminals without true color support
+	Fallback256 ThemeColors `json:"fallback_256" yaml:"fallback_256" toml:"fallback_256"`
+	Fallback16  ThemeColors `json:"fallback_16" yaml:"fallback_16" toml:"fallback_16"`
 }
 
 var DefaultTheme = Theme{
 	Name: "gitflow",
 	Colors: ThemeColors{
-		Primary:     "#00D9A5", // Green
-		Secondary:   "#00B4A6", // Teal
-		Tertiary:    "#0091EA", // Blue
-		Accent:      "#00E5FF", // Firozi/Cyan
-		Highlight:   "#FF6D00", // Orange
-		Background:  "#0D1117", // Dark background
-		Foreground:  "#E6EDF3", // Light text
-		Success:     "#3FB950", // Success green
-		Warning:     "#FFA500", // Warning orange
-		Error:       "#F85149", // Error red
-		Muted:       "#8B949E", // Muted gray
-		Border:      "#30363D", // Border color
+		Primary:    "#00D9A5", // Green
+		Secondary:  "#00B4A6", // Teal
+		Tertiary:   "#0091EA", // Blue
+		Accent:     "#00E5FF", // Firozi/Cyan
+		Highlight:  "#FF6D00", // Orange
+		Background: "#0D1117", // Dark background
+		Foreground: "#E6EDF3", // Light text
+		Success:    "#3FB950", // Success green
+		Warning:    "#FFA500", // Warning orange
+		Error:      "#F85149", // Error red
+		Muted:      "#8B949E", // Muted gray
+		Border:     "#30363D", // Border color
+	},
+	Fallback256: ThemeColors{
+		Primary: "43", Secondary: "37", Tertiary: "32", Accent: "51", Highlight: "202",
+		Background: "233", Foreground: "255", Success: "71", Warning: "214", Error: "203",
+		Muted: "246", Border: "237",
+	},
+	Fallback16: ThemeColors{
+		Primary: "10", Secondary: "6", Tertiary: "4", Accent: "14", Highlight: "3",
+		Background: "0", Foreground: "15", Success: "2", Warning: "11", Error: "9",
+		Muted: "8", Border: "8",
 	},
 }
 
 // Config holds all application configuration
 type Config struct {
-	Theme           Theme    `json:"theme"`
-	GitPath         string   `json:"git_path"`
-	Editor          string   `json:"editor"`
-	DefaultBranch   string   `json:"default_branch"`
-	ShowGraph       bool     `json:"show_graph"`
-	GraphStyle      string   `json:"graph_style"` // ascii, unicode, compact
-	MouseEnabled    bool     `json:"mouse_enabled"`
-	Animations      bool     `json:"animations"`
-	AuthMethod      string   `json:"auth_method"` // ssh, https, token
-	RecentRepos     []string `json:"recent_repos"`
-	MaxRecentRepos  int      `json:"max_recent_repos"`
+	Version        int      `json:"version" yaml:"version" toml:"version"`
+	Theme          Theme    `json:"theme" yaml:"theme" toml:"theme"`
+	GitPath        string   `json:"git_path" yaml:"git_path" toml:"git_path"`
+	Editor         string   `json:"editor" yaml:"editor" toml:"editor"`
+	DefaultBranch  string   `json:"default_branch" yaml:"default_branch" toml:"default_branch"`
+	ShowGraph      bool     `json:"show_graph" yaml:"show_graph" toml:"show_graph"`
+	GraphStyle     string   `json:"graph_style" yaml:"graph_style" toml:"graph_style"` // ascii, unicode, compact
+	MouseEnabled   bool     `json:"mouse_enabled" yaml:"mouse_enabled" toml:"mouse_enabled"`
+	Animations     bool     `json:"animations" yaml:"animations" toml:"animations"`
+	AuthMethod     string   `json:"auth_method" yaml:"auth_method" toml:"auth_method"` // ssh, https, token
+	RecentRepos    []string `json:"recent_repos" yaml:"recent_repos" toml:"recent_repos"`
+	MaxRecentRepos int      `json:"max_recent_repos" yaml:"max_recent_repos" toml:"max_recent_repos"`
+
+	// Commit configures the commit composer
+	Commit CommitConfig `json:"commit" yaml:"commit" toml:"commit"`
+
+	// CustomCommands are user-defined commands and macros
+	CustomCommands []CustomCommand `json:"custom_commands" yaml:"custom_commands" toml:"custom_commands"`
+
+	// Keybindings maps view -> action -> key sequences, e.g.
+	// {"graph": {"top": ["g g"]}}. Unset actions keep their defaults.
+	Keybindings map[string]map[string][]string `json:"keybindings" yaml:"keybindings" toml:"keybindings"`
+
+	// path is the file the config was loaded from
+	path string
+}
+
+// CommitConfig configures the commit composer and the rules messages
+// are checked against before committing
+type CommitConfig struct {
+	SubjectLimit  int      `json:"subject_limit" yaml:"subject_limit" toml:"subject_limit"`       // Maximum subject length, 0 for no limit
+	BodyLineLimit int      `json:"body_line_limit" yaml:"body_line_limit" toml:"body_line_limit"` // Maximum body line length, 0 for no limit
+	Conventional  bool     `json:"conventional" yaml:"conventional" toml:"conventional"`          // Require "type(scope): subject"
+	Types         []string `json:"types" yaml:"types" toml:"types"`                               // Offered by the type picker
+	Scopes        []string `json:"scopes" yaml:"scopes" toml:"scopes"`                            // Offered by the scope picker; any scope if empty
+	SignOff       bool     `json:"sign_off" yaml:"sign_off" toml:"sign_off"`                      // Add Signed-off-by to every commit
+}
+
+// CustomCommand is a user-defined command. Each step is a text/template
+// rendered with the current selection and run through the shell, e.g.
+// "git push --force-with-lease origin {{q .CurrentBranch}}".
+type CustomCommand struct {
+	Name        string         `json:"name" yaml:"name" toml:"name"`
+	Description string         `json:"description" yaml:"description" toml:"description"`
+	Key         string         `json:"key" yaml:"key" toml:"key"`
+	Steps       []string       `json:"steps" yaml:"steps" toml:"steps"`
+	Prompts     []CommandInput `json:"prompts" yaml:"prompts" toml:"prompts"`
+	Confirm     string         `json:"confirm" yaml:"confirm" toml:"confirm"` // Confirmation question, empty to skip
+	Output      string         `json:"output" yaml:"output" toml:"output"`    // none, status, panel
+}
+
+// CommandInput is a value asked for before a custom command runs,
+// available to steps as {{.Input.<Name>}}
+type CommandInput struct {
+	Name    string `json:"name" yaml:"name" toml:"name"`
+	Label   string `json:"label" yaml:"label" toml:"label"`
+	Default string `json:"default" yaml:"default" toml:"default"`
 }
 
 // Default returns default configuration
 func Default() *Config {
 	return &Config{
+		Version:        SchemaVersion,
 		Theme:          DefaultTheme,
 		GitPath:        "git",
 		Editor:         os.Getenv("EDITOR"),
@@ -75,54 +136,80 @@ func Default() *Config {
 		AuthMethod:     "ssh",
 		RecentRepos:    []string{},
 		MaxRecentRepos: 10,
+		Commit: CommitConfig{
+			SubjectLimit:  72,
+			BodyLineLimit: 72,
+			Types:         []string{"feat", "fix", "docs", "style", "refactor", "perf", "test", "build", "ci", "chore", "revert"},
+			Scopes:        []string{},
+		},
+		CustomCommands: []CustomCommand{},
+		Keybindings:    map[string]map[string][]string{},
 	}
 }
 
-// Load loads configuration from file
+// Load loads configuration from config.json, config.yaml or config.toml,
+// migrating files written by older versions
 func Load() (*Config, error) {
-	configDir, err := os.UserConfigDir()
-	if err != nil {
-		return nil, err
+	configPath, exists := findConfigFile(GetConfigDir())
+	if !exists {
+		cfg := Default()
+		_ = cfg.Save()
+		return cfg, nil
 	}
 
-	configPath := filepath.Join(configDir, "gitflow-tui", "config.json")
-	data, err := os.ReadFile(configPath)
+	raw, err := decodeFile(configPath)
 	if err != nil {
-		if os.IsNotExist(err) {
-			cfg := Default()
-			_ = cfg.Save()
-			return cfg, nil
-		}
 		return nil, err
 	}
 
-	var cfg Config
-	if err := json.Unmarshal(data, &cfg); err != nil {
-		return nil, err
+	// Older files are migrated in memory only; MigrateFile rewrites them
+	cfg, err := Decode(raw)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", configPath, err)
 	}
+	cfg.path = configPath
 
-	return &cfg, nil
+	return cfg, nil
 }
 
-// Save saves configuration to file
-func (c *Config) Save() error {
-	configDir, err := os.UserConfigDir()
+// MigrateFile rewrites the config file in the current schema version. It
+// returns the file path and whether the file needed migrating.
+func MigrateFile() (string, bool, error) {
+	configPath, exists := findConfigFile(GetConfigDir())
+	if !exists {
+		return configPath, false, nil
+	}
+
+	raw, err := decodeFile(configPath)
 	if err != nil {
-		return err
+		return configPath, false, err
+	}
+	migrated, err := Migrate(raw)
+	if err != nil || !migrated {
+		return configPath, false, err
 	}
 
-	appDir := filepath.Join(configDir, "gitflow-tui")
+	cfg, err := Decode(raw)
+	if err != nil {
+		return configPath, false, fmt.Errorf("%s: %w", configPath, err)
+	}
+	cfg.path = configPath
+	return configPath, true, cfg.Save()
+}
+
+// Save saves configuration to the file it was loaded from
+func (c *Config) Save() error {
+	appDir := GetConfigDir()
 	if err := os.MkdirAll(appDir, 0755); err != nil {
 		return err
 	}
 
-	configPath := filepath.Join(appDir, "config.json")
-	data, err := json.MarshalIndent(c, "", "  ")
-	if err != nil {
-		return err
+	configPath := c.path
+	if configPath == "" {
+		configPath, _ = findConfigFile(appDir)
 	}
 
-	return os.WriteFile(configPath, data, 0644)
+	return encodeFile(configPath, c)
 }
 
 // GetConfigDir returns the configuration directory
@@ -130,3 +217,8 @@ func GetConfigDir() string {
 	configDir, _ := os.UserConfigDir()
 	return filepath.Join(configDir, "gitflow-tui")
 }
+
+// PluginsDir returns the directory plugin executables are loaded from
+func PluginsDir() string {
+	return filepath.Join(GetConfigDir(), "plugins")
+}
@@ -0,0 +1,55 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// hookConfigNames are the project hook config files tried in order
+var hookConfigNames = []string{".gitflow-hooks.json", ".gitflow-hooks.yaml", ".gitflow-hooks.yml", ".gitflow-hooks.toml"}
+
+// HookConfig is a project's declarative hook configuration, committed to
+// the repository: each hook runs its commands in order, e.g.
+// {"hooks": {"pre-commit": ["go vet ./..."]}}
+type HookConfig struct {
+	Path  string
+	Hooks map[string][]string
+}
+
+// LoadHooks reads the hook config in repoPath. It returns nil when the
+// repository has none.
+func LoadHooks(repoPath string) (*HookConfig, error) {
+	for _, name := range hookConfigNames {
+		path := filepath.Join(repoPath, name)
+		if _, err := os.Stat(path); err != nil {
+			continue
+		}
+
+		raw, err := decodeFile(path)
+		if err != nil {
+			return nil, err
+		}
+		hooks, ok := raw["hooks"].(map[string]interface{})
+		if !ok {
+			return nil, ValidationError{Key: "hooks", Message: fmt.Sprintf("%s: expected a map of hook names to commands", path)}
+		}
+
+		cfg := &HookConfig{Path: path, Hooks: make(map[string][]string)}
+		for hook, value := range hooks {
+			commands, ok := value.([]interface{})
+			if !ok {
+				return nil, ValidationError{Key: "hooks." + hook, Message: fmt.Sprintf("%s: expected a list of commands", path)}
+			}
+			for _, c := range commands {
+				command, ok := c.(string)
+				if !ok {
+					return nil, ValidationError{Key: "hooks." + hook, Message: fmt.Sprintf("%s: commands must be strings", path)}
+				}
+				cfg.Hooks[hook] = append(cfg.Hooks[hook], command)
+			}
+		}
+		return cfg, nil
+	}
+	return nil, nil
+}
@@ -0,0 +1,84 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestLoadHooks(t *testing.T) {
+	tests := []struct {
+		name    string
+		file    string
+		content string
+		want    map[string][]string
+		wantErr string
+	}{
+		{
+			name:    "json",
+			file:    ".gitflow-hooks.json",
+			content: `{"hooks": {"pre-commit": ["go vet ./...", "go test ./..."]}}`,
+			want:    map[string][]string{"pre-commit": {"go vet ./...", "go test ./..."}},
+		},
+		{
+			name:    "yaml",
+			file:    ".gitflow-hooks.yaml",
+			content: "hooks:\n  commit-msg:\n    - gitflow-tui lint --edit \"$1\"\n  pre-push: [make check]\n",
+			want:    map[string][]string{"commit-msg": {`gitflow-tui lint --edit "$1"`}, "pre-push": {"make check"}},
+		},
+		{
+			name:    "toml",
+			file:    ".gitflow-hooks.toml",
+			content: "[hooks]\npre-commit = [\"gofmt -l .\"]\n",
+			want:    map[string][]string{"pre-commit": {"gofmt -l ."}},
+		},
+		{
+			name:    "no hooks map",
+			file:    ".gitflow-hooks.json",
+			content: `{"pre-commit": ["true"]}`,
+			wantErr: "hooks: ",
+		},
+		{
+			name:    "command not a list",
+			file:    ".gitflow-hooks.yaml",
+			content: "hooks:\n  pre-commit: go test ./...\n",
+			wantErr: "hooks.pre-commit: ",
+		},
+		{
+			name:    "command not a string",
+			file:    ".gitflow-hooks.json",
+			content: `{"hooks": {"pre-commit": [1]}}`,
+			wantErr: "commands must be strings",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			path := filepath.Join(dir, tt.file)
+			if err := os.WriteFile(path, []byte(tt.content), 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			cfg, err := LoadHooks(dir)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("LoadHooks() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if cfg.Path != path || !reflect.DeepEqual(cfg.Hooks, tt.want) {
+				t.Errorf("LoadHooks() = %+v, want %v from %s", cfg, tt.want, path)
+			}
+		})
+	}
+
+	cfg, err := LoadHooks(t.TempDir())
+	if cfg != nil || err != nil {
+		t.Errorf("LoadHooks() without a file = %+v, %v, want nil", cfg, err)
+	}
+}
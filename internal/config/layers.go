@@ -0,0 +1,383 @@
+package config
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Source identifies the layer a configuration value came from
+type Source string
+
+const (
+	SourceDefault   Source = "default"
+	SourceGlobal    Source = "global"
+	SourceRepoFile  Source = "repo"
+	SourceGitConfig Source = "gitconfig"
+	SourceEnv       Source = "env"
+)
+
+// RepoConfigFile is the per-repository override file name. The .yaml,
+// .yml and .toml variants are also accepted.
+const RepoConfigFile = ".gitflow-tui.json"
+
+// GitConfigSection is the .git/config section holding overrides
+const GitConfigSection = "gitflow-tui"
+
+// EnvPrefix is the prefix of environment variable overrides
+const EnvPrefix = "GITFLOW_"
+
+// repoKeys are the settings the repository layers may override. Anything
+// that runs programs (custom_commands, keybindings, editor, git_path) is
+// only read from the user's own config, so opening a cloned repository
+// cannot run commands from it.
+var repoKeys = map[string]bool{
+	"version":        true,
+	"theme":          true,
+	"default_branch": true,
+	"show_graph":     true,
+	"graph_style":    true,
+	"commit":         true,
+}
+
+// Origin records where an effective value was defined
+type Origin struct {
+	Source Source
+	Path   string // File path or variable name
+}
+
+// String formats the origin like git config --show-origin
+func (o Origin) String() string {
+	if o.Path == "" {
+		return string(o.Source)
+	}
+	return fmt.Sprintf("%s:%s", o.Source, o.Path)
+}
+
+// Entry is a single effective configuration value
+type Entry struct {
+	Key    string
+	Value  string
+	Origin Origin
+}
+
+// Effective is the merged configuration with provenance
+type Effective struct {
+	Config  *Config
+	Origins map[string]Origin
+}
+
+// layer is a set of raw values from one source
+type layer struct {
+	origin Origin
+	values map[string]interface{}
+	// Per-key origins when a layer spans several locations (env vars)
+	keyOrigins map[string]Origin
+}
+
+// LoadLayered merges defaults, the global config, per-repository
+// overrides and GITFLOW_* environment variables, in that order.
+// An empty repoPath skips the repository layers.
+func LoadLayered(repoPath string) (*Effective, error) {
+	defaults, err := toMap(Default())
+	if err != nil {
+		return nil, err
+	}
+
+	layers := []layer{{origin: Origin{Source: SourceDefault}, values: defaults}}
+
+	if globalPath, ok := findConfigFile(GetConfigDir()); ok {
+		global, err := readFileLayer(globalPath, SourceGlobal)
+		if err != nil {
+			return nil, err
+		}
+		layers = append(layers, *global)
+	}
+
+	if repoPath != "" {
+		if repoFile, ok := findRepoConfigFile(repoPath); ok {
+			l, err := readFileLayer(repoFile, SourceRepoFile)
+			if err != nil {
+				return nil, err
+			}
+			layers = append(layers, *l)
+		}
+
+		gitLayer, err := readGitConfigLayer(repoPath)
+		if err != nil {
+			return nil, err
+		}
+		if gitLayer != nil {
+			layers = append(layers, *gitLayer)
+		}
+	}
+
+	if envLayer := readEnvLayer(os.Environ()); envLayer != nil {
+		layers = append(layers, *envLayer)
+	}
+
+	return merge(layers)
+}
+
+// merge applies layers in order; later layers win
+func merge(layers []layer) (*Effective, error) {
+	merged := make(map[string]interface{})
+	origins := make(map[string]Origin)
+
+	for _, l := range layers {
+		mergeDefaults(merged, l.values)
+		for key := range l.values {
+			if o, ok := l.keyOrigins[key]; ok {
+				origins[key] = o
+			} else {
+				origins[key] = l.origin
+			}
+		}
+	}
+
+	data, err := json.Marshal(merged)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := Default()
+	if err := json.Unmarshal(data, cfg); err != nil {
+		return nil, err
+	}
+	if errs := cfg.Validate(); len(errs) > 0 {
+		return nil, errs
+	}
+
+	return &Effective{Config: cfg, Origins: origins}, nil
+}
+
+// Entries returns the effective values sorted by key
+func (e *Effective) Entries() ([]Entry, error) {
+	values, err := toMap(e.Config)
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []Entry
+	for key, value := range values {
+		entries = append(entries, Entry{
+			Key:    key,
+			Value:  displayValue(value),
+			Origin: e.Origins[key],
+		})
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].Key < entries[j].Key
+	})
+	return entries, nil
+}
+
+// displayValue renders a generic value for listing
+func displayValue(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case []interface{}:
+		var items []string
+		for _, item := range v {
+			items = append(items, fmt.Sprint(item))
+		}
+		return strings.Join(items, ",")
+	default:
+		data, _ := json.Marshal(v)
+		return string(data)
+	}
+}
+
+// findRepoConfigFile returns the per-repository override file, if any
+func findRepoConfigFile(repoPath string) (string, bool) {
+	base := strings.TrimSuffix(RepoConfigFile, ".json")
+	for _, ext := range []string{".json", ".yaml", ".yml", ".toml"} {
+		path := filepath.Join(repoPath, base+ext)
+		if _, err := os.Stat(path); err == nil {
+			return path, true
+		}
+	}
+	return "", false
+}
+
+// readFileLayer reads and migrates a JSON, YAML or TOML config file
+func readFileLayer(path string, source Source) (*layer, error) {
+	values, err := decodeFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := Migrate(values); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	if errs := checkKeys(values, reflectKeys(Default()), ""); len(errs) > 0 {
+		return nil, fmt.Errorf("%s: %w", path, errs)
+	}
+	if source == SourceRepoFile {
+		if errs := checkRepoKeys(values); len(errs) > 0 {
+			return nil, fmt.Errorf("%s: %w", path, errs)
+		}
+	}
+
+	return &layer{origin: Origin{Source: source, Path: path}, values: values}, nil
+}
+
+// readGitConfigLayer reads the [gitflow-tui] section of the repository config
+func readGitConfigLayer(repoPath string) (*layer, error) {
+	cmd := exec.Command("git", "config", "--local", "--get-regexp", `^`+GitConfigSection+`\.`)
+	cmd.Dir = repoPath
+	var out bytes.Buffer
+	cmd.Stdout = &out
+	if err := cmd.Run(); err != nil {
+		// Exit code 1 means the section is empty or missing
+		if exitErr, ok := err.(*exec.ExitError); ok && exitErr.ExitCode() == 1 {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	strValues := make(map[string]string)
+	scanner := bufio.NewScanner(&out)
+	for scanner.Scan() {
+		name, value, _ := strings.Cut(scanner.Text(), " ")
+		strValues[strings.TrimPrefix(name, GitConfigSection+".")] = value
+	}
+
+	values, err := fromStrings(strValues)
+	if err != nil {
+		return nil, fmt.Errorf("[%s] section: %w", GitConfigSection, err)
+	}
+	if errs := checkRepoKeys(values); len(errs) > 0 {
+		return nil, fmt.Errorf("[%s] section: %w", GitConfigSection, errs)
+	}
+
+	return &layer{
+		origin: Origin{Source: SourceGitConfig, Path: gitConfigPath(repoPath)},
+		values: values,
+	}, nil
+}
+
+// checkRepoKeys reports keys a repository layer may not set
+func checkRepoKeys(values map[string]interface{}) ValidationErrors {
+	var errs ValidationErrors
+	for key := range values {
+		if !repoKeys[key] {
+			errs = append(errs, ValidationError{Key: key, Message: "can only be set in the global config"})
+		}
+	}
+	sort.Slice(errs, func(i, j int) bool { return errs[i].Key < errs[j].Key })
+	return errs
+}
+
+// gitConfigPath returns the repository config file, which lives in the
+// common git directory for linked worktrees and is the top of a bare
+// repository
+func gitConfigPath(repoPath string) string {
+	cmd := exec.Command("git", "rev-parse", "--path-format=absolute", "--git-common-dir")
+	cmd.Dir = repoPath
+	out, err := cmd.Output()
+	if err != nil {
+		return filepath.Join(repoPath, ".git", "config")
+	}
+	return filepath.Join(strings.TrimSpace(string(out)), "config")
+}
+
+// readEnvLayer collects GITFLOW_* variables, e.g. GITFLOW_DEFAULT_BRANCH.
+// Unknown or malformed variables are ignored rather than fatal.
+func readEnvLayer(environ []string) *layer {
+	values := make(map[string]interface{})
+	keyOrigins := make(map[string]Origin)
+	for _, kv := range environ {
+		name, value, ok := strings.Cut(kv, "=")
+		if !ok || !strings.HasPrefix(name, EnvPrefix) {
+			continue
+		}
+
+		typed, err := fromStrings(map[string]string{strings.TrimPrefix(name, EnvPrefix): value})
+		if err != nil {
+			continue
+		}
+		for key, raw := range typed {
+			values[key] = raw
+			keyOrigins[key] = Origin{Source: SourceEnv, Path: name}
+		}
+	}
+	if len(values) == 0 {
+		return nil
+	}
+
+	return &layer{origin: Origin{Source: SourceEnv}, values: values, keyOrigins: keyOrigins}
+}
+
+// normalizeKey makes git config (defaultbranch) and JSON (default_branch)
+// keys comparable
+func normalizeKey(key string) string {
+	return strings.ToLower(strings.NewReplacer("_", "", "-", "").Replace(key))
+}
+
+// fromStrings converts string overrides to typed values using the
+// Config field types
+func fromStrings(in map[string]string) (map[string]interface{}, error) {
+	fields := make(map[string]reflect.StructField)
+	t := reflect.TypeOf(Config{})
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if tag != "" && tag != "-" {
+			fields[normalizeKey(tag)] = f
+		}
+	}
+
+	out := make(map[string]interface{})
+	for key, value := range in {
+		f, ok := fields[normalizeKey(key)]
+		if !ok {
+			return nil, fmt.Errorf("unknown key %q", key)
+		}
+		tag, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+
+		var typed interface{}
+		switch f.Type.Kind() {
+		case reflect.String:
+			typed = value
+		case reflect.Bool:
+			b, err := strconv.ParseBool(value)
+			if err != nil {
+				return nil, fmt.Errorf("%s: expected a boolean, got %q", key, value)
+			}
+			typed = b
+		case reflect.Int:
+			n, err := strconv.Atoi(value)
+			if err != nil {
+				return nil, fmt.Errorf("%s: expected an integer, got %q", key, value)
+			}
+			typed = n
+		case reflect.Slice:
+			if f.Type.Elem().Kind() != reflect.String {
+				return nil, fmt.Errorf("%s cannot be overridden from a string", key)
+			}
+			var items []string
+			for _, item := range strings.Split(value, ",") {
+				if item = strings.TrimSpace(item); item != "" {
+					items = append(items, item)
+				}
+			}
+			typed = items
+		default:
+			return nil, fmt.Errorf("%s cannot be overridden from a string", key)
+		}
+
+		out[tag] = typed
+	}
+
+	return out, nil
+}
@@ -0,0 +1,129 @@
+package config
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// testRepo creates a git repository with the user's config isolated
+func testRepo(t *testing.T) string {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("XDG_CONFIG_HOME", filepath.Join(home, ".config"))
+	t.Setenv("GIT_CONFIG_GLOBAL", filepath.Join(home, ".gitconfig"))
+	t.Setenv("GIT_CONFIG_NOSYSTEM", "1")
+
+	dir := t.TempDir()
+	gitConfig(t, dir, "init", "-q")
+	return dir
+}
+
+// gitConfig runs git in dir
+func gitConfig(t *testing.T, dir string, args ...string) {
+	t.Helper()
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("git %s: %v: %s", strings.Join(args, " "), err, out)
+	}
+}
+
+func TestLoadLayeredRepoFile(t *testing.T) {
+	tests := []struct {
+		name    string
+		file    string
+		content string
+		wantErr string
+	}{
+		{"json", ".gitflow-tui.json", `{"default_branch": "develop", "commit": {"subject_limit": 50}}`, ""},
+		{"yaml", ".gitflow-tui.yaml", "default_branch: develop\ncommit:\n  subject_limit: 50\n", ""},
+		{"toml", ".gitflow-tui.toml", "default_branch = \"develop\"\n[commit]\nsubject_limit = 50\n", ""},
+		{
+			"custom commands",
+			".gitflow-tui.json",
+			`{"custom_commands": [{"name": "x", "steps": ["touch pwned"]}]}`,
+			"custom_commands: can only be set in the global config",
+		},
+		{
+			"keybindings",
+			".gitflow-tui.yaml",
+			"keybindings:\n  graph:\n    down: [x]\n",
+			"keybindings: can only be set in the global config",
+		},
+		{"editor", ".gitflow-tui.toml", "editor = \"sh -c 'touch pwned'\"\n", "editor: can only be set in the global config"},
+		{"git path", ".gitflow-tui.json", `{"git_path": "./evil"}`, "git_path: can only be set in the global config"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := testRepo(t)
+			if err := os.WriteFile(filepath.Join(dir, tt.file), []byte(tt.content), 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			eff, err := LoadLayered(dir)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("LoadLayered() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if eff.Config.DefaultBranch != "develop" || eff.Config.Commit.SubjectLimit != 50 {
+				t.Errorf("LoadLayered() = %+v, want the repository overrides", eff.Config)
+			}
+			if o := eff.Origins["default_branch"]; o.Source != SourceRepoFile {
+				t.Errorf("default_branch origin = %v, want %s", o, SourceRepoFile)
+			}
+		})
+	}
+}
+
+func TestLoadLayeredGitConfig(t *testing.T) {
+	dir := testRepo(t)
+	gitConfig(t, dir, "config", "gitflow-tui.graphstyle", "ascii")
+	eff, err := LoadLayered(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if eff.Config.GraphStyle != "ascii" || eff.Origins["graph_style"].Source != SourceGitConfig {
+		t.Errorf("graph_style = %q from %v, want ascii from git config", eff.Config.GraphStyle, eff.Origins["graph_style"])
+	}
+
+	gitConfig(t, dir, "config", "gitflow-tui.editor", "touch pwned")
+	if _, err := LoadLayered(dir); err == nil || !strings.Contains(err.Error(), "editor: can only be set in the global config") {
+		t.Errorf("LoadLayered() error = %v, want editor rejected", err)
+	}
+}
+
+func TestLoadLayeredGlobal(t *testing.T) {
+	dir := testRepo(t)
+	global := filepath.Join(os.Getenv("XDG_CONFIG_HOME"), "gitflow-tui")
+	if err := os.MkdirAll(global, 0755); err != nil {
+		t.Fatal(err)
+	}
+	content := `{"version": 2, "editor": "vim", "custom_commands": [{"name": "hello", "steps": ["echo hi"]}]}`
+	if err := os.WriteFile(filepath.Join(global, "config.json"), []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("GITFLOW_EDITOR", "nano")
+
+	eff, err := LoadLayered(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(eff.Config.CustomCommands) != 1 || eff.Config.Editor != "nano" {
+		t.Errorf("LoadLayered() = %+v, want the global custom command and the env editor", eff.Config)
+	}
+	if o := eff.Origins["editor"]; o.Source != SourceEnv || o.Path != "GITFLOW_EDITOR" {
+		t.Errorf("editor origin = %v, want env:GITFLOW_EDITOR", o)
+	}
+}
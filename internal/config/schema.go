@@ -0,0 +1,354 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+)
+
+// SchemaVersion is the current configuration schema version
+const SchemaVersion = 2
+
+// configNames are the config file names tried in order
+var configNames = []string{"config.json", "config.yaml", "config.yml", "config.toml"}
+
+// ValidationError describes an invalid configuration value
+type ValidationError struct {
+	Key     string
+	Message string
+}
+
+func (e ValidationError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Key, e.Message)
+}
+
+// ValidationErrors collects all problems found in a configuration
+type ValidationErrors []ValidationError
+
+func (e ValidationErrors) Error() string {
+	var msgs []string
+	for _, err := range e {
+		msgs = append(msgs, err.Error())
+	}
+	return "invalid configuration:\n  " + strings.Join(msgs, "\n  ")
+}
+
+// migration upgrades a raw config from version From to From+1
+type migration struct {
+	From  int
+	Apply func(map[string]interface{})
+}
+
+// migrations are applied in order to bring old files up to SchemaVersion
+var migrations = []migration{
+	{
+		// Version 1 had no version key and wrote theme fields with Go names
+		From: 1,
+		Apply: func(raw map[string]interface{}) {
+			theme, ok := raw["theme"].(map[string]interface{})
+			if !ok {
+				return
+			}
+			lowerKeys(theme)
+			if colors, ok := theme["colors"].(map[string]interface{}); ok {
+				lowerKeys(colors)
+			}
+		},
+	},
+}
+
+// lowerKeys lowercases the keys of m in place
+func lowerKeys(m map[string]interface{}) {
+	for k, v := range m {
+		if lower := strings.ToLower(k); lower != k {
+			delete(m, k)
+			m[lower] = v
+		}
+	}
+}
+
+// findConfigFile returns the first existing config file in dir, or the
+// default JSON path when none exists
+func findConfigFile(dir string) (string, bool) {
+	for _, name := range configNames {
+		path := filepath.Join(dir, name)
+		if _, err := os.Stat(path); err == nil {
+			return path, true
+		}
+	}
+	return filepath.Join(dir, configNames[0]), false
+}
+
+// decodeFile reads a JSON, YAML or TOML file into a generic map
+func decodeFile(path string) (map[string]interface{}, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	raw := make(map[string]interface{})
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, &raw)
+	case ".toml":
+		err = toml.Unmarshal(data, &raw)
+	default:
+		err = json.Unmarshal(data, &raw)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	return raw, nil
+}
+
+// encodeFile writes v in the format given by the extension. Typed values
+// keep integers integers, which a generic map decoded from JSON would not.
+func encodeFile(path string, v interface{}) error {
+	var data []byte
+	var err error
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		data, err = yaml.Marshal(v)
+	case ".toml":
+		var buf bytes.Buffer
+		err = toml.NewEncoder(&buf).Encode(v)
+		data = buf.Bytes()
+	default:
+		data, err = json.MarshalIndent(v, "", "  ")
+	}
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, data, 0644)
+}
+
+// Migrate upgrades raw to SchemaVersion and reports whether it changed
+func Migrate(raw map[string]interface{}) (bool, error) {
+	version := 1
+	if v, ok := raw["version"]; ok {
+		n, err := strconv.Atoi(fmt.Sprint(v))
+		if err != nil {
+			return false, ValidationError{Key: "version", Message: fmt.Sprintf("expected an integer, got %v", v)}
+		}
+		version = n
+	}
+
+	if version > SchemaVersion {
+		return false, ValidationError{
+			Key:     "version",
+			Message: fmt.Sprintf("version %d is newer than supported version %d", version, SchemaVersion),
+		}
+	}
+
+	changed := false
+	for _, m := range migrations {
+		if m.From == version {
+			m.Apply(raw)
+			version++
+			changed = true
+		}
+	}
+	raw["version"] = version
+
+	return changed, nil
+}
+
+// mergeDefaults deep-merges raw over base
+func mergeDefaults(base, raw map[string]interface{}) {
+	for k, v := range raw {
+		if sub, ok := v.(map[string]interface{}); ok {
+			if baseSub, ok := base[k].(map[string]interface{}); ok {
+				mergeDefaults(baseSub, sub)
+				continue
+			}
+		}
+		base[k] = v
+	}
+}
+
+// toMap converts a value to a generic map through JSON
+func toMap(v interface{}) (map[string]interface{}, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	raw := make(map[string]interface{})
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, err
+	}
+	return raw, nil
+}
+
+// Decode migrates raw, merges it over the defaults and validates the result
+func Decode(raw map[string]interface{}) (*Config, error) {
+	if _, err := Migrate(raw); err != nil {
+		return nil, err
+	}
+
+	var errs ValidationErrors
+	errs = append(errs, checkKeys(raw, reflectKeys(Default()), "")...)
+
+	merged, err := toMap(Default())
+	if err != nil {
+		return nil, err
+	}
+	mergeDefaults(merged, raw)
+
+	data, err := json.Marshal(merged)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := &Config{}
+	if err := json.Unmarshal(data, cfg); err != nil {
+		var typeErr *json.UnmarshalTypeError
+		if errors.As(err, &typeErr) {
+			errs = append(errs, ValidationError{
+				Key:     typeErr.Field,
+				Message: fmt.Sprintf("expected %s, got %s", typeErr.Type, typeErr.Value),
+			})
+			return nil, errs
+		}
+		return nil, err
+	}
+
+	errs = append(errs, cfg.Validate()...)
+	if len(errs) > 0 {
+		return nil, errs
+	}
+
+	return cfg, nil
+}
+
+// reflectKeys returns the known key tree of a config
+func reflectKeys(cfg *Config) map[string]interface{} {
+	keys, _ := toMap(cfg)
+	return keys
+}
+
+// checkKeys reports keys in raw that are not part of the schema
+func checkKeys(raw, known map[string]interface{}, prefix string) ValidationErrors {
+	var errs ValidationErrors
+	for k, v := range raw {
+		knownV, ok := known[k]
+		if !ok {
+			errs = append(errs, ValidationError{Key: prefix + k, Message: "unknown key"})
+			continue
+		}
+		sub, isMap := v.(map[string]interface{})
+		knownSub, knownIsMap := knownV.(map[string]interface{})
+		// Empty maps in the defaults (keybindings) accept any keys
+		if isMap && knownIsMap && len(knownSub) > 0 {
+			errs = append(errs, checkKeys(sub, knownSub, prefix+k+".")...)
+		}
+	}
+	sort.Slice(errs, func(i, j int) bool { return errs[i].Key < errs[j].Key })
+	return errs
+}
+
+var colorPattern = regexp.MustCompile(`^(#[0-9A-Fa-f]{3}|#[0-9A-Fa-f]{6}|[0-9]{1,3})$`)
+
+// Validate checks the configuration values
+func (c *Config) Validate() ValidationErrors {
+	var errs ValidationErrors
+
+	oneOf := func(key, value string, allowed ...string) {
+		for _, a := range allowed {
+			if value == a {
+				return
+			}
+		}
+		errs = append(errs, ValidationError{
+			Key:     key,
+			Message: fmt.Sprintf("%q is not one of %s", value, strings.Join(allowed, ", ")),
+		})
+	}
+
+	seen := make(map[string]bool)
+	for i, cc := range c.CustomCommands {
+		key := fmt.Sprintf("custom_commands[%d]", i)
+		if cc.Name == "" {
+			errs = append(errs, ValidationError{Key: key + ".name", Message: "must not be empty"})
+		} else if seen[cc.Name] {
+			errs = append(errs, ValidationError{Key: key + ".name", Message: fmt.Sprintf("duplicate command %q", cc.Name)})
+		}
+		seen[cc.Name] = true
+		if len(cc.Steps) == 0 {
+			errs = append(errs, ValidationError{Key: key + ".steps", Message: "must have at least one step"})
+		}
+		if cc.Output != "" {
+			oneOf(key+".output", cc.Output, "none", "status", "panel")
+		}
+		for j, p := range cc.Prompts {
+			if p.Name == "" {
+				errs = append(errs, ValidationError{Key: fmt.Sprintf("%s.prompts[%d].name", key, j), Message: "must not be empty"})
+			}
+		}
+	}
+
+	oneOf("graph_style", c.GraphStyle, "ascii", "unicode", "compact")
+	oneOf("auth_method", c.AuthMethod, "ssh", "https", "token", "oauth")
+
+	if c.MaxRecentRepos < 1 || c.MaxRecentRepos > 100 {
+		errs = append(errs, ValidationError{
+			Key:     "max_recent_repos",
+			Message: fmt.Sprintf("must be between 1 and 100, got %d", c.MaxRecentRepos),
+		})
+	}
+	if c.Commit.SubjectLimit < 0 {
+		errs = append(errs, ValidationError{Key: "commit.subject_limit", Message: "must not be negative"})
+	}
+	if c.Commit.BodyLineLimit < 0 {
+		errs = append(errs, ValidationError{Key: "commit.body_line_limit", Message: "must not be negative"})
+	}
+	if c.GitPath == "" {
+		errs = append(errs, ValidationError{Key: "git_path", Message: "must not be empty"})
+	}
+	if c.Theme.Name == "" {
+		errs = append(errs, ValidationError{Key: "theme.name", Message: "must not be empty"})
+	}
+
+	palettes := []struct {
+		key      string
+		colors   ThemeColors
+		optional bool
+	}{
+		{"theme.colors", c.Theme.Colors, false},
+		{"theme.fallback_256", c.Theme.Fallback256, true},
+		{"theme.fallback_16", c.Theme.Fallback16, true},
+	}
+	for _, p := range palettes {
+		colors, _ := toMap(p.colors)
+		var names []string
+		for name := range colors {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			value := fmt.Sprint(colors[name])
+			if value == "" && p.optional {
+				continue
+			}
+			if !colorPattern.MatchString(value) {
+				errs = append(errs, ValidationError{
+					Key:     p.key + "." + name,
+					Message: fmt.Sprintf("%q is not a hex (#RRGGBB) or ANSI color", value),
+				})
+			}
+		}
+	}
+
+	return errs
+}
@@ -0,0 +1,240 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestMigrate(t *testing.T) {
+	tests := []struct {
+		name        string
+		raw         map[string]interface{}
+		wantChanged bool
+		want        map[string]interface{}
+		wantErr     string
+	}{
+		{
+			name: "v1 theme fields",
+			raw: map[string]interface{}{
+				"theme": map[string]interface{}{
+					"Name":   "nord",
+					"Colors": map[string]interface{}{"Primary": "#88C0D0"},
+				},
+			},
+			wantChanged: true,
+			want: map[string]interface{}{
+				"version": 2,
+				"theme": map[string]interface{}{
+					"name":   "nord",
+					"colors": map[string]interface{}{"primary": "#88C0D0"},
+				},
+			},
+		},
+		{
+			name:        "v1 without theme",
+			raw:         map[string]interface{}{"editor": "vim"},
+			wantChanged: true,
+			want:        map[string]interface{}{"version": 2, "editor": "vim"},
+		},
+		{
+			name: "current",
+			raw:  map[string]interface{}{"version": float64(2), "theme": map[string]interface{}{"Name": "kept"}},
+			want: map[string]interface{}{"version": 2, "theme": map[string]interface{}{"Name": "kept"}},
+		},
+		{
+			name:    "newer",
+			raw:     map[string]interface{}{"version": 3},
+			wantErr: "version: version 3 is newer than supported version 2",
+		},
+		{
+			name:    "not a number",
+			raw:     map[string]interface{}{"version": "two"},
+			wantErr: "version: expected an integer, got two",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			changed, err := Migrate(tt.raw)
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr {
+					t.Fatalf("Migrate() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if changed != tt.wantChanged {
+				t.Errorf("Migrate() changed = %v, want %v", changed, tt.wantChanged)
+			}
+			if !reflect.DeepEqual(tt.raw, tt.want) {
+				t.Errorf("Migrate() = %v, want %v", tt.raw, tt.want)
+			}
+		})
+	}
+}
+
+func TestDecode(t *testing.T) {
+	tests := []struct {
+		name    string
+		file    string
+		content string
+		check   func(*Config) bool
+		wantErr []string
+	}{
+		{
+			name:    "v1 json",
+			file:    "config.json",
+			content: `{"editor": "vim", "theme": {"Name": "nord", "Colors": {"Primary": "#88C0D0"}}}`,
+			check: func(c *Config) bool {
+				return c.Version == 2 && c.Editor == "vim" && c.Theme.Name == "nord" &&
+					c.Theme.Colors.Primary == "#88C0D0" &&
+					// Unset colors and keys keep their defaults
+					c.Theme.Colors.Error == DefaultTheme.Colors.Error && c.GraphStyle == "unicode"
+			},
+		},
+		{
+			name:    "yaml",
+			file:    "config.yaml",
+			content: "version: 2\ngraph_style: ascii\ncommit:\n  subject_limit: 50\n  types: [feat, fix]\n",
+			check: func(c *Config) bool {
+				return c.GraphStyle == "ascii" && c.Commit.SubjectLimit == 50 &&
+					reflect.DeepEqual(c.Commit.Types, []string{"feat", "fix"}) && c.MaxRecentRepos == Default().MaxRecentRepos
+			},
+		},
+		{
+			name: "toml",
+			file: "config.toml",
+			content: "version = 2\nmax_recent_repos = 5\n\n[[custom_commands]]\nname = \"push-lease\"\n" +
+				"steps = [\"git push --force-with-lease\"]\n\n[keybindings.graph]\ntop = [\"g g\"]\n",
+			check: func(c *Config) bool {
+				return c.MaxRecentRepos == 5 && len(c.CustomCommands) == 1 &&
+					c.CustomCommands[0].Name == "push-lease" &&
+					reflect.DeepEqual(c.Keybindings["graph"]["top"], []string{"g g"})
+			},
+		},
+		{
+			name:    "unknown keys",
+			file:    "config.json",
+			content: `{"version": 2, "colour": "red", "theme": {"colors": {"pink": "#fff"}}}`,
+			wantErr: []string{"colour: unknown key", "theme.colors.pink: unknown key"},
+		},
+		{
+			name:    "type error",
+			file:    "config.yaml",
+			content: "version: 2\ncommit:\n  subject_limit: fifty\n",
+			wantErr: []string{"commit.subject_limit: expected int, got string"},
+		},
+		{
+			name:    "invalid values",
+			file:    "config.toml",
+			content: "version = 2\ngraph_style = \"fancy\"\nmax_recent_repos = 0\n",
+			wantErr: []string{
+				`graph_style: "fancy" is not one of ascii, unicode, compact`,
+				"max_recent_repos: must be between 1 and 100, got 0",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), tt.file)
+			if err := os.WriteFile(path, []byte(tt.content), 0644); err != nil {
+				t.Fatal(err)
+			}
+			raw, err := decodeFile(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			cfg, err := Decode(raw)
+			if len(tt.wantErr) > 0 {
+				if err == nil {
+					t.Fatalf("Decode() = %+v, want errors %q", cfg, tt.wantErr)
+				}
+				for _, want := range tt.wantErr {
+					if !strings.Contains(err.Error(), want) {
+						t.Errorf("Decode() error = %v, want %q", err, want)
+					}
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !tt.check(cfg) {
+				t.Errorf("Decode() = %+v", cfg)
+			}
+		})
+	}
+}
+
+func TestCheckKeys(t *testing.T) {
+	known := reflectKeys(Default())
+	raw := map[string]interface{}{
+		"editor":      "vim",
+		"zzz":         1,
+		"keybindings": map[string]interface{}{"anything": map[string]interface{}{"goes": []interface{}{"x"}}},
+		"commit":      map[string]interface{}{"subject_limit": 50, "sign": true},
+		"theme":       map[string]interface{}{"colors": map[string]interface{}{"primary": "#fff", "pink": "#f0f"}},
+	}
+	var got []string
+	for _, err := range checkKeys(raw, known, "") {
+		got = append(got, err.Key)
+	}
+	want := []string{"commit.sign", "theme.colors.pink", "zzz"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("checkKeys() = %v, want %v", got, want)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(*Config)
+		want   []string
+	}{
+		{"default", func(*Config) {}, nil},
+		{"graph style", func(c *Config) { c.GraphStyle = "fancy" }, []string{"graph_style"}},
+		{"auth method", func(c *Config) { c.AuthMethod = "password" }, []string{"auth_method"}},
+		{"recent repos", func(c *Config) { c.MaxRecentRepos = 101 }, []string{"max_recent_repos"}},
+		{"commit limits", func(c *Config) { c.Commit.SubjectLimit, c.Commit.BodyLineLimit = -1, -1 },
+			[]string{"commit.subject_limit", "commit.body_line_limit"}},
+		{"git path", func(c *Config) { c.GitPath = "" }, []string{"git_path"}},
+		{"theme name", func(c *Config) { c.Theme.Name = "" }, []string{"theme.name"}},
+		{"colors", func(c *Config) {
+			c.Theme.Colors.Primary = "green"
+			c.Theme.Colors.Border = "#12"
+			c.Theme.Fallback16.Error = "999x"
+		}, []string{"theme.colors.border", "theme.colors.primary", "theme.fallback_16.error"}},
+		{"empty fallback", func(c *Config) { c.Theme.Fallback256 = ThemeColors{} }, nil},
+		{"custom commands", func(c *Config) {
+			c.CustomCommands = []CustomCommand{
+				{Name: "a", Steps: []string{"true"}, Output: "popup"},
+				{Name: "a", Prompts: []CommandInput{{Label: "x"}}},
+				{Steps: []string{"true"}},
+			}
+		}, []string{
+			"custom_commands[0].output",
+			"custom_commands[1].name",
+			"custom_commands[1].steps",
+			"custom_commands[1].prompts[0].name",
+			"custom_commands[2].name",
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := Default()
+			tt.modify(cfg)
+			var got []string
+			for _, err := range cfg.Validate() {
+				got = append(got, err.Key)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Validate() keys = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
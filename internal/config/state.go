@@ -0,0 +1,62 @@
+package config
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+)
+
+// MaxRecentCommands limits the remembered palette history
+const MaxRecentCommands = 10
+
+// State holds data the application remembers between runs. Unlike
+// Config it is written by the application, never by the user.
+type State struct {
+	RecentCommands []string `json:"recent_commands"`
+}
+
+// statePath returns the state file path
+func statePath() string {
+	return filepath.Join(GetConfigDir(), "state.json")
+}
+
+// LoadState loads the application state, returning empty state when missing
+func LoadState() (*State, error) {
+	data, err := os.ReadFile(statePath())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return &State{}, nil
+		}
+		return nil, err
+	}
+
+	var state State
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, err
+	}
+	return &state, nil
+}
+
+// Save writes the application state
+func (s *State) Save() error {
+	if err := os.MkdirAll(GetConfigDir(), 0755); err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(statePath(), data, 0644)
+}
+
+// AddRecentCommand moves name to the front of the recent commands
+func (s *State) AddRecentCommand(name string) {
+	recent := []string{name}
+	for _, c := range s.RecentCommands {
+		if c != name && len(recent) < MaxRecentCommands {
+			recent = append(recent, c)
+		}
+	}
+	s.RecentCommands = recent
+}
@@ -0,0 +1,326 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// AutoTheme selects the dark or light bundled theme from the terminal background
+const AutoTheme = "auto"
+
+// ColorDepth is the number of colors the terminal supports
+type ColorDepth int
+
+const (
+	TrueColor ColorDepth = iota
+	ANSI256
+	ANSI16
+)
+
+// Bundled themes; fallbacks use ANSI color numbers
+var (
+	LightTheme = Theme{
+		Name: "gitflow-light",
+		Colors: ThemeColors{
+			Primary:    "#00875F",
+			Secondary:  "#00796B",
+			Tertiary:   "#0062B1",
+			Accent:     "#00838F",
+			Highlight:  "#D84315",
+			Background: "#FFFFFF",
+			Foreground: "#1F2328",
+			Success:    "#1A7F37",
+			Warning:    "#9A6700",
+			Error:      "#CF222E",
+			Muted:      "#656D76",
+			Border:     "#D0D7DE",
+		},
+		Fallback256: ThemeColors{
+			Primary: "29", Secondary: "30", Tertiary: "25", Accent: "31", Highlight: "166",
+			Background: "231", Foreground: "235", Success: "28", Warning: "136", Error: "160",
+			Muted: "243", Border: "252",
+		},
+		Fallback16: ThemeColors{
+			Primary: "2", Secondary: "6", Tertiary: "4", Accent: "6", Highlight: "3",
+			Background: "15", Foreground: "0", Success: "2", Warning: "3", Error: "1",
+			Muted: "8", Border: "7",
+		},
+	}
+
+	SolarizedDarkTheme = Theme{
+		Name: "solarized-dark",
+		Colors: ThemeColors{
+			Primary:    "#859900",
+			Secondary:  "#2AA198",
+			Tertiary:   "#268BD2",
+			Accent:     "#6C71C4",
+			Highlight:  "#CB4B16",
+			Background: "#002B36",
+			Foreground: "#839496",
+			Success:    "#859900",
+			Warning:    "#B58900",
+			Error:      "#DC322F",
+			Muted:      "#586E75",
+			Border:     "#073642",
+		},
+		Fallback256: ThemeColors{
+			Primary: "64", Secondary: "37", Tertiary: "33", Accent: "61", Highlight: "166",
+			Background: "234", Foreground: "244", Success: "64", Warning: "136", Error: "160",
+			Muted: "240", Border: "235",
+		},
+		Fallback16: ThemeColors{
+			Primary: "2", Secondary: "6", Tertiary: "4", Accent: "13", Highlight: "9",
+			Background: "8", Foreground: "12", Success: "2", Warning: "3", Error: "1",
+			Muted: "10", Border: "0",
+		},
+	}
+
+	SolarizedLightTheme = Theme{
+		Name: "solarized-light",
+		Colors: ThemeColors{
+			Primary:    "#859900",
+			Secondary:  "#2AA198",
+			Tertiary:   "#268BD2",
+			Accent:     "#6C71C4",
+			Highlight:  "#CB4B16",
+			Background: "#FDF6E3",
+			Foreground: "#657B83",
+			Success:    "#859900",
+			Warning:    "#B58900",
+			Error:      "#DC322F",
+			Muted:      "#93A1A1",
+			Border:     "#EEE8D5",
+		},
+		Fallback256: ThemeColors{
+			Primary: "64", Secondary: "37", Tertiary: "33", Accent: "61", Highlight: "166",
+			Background: "230", Foreground: "241", Success: "64", Warning: "136", Error: "160",
+			Muted: "245", Border: "254",
+		},
+		Fallback16: ThemeColors{
+			Primary: "2", Secondary: "6", Tertiary: "4", Accent: "13", Highlight: "9",
+			Background: "15", Foreground: "11", Success: "2", Warning: "3", Error: "1",
+			Muted: "14", Border: "7",
+		},
+	}
+
+	HighContrastTheme = Theme{
+		Name: "high-contrast",
+		Colors: ThemeColors{
+			Primary:    "#00FF00",
+			Secondary:  "#00FFFF",
+			Tertiary:   "#5FAFFF",
+			Accent:     "#FFFF00",
+			Highlight:  "#FF8700",
+			Background: "#000000",
+			Foreground: "#FFFFFF",
+			Success:    "#00FF00",
+			Warning:    "#FFFF00",
+			Error:      "#FF0000",
+			Muted:      "#D0D0D0",
+			Border:     "#FFFFFF",
+		},
+		Fallback256: ThemeColors{
+			Primary: "46", Secondary: "51", Tertiary: "75", Accent: "226", Highlight: "208",
+			Background: "16", Foreground: "231", Success: "46", Warning: "226", Error: "196",
+			Muted: "252", Border: "231",
+		},
+		Fallback16: ThemeColors{
+			Primary: "10", Secondary: "14", Tertiary: "12", Accent: "11", Highlight: "11",
+			Background: "0", Foreground: "15", Success: "10", Warning: "11", Error: "9",
+			Muted: "7", Border: "15",
+		},
+	}
+
+	// ColorBlindTheme uses the Okabe-Ito palette, which stays
+	// distinguishable under the common forms of color vision deficiency
+	ColorBlindTheme = Theme{
+		Name: "colorblind",
+		Colors: ThemeColors{
+			Primary:    "#009E73",
+			Secondary:  "#56B4E9",
+			Tertiary:   "#0072B2",
+			Accent:     "#CC79A7",
+			Highlight:  "#E69F00",
+			Background: "#0D1117",
+			Foreground: "#E6EDF3",
+			Success:    "#0072B2",
+			Warning:    "#F0E442",
+			Error:      "#D55E00",
+			Muted:      "#8B949E",
+			Border:     "#30363D",
+		},
+		Fallback256: ThemeColors{
+			Primary: "36", Secondary: "74", Tertiary: "25", Accent: "175", Highlight: "214",
+			Background: "233", Foreground: "255", Success: "25", Warning: "227", Error: "166",
+			Muted: "246", Border: "237",
+		},
+		Fallback16: ThemeColors{
+			Primary: "2", Secondary: "14", Tertiary: "4", Accent: "5", Highlight: "3",
+			Background: "0", Foreground: "15", Success: "4", Warning: "11", Error: "1",
+			Muted: "8", Border: "8",
+		},
+	}
+)
+
+// BundledThemes returns the themes shipped with the application
+func BundledThemes() []Theme {
+	return []Theme{
+		DefaultTheme,
+		LightTheme,
+		SolarizedDarkTheme,
+		SolarizedLightTheme,
+		HighContrastTheme,
+		ColorBlindTheme,
+	}
+}
+
+// ThemesDir returns the directory user theme files are loaded from
+func ThemesDir() string {
+	return filepath.Join(GetConfigDir(), "themes")
+}
+
+// LoadThemeFile reads a JSON, YAML or TOML theme file. The theme name
+// defaults to the file name.
+func LoadThemeFile(path string) (Theme, error) {
+	raw, err := decodeFile(path)
+	if err != nil {
+		return Theme{}, err
+	}
+
+	data, err := json.Marshal(raw)
+	if err != nil {
+		return Theme{}, err
+	}
+
+	var theme Theme
+	if err := json.Unmarshal(data, &theme); err != nil {
+		return Theme{}, fmt.Errorf("%s: %w", path, err)
+	}
+	if theme.Name == "" {
+		theme.Name = strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	}
+
+	// Colors missing from the file fall back to the default theme
+	theme.Colors = fillColors(theme.Colors, DefaultTheme.Colors)
+
+	cfg := Default()
+	cfg.Theme = theme
+	if errs := cfg.Validate(); len(errs) > 0 {
+		return Theme{}, fmt.Errorf("%s: %w", path, errs)
+	}
+
+	return theme, nil
+}
+
+// fillColors returns c with empty entries taken from base
+func fillColors(c, base ThemeColors) ThemeColors {
+	cm, _ := toMap(c)
+	bm, _ := toMap(base)
+	for k, v := range cm {
+		if v == "" {
+			cm[k] = bm[k]
+		}
+	}
+	data, _ := json.Marshal(cm)
+	var out ThemeColors
+	_ = json.Unmarshal(data, &out)
+	return out
+}
+
+// Themes returns bundled themes followed by user themes from ThemesDir.
+// A user theme with a bundled name replaces the bundled one. Files that
+// fail to load are reported but do not prevent the others from loading.
+func Themes() ([]Theme, []error) {
+	themes := BundledThemes()
+	index := make(map[string]int)
+	for i, t := range themes {
+		index[t.Name] = i
+	}
+
+	entries, err := os.ReadDir(ThemesDir())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return themes, nil
+		}
+		return themes, []error{err}
+	}
+
+	var names []string
+	for _, e := range entries {
+		switch strings.ToLower(filepath.Ext(e.Name())) {
+		case ".json", ".yaml", ".yml", ".toml":
+			names = append(names, e.Name())
+		}
+	}
+	sort.Strings(names)
+
+	var errs []error
+	for _, name := range names {
+		theme, err := LoadThemeFile(filepath.Join(ThemesDir(), name))
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if i, ok := index[theme.Name]; ok {
+			themes[i] = theme
+		} else {
+			index[theme.Name] = len(themes)
+			themes = append(themes, theme)
+		}
+	}
+
+	return themes, errs
+}
+
+// ResolveTheme returns the theme to use for the configured one. "auto"
+// picks the bundled dark or light theme and keeps the name "auto"; a known
+// theme name selects that palette unless the configured colors were
+// customized; any other name keeps the configured colors as a custom theme.
+func ResolveTheme(configured Theme, dark bool) Theme {
+	name := configured.Name
+	if name == AutoTheme {
+		if dark {
+			name = DefaultTheme.Name
+		} else {
+			name = LightTheme.Name
+		}
+	}
+
+	themes, _ := Themes()
+	for _, t := range themes {
+		if t.Name != name {
+			continue
+		}
+		// Colors of their own, e.g. a customized v1 "gitflow" theme, win
+		if configured.Name != AutoTheme && !stockColors(configured.Colors, t) {
+			return configured
+		}
+		t.Name = configured.Name
+		return t
+	}
+	return configured
+}
+
+// stockColors reports whether c are not customized: unset, the default
+// palette that fills in missing colors, or the palette of theme t
+func stockColors(c ThemeColors, t Theme) bool {
+	return c == ThemeColors{} || c == DefaultTheme.Colors || c == t.Colors
+}
+
+// ForDepth returns the palette to use on a terminal with the given color
+// depth, preferring the theme's explicit fallbacks. Colors without a
+// fallback are left for the renderer to approximate.
+func (t Theme) ForDepth(depth ColorDepth) ThemeColors {
+	switch depth {
+	case ANSI256:
+		return fillColors(t.Fallback256, t.Colors)
+	case ANSI16:
+		return fillColors(t.Fallback16, fillColors(t.Fallback256, t.Colors))
+	default:
+		return t.Colors
+	}
+}
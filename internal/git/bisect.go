@@ -0,0 +1,160 @@
+package git
+
+import (
+	"io"
+	"math/bits"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Bisect terms accepted by BisectMark
+const (
+	BisectGood = "good"
+	BisectBad  = "bad"
+	BisectSkip = "skip"
+)
+
+// Bisect is the state of a bisect session
+type Bisect struct {
+	Bad       string // Newest known bad commit; empty until one is marked
+	Good      []string
+	Skipped   []string
+	Current   string   // Commit checked out for testing
+	Remaining []string // Commits that may still be the first bad one, Bad included
+	Culprit   string   // First bad commit, once found
+}
+
+// Mark returns the term a commit was marked with, or "" when unmarked
+func (b *Bisect) Mark(hash string) string {
+	switch {
+	case hash == b.Bad:
+		return BisectBad
+	case contains(b.Good, hash):
+		return BisectGood
+	case contains(b.Skipped, hash):
+		return BisectSkip
+	}
+	return ""
+}
+
+// Candidate reports whether hash may still be the first bad commit
+func (b *Bisect) Candidate(hash string) bool {
+	return contains(b.Remaining, hash)
+}
+
+// Left returns the number of commits left to test and roughly how many
+// steps that takes
+func (b *Bisect) Left() (commits, steps int) {
+	for _, hash := range b.Remaining {
+		if hash != b.Bad && !contains(b.Skipped, hash) {
+			commits++
+		}
+	}
+	return commits, bits.Len(uint(commits))
+}
+
+func contains(hashes []string, hash string) bool {
+	for _, h := range hashes {
+		if h == hash {
+			return true
+		}
+	}
+	return false
+}
+
+// GetBisect returns the state of the bisect session, or nil when not
+// bisecting
+func (g *Git) GetBisect() (*Bisect, error) {
+	out, err := g.Execute("rev-parse", "--git-path", "BISECT_START")
+	if err != nil {
+		return nil, err
+	}
+	start := strings.TrimSpace(out)
+	if !filepath.IsAbs(start) {
+		start = filepath.Join(g.repoPath, start)
+	}
+	if _, err := os.Stat(start); err != nil {
+		return nil, nil
+	}
+
+	out, err = g.Execute("for-each-ref", "--format=%(refname) %(objectname)", "refs/bisect/")
+	if err != nil {
+		return nil, err
+	}
+	b := &Bisect{}
+	for _, line := range strings.Split(out, "\n") {
+		ref, hash, found := strings.Cut(line, " ")
+		if !found {
+			continue
+		}
+		ref = strings.TrimPrefix(ref, "refs/bisect/")
+		switch {
+		case ref == BisectBad:
+			b.Bad = hash
+		case strings.HasPrefix(ref, BisectGood+"-"):
+			b.Good = append(b.Good, hash)
+		case strings.HasPrefix(ref, BisectSkip+"-"):
+			b.Skipped = append(b.Skipped, hash)
+		}
+	}
+
+	if out, err := g.Execute("rev-parse", "HEAD"); err == nil {
+		b.Current = strings.TrimSpace(out)
+	}
+
+	if b.Bad != "" && len(b.Good) > 0 {
+		args := append([]string{"rev-list", b.Bad, "--not"}, b.Good...)
+		out, err := g.Execute(args...)
+		if err != nil {
+			return nil, err
+		}
+		b.Remaining = strings.Fields(out)
+		if len(b.Remaining) == 1 {
+			b.Culprit = b.Bad
+		}
+	}
+
+	return b, nil
+}
+
+// BisectStart starts a bisect session; commits are marked afterwards
+func (g *Git) BisectStart() error {
+	_, err := g.Execute("bisect", "start")
+	return err
+}
+
+// BisectMark marks rev, or the checked-out commit when rev is empty, as
+// good, bad or skipped. It returns git's first line of output, e.g.
+// "Bisecting: 3 revisions left to test after this (roughly 2 steps)".
+func (g *Git) BisectMark(term, rev string) (string, error) {
+	args := []string{"bisect", term}
+	if rev != "" {
+		args = append(args, rev)
+	}
+	out, err := g.Execute(args...)
+	if err != nil {
+		return "", err
+	}
+	line, _, _ := strings.Cut(strings.TrimSpace(out), "\n")
+	return line, nil
+}
+
+// BisectRun runs command through the shell on each commit to test, exit
+// code 0 meaning good, 125 skip and anything else up to 127 bad, and
+// writes the output to w
+func (g *Git) BisectRun(w io.Writer, command string) error {
+	return g.ExecuteTo(w, "bisect", "run", "sh", "-c", command)
+}
+
+// BisectLog returns the commands of the bisect session so far
+func (g *Git) BisectLog() (string, error) {
+	return g.Execute("bisect", "log")
+}
+
+// BisectReset ends the bisect session and checks out the branch it was
+// started from
+func (g *Git) BisectReset() error {
+	_, err := g.Execute("bisect", "reset")
+	return err
+}
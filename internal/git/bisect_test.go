@@ -0,0 +1,137 @@
+package git
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestBisectLeft(t *testing.T) {
+	tests := []struct {
+		bisect         Bisect
+		commits, steps int
+	}{
+		{Bisect{}, 0, 0},
+		{Bisect{Bad: "e", Remaining: []string{"e"}}, 0, 0},
+		{Bisect{Bad: "e", Remaining: []string{"e", "d"}}, 1, 1},
+		{Bisect{Bad: "e", Remaining: []string{"e", "d", "c", "b", "a"}}, 4, 3},
+		{Bisect{Bad: "e", Remaining: []string{"e", "d", "c", "b", "a"}, Skipped: []string{"c", "x"}}, 3, 2},
+		{Bisect{Bad: "e", Remaining: []string{"e", "d"}, Skipped: []string{"d"}}, 0, 0},
+	}
+	for _, tt := range tests {
+		if commits, steps := tt.bisect.Left(); commits != tt.commits || steps != tt.steps {
+			t.Errorf("%+v.Left() = %d, %d, want %d, %d", tt.bisect, commits, steps, tt.commits, tt.steps)
+		}
+	}
+}
+
+// bisectRepo creates a repository whose first commit is good and whose
+// fourth commit introduced a bug, returning the commits oldest first
+func bisectRepo(t *testing.T) (*Git, []string) {
+	t.Helper()
+	g := testRepo(t)
+	commits := []string{strings.TrimSpace(run(t, g, "rev-parse", "HEAD"))}
+	for i := 2; i <= 6; i++ {
+		commits = append(commits, commitFile(t, g, "a.txt", fmt.Sprintf("%d\n", i), fmt.Sprintf("commit %d", i)))
+	}
+	return g, commits
+}
+
+func TestGetBisect(t *testing.T) {
+	g, commits := bisectRepo(t)
+	bad := commits[3]
+
+	if b, err := g.GetBisect(); b != nil || err != nil {
+		t.Fatalf("GetBisect() before starting = %+v, %v, want nil", b, err)
+	}
+	if err := g.BisectStart(); err != nil {
+		t.Fatal(err)
+	}
+	if b, err := g.GetBisect(); err != nil || b == nil || b.Bad != "" || len(b.Good) != 0 || b.Remaining != nil {
+		t.Fatalf("GetBisect() after starting = %+v, %v, want an empty session", b, err)
+	}
+
+	if _, err := g.BisectMark(BisectBad, ""); err != nil {
+		t.Fatal(err)
+	}
+	out, err := g.BisectMark(BisectGood, commits[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if out == "" {
+		t.Error("BisectMark() returned no progress line")
+	}
+	b, err := g.GetBisect()
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantRemaining := []string{commits[5], commits[4], commits[3], commits[2], commits[1]}
+	if b.Bad != commits[5] || !reflect.DeepEqual(b.Good, commits[:1]) || !reflect.DeepEqual(b.Remaining, wantRemaining) {
+		t.Errorf("GetBisect() = %+v, want %s bad, %s good and %v remaining", b, commits[5], commits[0], wantRemaining)
+	}
+	if b.Current == "" || !b.Candidate(b.Current) || b.Culprit != "" {
+		t.Errorf("GetBisect() = %+v, want a candidate checked out and no culprit yet", b)
+	}
+	if n, steps := b.Left(); n != 4 || steps != 3 {
+		t.Errorf("Left() = %d, %d, want 4, 3", n, steps)
+	}
+
+	skipped := b.Current
+	if _, err := g.BisectMark(BisectSkip, ""); err != nil {
+		t.Fatal(err)
+	}
+	if b, err = g.GetBisect(); err != nil {
+		t.Fatal(err)
+	}
+	if b.Mark(skipped) != BisectSkip || b.Current == skipped {
+		t.Errorf("GetBisect() after skip = %+v, want %s skipped and another commit checked out", b, skipped)
+	}
+	if n, _ := b.Left(); n != 3 {
+		t.Errorf("Left() after skip = %d, want 3", n)
+	}
+
+	// Start over, as skipping the culprit would leave it ambiguous
+	if err := g.BisectReset(); err != nil {
+		t.Fatal(err)
+	}
+	if err := g.BisectStart(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := g.BisectMark(BisectBad, commits[5]); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := g.BisectMark(BisectGood, commits[0]); err != nil {
+		t.Fatal(err)
+	}
+	if b, err = g.GetBisect(); err != nil {
+		t.Fatal(err)
+	}
+
+	// Test the checked-out commits until the culprit is found
+	for i := 0; b.Culprit == "" && i < len(commits); i++ {
+		term := BisectGood
+		if b.Current == bad || b.Current == commits[4] || b.Current == commits[5] {
+			term = BisectBad
+		}
+		if _, err := g.BisectMark(term, ""); err != nil {
+			t.Fatal(err)
+		}
+		if b, err = g.GetBisect(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if b.Culprit != bad || !reflect.DeepEqual(b.Remaining, []string{bad}) {
+		t.Errorf("GetBisect() = %+v, want culprit %s", b, bad)
+	}
+	if n, steps := b.Left(); n != 0 || steps != 0 {
+		t.Errorf("Left() with the culprit found = %d, %d, want 0, 0", n, steps)
+	}
+
+	if err := g.BisectReset(); err != nil {
+		t.Fatal(err)
+	}
+	if b, err := g.GetBisect(); b != nil || err != nil {
+		t.Errorf("GetBisect() after reset = %+v, %v, want nil", b, err)
+	}
+}
@@ -0,0 +1,180 @@
+package git
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// BlameLine is one line of a file with the commit that last changed it
+type BlameLine struct {
+	Hash      string
+	ShortHash string
+	Author    string
+	Email     string
+	Date      time.Time
+	Summary   string
+	Path      string // Path of the file in Hash
+	Line      int    // Line number in Hash
+	Text      string
+	Boundary  bool // Hash is the first commit git looked at
+
+	// The commit before Hash and the file's path there, empty when Hash
+	// added the file
+	Previous     string
+	PreviousPath string
+}
+
+// FileRevision is a commit that changed a file, with the file's path in it
+type FileRevision struct {
+	Commit  Commit
+	Status  string // A, M, D or R
+	Path    string
+	OldPath string // Set for renames
+}
+
+// Blame returns who last changed each line of path as of rev; an empty
+// rev blames the working tree
+func (g *Git) Blame(rev, path string) ([]BlameLine, error) {
+	args := []string{"blame", "--porcelain"}
+	if rev != "" {
+		args = append(args, rev)
+	}
+	out, err := g.Execute(append(args, "--", path)...)
+	if err != nil {
+		return nil, err
+	}
+	return parseBlame(out), nil
+}
+
+// parseBlame parses git blame --porcelain output
+func parseBlame(out string) []BlameLine {
+	// Commit details are only given the first time a commit appears
+	commits := make(map[string]*BlameLine)
+	var lines []BlameLine
+	var cur *BlameLine
+
+	for _, line := range strings.Split(out, "\n") {
+		if strings.HasPrefix(line, "\t") {
+			if cur != nil {
+				l := *cur
+				l.Text = line[1:]
+				lines = append(lines, l)
+			}
+			continue
+		}
+
+		key, value, _ := strings.Cut(line, " ")
+		if (len(key) == 40 || len(key) == 64) && isHex(key) {
+			info, ok := commits[key]
+			if !ok {
+				info = &BlameLine{Hash: key, ShortHash: key[:7]}
+				commits[key] = info
+			}
+			fields := strings.Fields(value)
+			if len(fields) >= 1 {
+				info.Line, _ = strconv.Atoi(fields[0])
+			}
+			cur = info
+			continue
+		}
+		if cur == nil {
+			continue
+		}
+
+		switch key {
+		case "author":
+			cur.Author = value
+		case "author-mail":
+			cur.Email = strings.Trim(value, "<>")
+		case "author-time":
+			if ts, err := strconv.ParseInt(value, 10, 64); err == nil {
+				cur.Date = time.Unix(ts, 0)
+			}
+		case "summary":
+			cur.Summary = value
+		case "filename":
+			cur.Path = value
+		case "boundary":
+			cur.Boundary = true
+		case "previous":
+			cur.Previous, cur.PreviousPath, _ = strings.Cut(value, " ")
+		}
+	}
+
+	return lines
+}
+
+// FileHistory returns the commits that changed path, newest first,
+// following renames
+func (g *Git) FileHistory(path string) ([]FileRevision, error) {
+	format := "%x1e%H%x00%h%x00%an%x00%ae%x00%at%x00%P%x00%s"
+	out, err := g.Execute("log", "--follow", "-M", "--name-status", "--format="+format, "--", path)
+	if err != nil {
+		return nil, err
+	}
+
+	var revisions []FileRevision
+	for _, record := range strings.Split(out, "\x1e") {
+		header, files, _ := strings.Cut(record, "\n")
+		parts := strings.SplitN(header, "\x00", 7)
+		if len(parts) < 7 {
+			continue
+		}
+
+		rev := FileRevision{Commit: Commit{
+			Hash:      parts[0],
+			ShortHash: parts[1],
+			Author:    parts[2],
+			Email:     parts[3],
+			Parents:   strings.Fields(parts[5]),
+			Message:   parts[6],
+		}}
+		if ts, err := strconv.ParseInt(parts[4], 10, 64); err == nil {
+			rev.Commit.Date = time.Unix(ts, 0)
+		}
+
+		// --follow limits the status lines to the followed file
+		for _, line := range strings.Split(files, "\n") {
+			fields := strings.Split(line, "\t")
+			if len(fields) < 2 || fields[0] == "" {
+				continue
+			}
+			rev.Status = fields[0][:1]
+			rev.Path = fields[len(fields)-1]
+			if len(fields) == 3 {
+				rev.OldPath = fields[1]
+			}
+			break
+		}
+		revisions = append(revisions, rev)
+	}
+
+	return revisions, nil
+}
+
+// ShowCommit returns a commit's details, stat and patch
+func (g *Git) ShowCommit(hash string) (string, error) {
+	return g.Execute("show", "--no-color", "--submodule=log", "--stat", "--patch", "--format=fuller", hash)
+}
+
+// ShowFile returns the changes hash made to the given paths; pass both
+// paths of a rename to see it as one
+func (g *Git) ShowFile(hash string, paths ...string) (string, error) {
+	if len(paths) == 0 {
+		return "", fmt.Errorf("no path given")
+	}
+	args := append([]string{"show", "--no-color", "--submodule=log", "-M", "--format=%h %s%n", hash, "--"}, paths...)
+	return g.Execute(args...)
+}
+
+// isHex reports whether s only contains hex digits
+func isHex(s string) bool {
+	for _, c := range s {
+		if !strings.ContainsRune("0123456789abcdef", c) {
+			return false
+		}
+	}
+	return s != ""
+}
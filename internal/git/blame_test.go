@@ -0,0 +1,75 @@
+package git
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseBlame(t *testing.T) {
+	const (
+		first  = "1111111111111111111111111111111111111111"
+		second = "2222222222222222222222222222222222222222"
+		sha256 = "3333333333333333333333333333333333333333333333333333333333333333"
+	)
+	out := strings.Join([]string{
+		first + " 1 1 2",
+		"author Ann",
+		"author-mail <ann@example.com>",
+		"author-time 1700000000",
+		"author-tz +0000",
+		"committer Ann",
+		"summary Initial commit",
+		"boundary",
+		"filename old.go",
+		"\tpackage main",
+		first + " 2 2",
+		"\t",
+		second + " 5 3 1",
+		"author Bob",
+		"author-mail <bob@example.com>",
+		"author-time 1710000000",
+		"summary fix: handle | in subjects",
+		"previous " + first + " old.go",
+		"filename new.go",
+		"\tauthor Bob is not a header here",
+		first + " 3 4 1",
+		"\t// end",
+		sha256 + " 1 5 1",
+		"author Cy",
+		"summary sha256 repository",
+		"filename new.go",
+		"\t\tindented",
+		"",
+	}, "\n")
+
+	want := []BlameLine{
+		{Hash: first, ShortHash: "1111111", Author: "Ann", Email: "ann@example.com", Date: time.Unix(1700000000, 0),
+			Summary: "Initial commit", Path: "old.go", Line: 1, Text: "package main", Boundary: true},
+		{Hash: first, ShortHash: "1111111", Author: "Ann", Email: "ann@example.com", Date: time.Unix(1700000000, 0),
+			Summary: "Initial commit", Path: "old.go", Line: 2, Text: "", Boundary: true},
+		{Hash: second, ShortHash: "2222222", Author: "Bob", Email: "bob@example.com", Date: time.Unix(1710000000, 0),
+			Summary: "fix: handle | in subjects", Path: "new.go", Line: 5, Text: "author Bob is not a header here",
+			Previous: first, PreviousPath: "old.go"},
+		{Hash: first, ShortHash: "1111111", Author: "Ann", Email: "ann@example.com", Date: time.Unix(1700000000, 0),
+			Summary: "Initial commit", Path: "old.go", Line: 3, Text: "// end", Boundary: true},
+		{Hash: sha256, ShortHash: "3333333", Author: "Cy", Summary: "sha256 repository", Path: "new.go",
+			Line: 1, Text: "\tindented"},
+	}
+
+	got := parseBlame(out)
+	if len(got) != len(want) {
+		t.Fatalf("parseBlame() returned %d lines, want %d: %+v", len(got), len(want), got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("line %d = %+v\nwant %+v", i+1, got[i], want[i])
+		}
+	}
+}
+
+func TestParseBlameEmpty(t *testing.T) {
+	if got := parseBlame(""); len(got) != 0 {
+		t.Errorf("parseBlame(\"\") = %+v, want no lines", got)
+	}
+}
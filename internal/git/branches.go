@@ -0,0 +1,156 @@
+package git
+
+import (
+	"bufio"
+	"fmt"
+	"strings"
+)
+
+// BranchComparison holds the differences between two branches
+type BranchComparison struct {
+	Base   string
+	Head   string
+	Ahead  []Commit // Commits on Head missing from Base
+	Behind []Commit // Commits on Base missing from Head
+	Diff   string   // Changes on Head since the merge base
+}
+
+// CreateBranch creates a branch at startPoint without checking it out
+func (g *Git) CreateBranch(name, startPoint string) error {
+	args := []string{"branch", name}
+	if startPoint != "" {
+		args = append(args, startPoint)
+	}
+	_, err := g.Execute(args...)
+	return err
+}
+
+// RenameBranch renames a local branch
+func (g *Git) RenameBranch(oldName, newName string) error {
+	_, err := g.Execute("branch", "-m", oldName, newName)
+	return err
+}
+
+// DeleteBranch deletes a local branch. Without force git refuses to
+// delete a branch that is not fully merged.
+func (g *Git) DeleteBranch(name string, force bool) error {
+	flag := "-d"
+	if force {
+		flag = "-D"
+	}
+	_, err := g.Execute("branch", flag, name)
+	return err
+}
+
+// DeleteRemoteBranch deletes a branch on a remote
+func (g *Git) DeleteRemoteBranch(remote, name string) error {
+	_, err := g.Execute("push", remote, "--delete", name)
+	return err
+}
+
+// GetUpstream returns the upstream of a branch, e.g. "origin/main"
+func (g *Git) GetUpstream(branch string) (string, error) {
+	out, err := g.Execute("rev-parse", "--abbrev-ref", "--symbolic-full-name", branch+"@{upstream}")
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(out), nil
+}
+
+// SetUpstream sets the upstream of a branch
+func (g *Git) SetUpstream(branch, upstream string) error {
+	_, err := g.Execute("branch", "--set-upstream-to="+upstream, branch)
+	return err
+}
+
+// UnsetUpstream removes the upstream of a branch
+func (g *Git) UnsetUpstream(branch string) error {
+	_, err := g.Execute("branch", "--unset-upstream", branch)
+	return err
+}
+
+// UnmergedCommits returns the commits on branch that are not in into
+func (g *Git) UnmergedCommits(branch, into string) ([]Commit, error) {
+	return g.logCommits(into+".."+branch, "--")
+}
+
+// CompareBranches compares head against base
+func (g *Git) CompareBranches(base, head string) (*BranchComparison, error) {
+	ahead, err := g.logCommits(base+".."+head, "--")
+	if err != nil {
+		return nil, err
+	}
+	behind, err := g.logCommits(head+".."+base, "--")
+	if err != nil {
+		return nil, err
+	}
+	diff, err := g.Execute("diff", base+"..."+head, "--")
+	if err != nil {
+		return nil, err
+	}
+
+	return &BranchComparison{
+		Base:   base,
+		Head:   head,
+		Ahead:  ahead,
+		Behind: behind,
+		Diff:   diff,
+	}, nil
+}
+
+// DefaultBranch returns the repository's default branch: configured, the
+// default_branch setting, if it exists locally, else the branch the origin
+// HEAD points to, or its remote-tracking branch (origin/main) when there
+// is no local one, else init.defaultBranch, main or master if they exist
+// locally
+func (g *Git) DefaultBranch(configured string) (string, error) {
+	if configured != "" && g.hasBranch(configured) {
+		return configured, nil
+	}
+	if out, err := g.Execute("symbolic-ref", "--short", "refs/remotes/origin/HEAD"); err == nil {
+		remote := strings.TrimSpace(out)
+		if name := strings.TrimPrefix(remote, "origin/"); g.hasBranch(name) {
+			return name, nil
+		}
+		return remote, nil
+	}
+
+	candidates := []string{"main", "master"}
+	if name := g.getConfig("init.defaultBranch"); name != "" {
+		candidates = append([]string{name}, candidates...)
+	}
+	for _, name := range candidates {
+		if g.hasBranch(name) {
+			return name, nil
+		}
+	}
+	return "", fmt.Errorf("no default branch found")
+}
+
+// hasBranch reports whether the local branch name exists
+func (g *Git) hasBranch(name string) bool {
+	_, err := g.Execute("rev-parse", "--verify", "--quiet", "refs/heads/"+name)
+	return err == nil
+}
+
+// MergedBranches returns the local branches fully merged into into,
+// excluding into itself and the current branch
+func (g *Git) MergedBranches(into string) ([]string, error) {
+	out, err := g.Execute("branch", "--merged", into, "--format=%(HEAD) %(refname:short)")
+	if err != nil {
+		return nil, err
+	}
+
+	var branches []string
+	scanner := bufio.NewScanner(strings.NewReader(out))
+	for scanner.Scan() {
+		line := scanner.Text()
+		if len(line) < 3 || line[0] == '*' {
+			continue
+		}
+		if name := line[2:]; name != into {
+			branches = append(branches, name)
+		}
+	}
+	return branches, nil
+}
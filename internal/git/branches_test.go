@@ -0,0 +1,61 @@
+package git
+
+import "testing"
+
+func TestDefaultBranch(t *testing.T) {
+	tests := []struct {
+		name       string
+		setup      func(t *testing.T, g *Git)
+		configured string
+		want       string
+		wantErr    bool
+	}{
+		{name: "configured", setup: func(t *testing.T, g *Git) { run(t, g, "branch", "develop") }, configured: "develop", want: "develop"},
+		{name: "configured missing", configured: "develop", want: "main"},
+		{name: "main", want: "main"},
+		{name: "master", setup: func(t *testing.T, g *Git) { run(t, g, "branch", "-m", "master") }, want: "master"},
+		{name: "init.defaultBranch", setup: func(t *testing.T, g *Git) {
+			run(t, g, "branch", "trunk")
+			run(t, g, "config", "init.defaultBranch", "trunk")
+		}, want: "trunk"},
+		{name: "none", setup: func(t *testing.T, g *Git) { run(t, g, "branch", "-m", "work") }, wantErr: true},
+		{name: "origin HEAD", setup: func(t *testing.T, g *Git) {
+			run(t, g, "branch", "stable")
+			run(t, g, "update-ref", "refs/remotes/origin/stable", "HEAD")
+			run(t, g, "symbolic-ref", "refs/remotes/origin/HEAD", "refs/remotes/origin/stable")
+		}, want: "stable"},
+		{name: "origin HEAD without local branch", setup: func(t *testing.T, g *Git) {
+			run(t, g, "update-ref", "refs/remotes/origin/stable", "HEAD")
+			run(t, g, "symbolic-ref", "refs/remotes/origin/HEAD", "refs/remotes/origin/stable")
+		}, want: "origin/stable"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := testRepo(t)
+			if tt.setup != nil {
+				tt.setup(t, g)
+			}
+			got, err := g.DefaultBranch(tt.configured)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("DefaultBranch() = %q, want an error", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("DefaultBranch(%q) = %q, want %q", tt.configured, got, tt.want)
+			}
+
+			// The result works as a base for the merged branch queries
+			if _, err := g.MergedBranches(got); err != nil {
+				t.Errorf("MergedBranches(%q): %v", got, err)
+			}
+			if _, err := g.UnmergedCommits("HEAD", got); err != nil {
+				t.Errorf("UnmergedCommits(HEAD, %q): %v", got, err)
+			}
+		})
+	}
+}
@@ -4,20 +4,24 @@ import (
 	"bufio"
 	"bytes"
 	"fmt"
+	"io"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"runtime"
+	"strconv"
 	"strings"
 	"time"
 )
 
 // Repository represents a Git repository
 type Repository struct {
-	Path       string
-	Branch     string
-	RemoteURL  string
-	IsBare     bool
-	Worktree   string
+	Path      string // Worktree, or GitDir for a bare repository
+	Branch    string
+	RemoteURL string
+	IsBare    bool
+	Worktree  string // Top level of the working tree, empty when bare
+	GitDir    string // e.g. .git, or .git/worktrees/NAME in a linked worktree
 }
 
 // Commit represents a Git commit
@@ -30,16 +34,27 @@ type Commit struct {
 	Date      time.Time
 	Refs      []string
 	Parents   []string
+	Body      string // Only filled by CommitsBetween
+
+	// Signature verification (%G?, %GS, %GK); only filled by
+	// VerifySignature since checking signatures is slow
+	Signature SignatureStatus
+	Signer    string
+	SignKey   string
 }
 
 // Branch represents a Git branch
 type Branch struct {
 	Name       string
 	Current    bool
-	Remote     string
+	Remote     string // Remote of the upstream, or of a remote-tracking branch
+	Upstream   string // e.g. origin/main
+	Gone       bool   // Upstream was deleted on the remote
+	IsRemote   bool   // Remote-tracking branch
 	Ahead      int
 	Behind     int
 	LastCommit time.Time
+	Author     string
 }
 
 // Status represents the working tree status
@@ -52,9 +67,10 @@ type Status struct {
 
 // FileStatus represents a file's status
 type FileStatus struct {
-	Path   string
-	Status string // M, A, D, R, C, U
-	Score  int    // For rename/copy
+	Path      string
+	Status    string // M, A, D, R, C, U
+	Score     int    // For rename/copy
+	Submodule string // For submodules, what changed, e.g. "new commits"
 }
 
 // Remote represents a Git remote
@@ -69,13 +85,20 @@ type Stash struct {
 	Index   int
 	Message string
 	Branch  string
+	Hash    string
+	Date    time.Time
 }
 
 // Tag represents a Git tag
 type Tag struct {
-	Name    string
-	Message string
-	Hash    string
+	Name      string
+	Message   string
+	Hash      string // Commit the tag points to
+	Annotated bool
+	Tagger    string
+	Email     string
+	Date      time.Time
+	Signature SignatureStatus // N for unsigned tags, S until verified
 }
 
 // Git is the main Git operations handler
@@ -88,21 +111,38 @@ func New(repoPath string) *Git {
 	return &Git{repoPath: repoPath}
 }
 
-// FindRepository finds the Git repository starting from the given path
+// FindRepository finds the Git repository containing startPath. It asks
+// git, so linked worktrees and submodules, where .git is a file, and bare
+// repositories are found too.
 func FindRepository(startPath string) (*Repository, error) {
-	path := startPath
-	for {
-		gitDir := filepath.Join(path, ".git")
-		if info, err := os.Stat(gitDir); err == nil && info.IsDir() {
-			return &Repository{Path: path}, nil
-		}
+	g := New(startPath)
+	out, err := g.Execute("rev-parse", "--is-bare-repository", "--absolute-git-dir")
+	if err != nil {
+		return nil, fmt.Errorf("not a git repository")
+	}
+	fields := strings.SplitN(strings.TrimSpace(out), "\n", 2)
+	if len(fields) < 2 {
+		return nil, fmt.Errorf("not a git repository")
+	}
 
-		parent := filepath.Dir(path)
-		if parent == path {
-			return nil, fmt.Errorf("not a git repository")
-		}
-		path = parent
+	repo := &Repository{IsBare: fields[0] == "true", GitDir: fields[1]}
+	if repo.IsBare {
+		repo.Path = repo.GitDir
+		return repo, nil
+	}
+
+	// --show-toplevel fails inside a git directory that does not name its
+	// working tree in core.worktree. Only a .git directory is known to sit
+	// in its working tree; a linked worktree's lives under the main .git.
+	if top, err := g.Execute("rev-parse", "--show-toplevel"); err == nil {
+		repo.Worktree = strings.TrimSpace(top)
+	} else if filepath.Base(repo.GitDir) == ".git" {
+		repo.Worktree = filepath.Dir(repo.GitDir)
+	} else {
+		return nil, fmt.Errorf("%s is a git directory; run from its working tree", repo.GitDir)
 	}
+	repo.Path = repo.Worktree
+	return repo, nil
 }
 
 // Execute runs a git command and returns output
@@ -121,6 +161,54 @@ func (g *Git) Execute(args ...string) (string, error) {
 	return out.String(), nil
 }
 
+// ExecuteTo runs a git command writing its output and errors to w as they
+// are produced
+func (g *Git) ExecuteTo(w io.Writer, args ...string) error {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = g.repoPath
+	cmd.Stdout = w
+	cmd.Stderr = w
+	return cmd.Run()
+}
+
+// executeWith runs git with extra environment variables and stdin
+func (g *Git) executeWith(env []string, stdin string, args ...string) (string, error) {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = g.repoPath
+	cmd.Env = append(os.Environ(), env...)
+	cmd.Stdin = strings.NewReader(stdin)
+	var out bytes.Buffer
+	var errOut bytes.Buffer
+	cmd.Stdout = &out
+	cmd.Stderr = &errOut
+
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("%s: %s", err, errOut.String())
+	}
+	return out.String(), nil
+}
+
+// ShellTo runs a shell command in the repository writing its output and
+// errors to w
+func (g *Git) ShellTo(w io.Writer, command string) error {
+	cmd := g.shell(command)
+	cmd.Stdout = w
+	cmd.Stderr = w
+	return cmd.Run()
+}
+
+// shell returns the platform's shell running command in the repository
+func (g *Git) shell(command string) *exec.Cmd {
+	var cmd *exec.Cmd
+	if runtime.GOOS == "windows" {
+		cmd = exec.Command("cmd", "/C", command)
+	} else {
+		cmd = exec.Command("sh", "-c", command)
+	}
+	cmd.Dir = g.repoPath
+	return cmd
+}
+
 // GetCurrentBranch returns the current branch name
 func (g *Git) GetCurrentBranch() (string, error) {
 	out, err := g.Execute("rev-parse", "--abbrev-ref", "HEAD")
@@ -130,9 +218,29 @@ func (g *Git) GetCurrentBranch() (string, error) {
 	return strings.TrimSpace(out), nil
 }
 
-// GetBranches returns all branches
+// GetBranches returns the local branches
 func (g *Git) GetBranches() ([]Branch, error) {
-	out, err := g.Execute("branch", "-vv")
+	return g.forEachBranch("refs/heads")
+}
+
+// GetRemoteBranches returns the remote-tracking branches
+func (g *Git) GetRemoteBranches() ([]Branch, error) {
+	return g.forEachBranch("refs/remotes")
+}
+
+// forEachBranch lists the branches under a ref prefix
+func (g *Git) forEachBranch(prefix string) ([]Branch, error) {
+	format := strings.Join([]string{
+		"%(HEAD)",
+		"%(refname:short)",
+		"%(symref)",
+		"%(upstream:short)",
+		"%(upstream:remotename)",
+		"%(upstream:track,nobracket)",
+		"%(committerdate:unix)",
+		"%(authorname)",
+	}, "%00")
+	out, err := g.Execute("for-each-ref", "--format="+format, prefix)
 	if err != nil {
 		return nil, err
 	}
@@ -140,46 +248,73 @@ func (g *Git) GetBranches() ([]Branch, error) {
 	var branches []Branch
 	scanner := bufio.NewScanner(strings.NewReader(out))
 	for scanner.Scan() {
-		line := scanner.Text()
-		if len(line) < 2 {
-			continue
+		if branch, ok := parseBranchLine(scanner.Text(), prefix == "refs/remotes"); ok {
+			branches = append(branches, branch)
 		}
+	}
 
-		current := line[0] == '*'
-		name := strings.TrimSpace(line[1:])
-		parts := strings.Fields(name)
-		if len(parts) == 0 {
-			continue
-		}
+	return branches, nil
+}
 
-		branch := Branch{
-			Name:    parts[0],
-			Current: current,
-		}
+// parseBranchLine parses a forEachBranch line. Symbolic refs such as
+// origin/HEAD and malformed lines are skipped.
+func parseBranchLine(line string, remote bool) (Branch, bool) {
+	parts := strings.Split(line, "\x00")
+	if len(parts) < 8 || parts[2] != "" {
+		return Branch{}, false
+	}
 
-		// Parse ahead/behind info
-		for _, part := range parts {
-			if strings.Contains(part, "[") {
-				info := strings.Trim(part, "[]")
-				if strings.Contains(info, "ahead") {
-					fmt.Sscanf(info, "ahead %d", &branch.Ahead)
-				}
-				if strings.Contains(info, "behind") {
-					fmt.Sscanf(info, "behind %d", &branch.Behind)
-				}
-			}
-		}
+	branch := Branch{
+		Name:     parts[1],
+		Current:  parts[0] == "*",
+		Upstream: parts[3],
+		Remote:   parts[4],
+		IsRemote: remote,
+		Author:   parts[7],
+	}
+	if branch.IsRemote {
+		branch.Remote, _, _ = strings.Cut(branch.Name, "/")
+	}
+	if ts, err := strconv.ParseInt(parts[6], 10, 64); err == nil {
+		branch.LastCommit = time.Unix(ts, 0)
+	}
 
-		branches = append(branches, branch)
+	// Track is "gone", "ahead 2", "behind 3" or "ahead 2, behind 3"
+	for _, item := range strings.Split(parts[5], ", ") {
+		switch {
+		case item == "gone":
+			branch.Gone = true
+		case strings.HasPrefix(item, "ahead "):
+			branch.Ahead, _ = strconv.Atoi(strings.TrimPrefix(item, "ahead "))
+		case strings.HasPrefix(item, "behind "):
+			branch.Behind, _ = strconv.Atoi(strings.TrimPrefix(item, "behind "))
+		}
 	}
 
-	return branches, nil
+	return branch, true
+}
+
+// CheckoutTracking creates and checks out a local branch tracking the
+// remote-tracking branch remoteBranch, e.g. "origin/feature"
+func (g *Git) CheckoutTracking(remoteBranch string) error {
+	_, err := g.Execute("checkout", "--track", remoteBranch)
+	return err
 }
 
 // GetCommits returns commit history
 func (g *Git) GetCommits(limit int) ([]Commit, error) {
-	format := "%H|%h|%s|%an|%ae|%ai|%D|%P"
-	out, err := g.Execute("log", fmt.Sprintf("-%d", limit), fmt.Sprintf("--pretty=format:%s", format))
+	return g.FilterCommits(LogFilter{Limit: limit})
+}
+
+// logFields are the fields logCommits reads, separated by NUL so that
+// subjects and names may contain any other character
+var logFields = []string{"%H", "%h", "%s", "%an", "%ae", "%ai", "%D", "%P"}
+
+// logCommits runs git log with args and parses the commits
+func (g *Git) logCommits(args ...string) ([]Commit, error) {
+	format := strings.Join(logFields, "%x00")
+	args = append([]string{"log", fmt.Sprintf("--pretty=format:%s", format)}, args...)
+	out, err := g.Execute(args...)
 	if err != nil {
 		return nil, err
 	}
@@ -188,13 +323,13 @@ func (g *Git) GetCommits(limit int) ([]Commit, error) {
 	scanner := bufio.NewScanner(strings.NewReader(out))
 	for scanner.Scan() {
 		line := scanner.Text()
-		parts := strings.Split(line, "|")
-		if len(parts) < 7 {
+		parts := strings.Split(line, "\x00")
+		if len(parts) < len(logFields) {
 			continue
 		}
 
 		date, _ := time.Parse("2006-01-02 15:04:05 -0700", parts[5])
-		
+
 		commit := Commit{
 			Hash:      parts[0],
 			ShortHash: parts[1],
@@ -254,6 +389,14 @@ func (g *Git) GetStatus() (*Status, error) {
 		}
 	}
 
+	if changes := g.submoduleChanges(); changes != nil {
+		for _, files := range [][]FileStatus{status.Staged, status.Unstaged} {
+			for i := range files {
+				files[i].Submodule = changes[files[i].Path]
+			}
+		}
+	}
+
 	return status, nil
 }
 
@@ -293,7 +436,7 @@ func (g *Git) GetRemotes() ([]Remote, error) {
 
 // GetStash returns stash list
 func (g *Git) GetStash() ([]Stash, error) {
-	out, err := g.Execute("stash", "list", "--format=%gd|%s")
+	out, err := g.Execute("stash", "list", "--format=%gd|%H|%ct|%s")
 	if err != nil {
 		return nil, err
 	}
@@ -302,47 +445,44 @@ func (g *Git) GetStash() ([]Stash, error) {
 	scanner := bufio.NewScanner(strings.NewReader(out))
 	for scanner.Scan() {
 		line := scanner.Text()
-		parts := strings.SplitN(line, "|", 2)
-		if len(parts) < 2 {
+		parts := strings.SplitN(line, "|", 4)
+		if len(parts) < 4 {
 			continue
 		}
 
 		var index int
 		fmt.Sscanf(parts[0], "stash@{%d}", &index)
-		
-		stashes = append(stashes, Stash{
+
+		stash := Stash{
 			Index:   index,
-			Message: parts[1],
-		})
-	}
+			Message: parts[3],
+			Hash:    parts[1],
+		}
+		if ts, err := strconv.ParseInt(parts[2], 10, 64); err == nil {
+			stash.Date = time.Unix(ts, 0)
+		}
 
-	return stashes, nil
-}
+		// Subjects are "WIP on <branch>: <commit>" or "On <branch>: <message>"
+		subject := strings.TrimPrefix(parts[3], "WIP ")
+		if rest, ok := cutPrefixFold(subject, "on "); ok {
+			if branch, message, ok := strings.Cut(rest, ": "); ok {
+				stash.Branch = branch
+				stash.Message = message
+			}
+		}
 
-// GetTags returns all tags
-func (g *Git) GetTags() ([]Tag, error) {
-	out, err := g.Execute("tag", "-l", "-n1")
-	if err != nil {
-		return nil, err
+		stashes = append(stashes, stash)
 	}
 
-	var tags []Tag
-	scanner := bufio.NewScanner(strings.NewReader(out))
-	for scanner.Scan() {
-		line := scanner.Text()
-		parts := strings.Fields(line)
-		if len(parts) == 0 {
-			continue
-		}
+	return stashes, nil
+}
 
-		tag := Tag{Name: parts[0]}
-		if len(parts) > 1 {
-			tag.Message = strings.Join(parts[1:], " ")
-		}
-		tags = append(tags, tag)
+// cutPrefixFold is strings.CutPrefix ignoring ASCII case
+func cutPrefixFold(s, prefix string) (string, bool) {
+	if len(s) >= len(prefix) && strings.EqualFold(s[:len(prefix)], prefix) {
+		return s[len(prefix):], true
 	}
-
-	return tags, nil
+	return s, false
 }
 
 // Stage adds files to staging area
@@ -361,32 +501,49 @@ func (g *Git) Unstage(paths ...string) error {
 
 // Commit creates a new commit
 func (g *Git) Commit(message string, amend bool) error {
-	args := []string{"commit", "-m", message}
-	if amend {
-		args = append(args, "--amend")
-	}
-	_, err := g.Execute(args...)
-	return err
+	return g.CommitWithOptions(message, CommitOptions{Amend: amend})
 }
 
 // Push pushes to remote
 func (g *Git) Push(remote, branch string, force bool) error {
+	_, err := g.Execute(pushArgs(remote, branch, force)...)
+	return err
+}
+
+// PushWithOutput pushes like Push, writing the output of git and the
+// pre-push hook to w as it is produced
+func (g *Git) PushWithOutput(remote, branch string, force bool, w io.Writer) error {
+	return g.ExecuteTo(w, pushArgs(remote, branch, force)...)
+}
+
+// pushArgs returns the git arguments for a push
+func pushArgs(remote, branch string, force bool) []string {
 	args := []string{"push", remote, branch}
 	if force {
 		args = append(args, "--force")
 	}
-	_, err := g.Execute(args...)
-	return err
+	return args
 }
 
 // Pull pulls from remote
 func (g *Git) Pull(remote, branch string, rebase bool) error {
+	_, err := g.Execute(pullArgs(remote, branch, rebase)...)
+	return err
+}
+
+// PullWithOutput pulls like Pull, writing the output of git and its hooks
+// to w as it is produced
+func (g *Git) PullWithOutput(remote, branch string, rebase bool, w io.Writer) error {
+	return g.ExecuteTo(w, pullArgs(remote, branch, rebase)...)
+}
+
+// pullArgs returns the git arguments for a pull
+func pullArgs(remote, branch string, rebase bool) []string {
 	args := []string{"pull", remote, branch}
 	if rebase {
 		args = append(args, "--rebase")
 	}
-	_, err := g.Execute(args...)
-	return err
+	return args
 }
 
 // Fetch fetches from remote
@@ -412,13 +569,23 @@ func (g *Git) Checkout(branch string, create bool) error {
 
 // Merge merges a branch
 func (g *Git) Merge(branch string, noFF bool) error {
+	_, err := g.Execute(mergeArgs(branch, noFF)...)
+	return err
+}
+
+// MergeWithOutput merges like Merge, writing the output of git and its
+// hooks to w as it is produced
+func (g *Git) MergeWithOutput(branch string, noFF bool, w io.Writer) error {
+	return g.ExecuteTo(w, mergeArgs(branch, noFF)...)
+}
+
+// mergeArgs returns the git arguments for a merge
+func mergeArgs(branch string, noFF bool) []string {
 	args := []string{"merge"}
 	if noFF {
 		args = append(args, "--no-ff")
 	}
-	args = append(args, branch)
-	_, err := g.Execute(args...)
-	return err
+	return append(args, branch)
 }
 
 // Rebase starts a rebase
@@ -502,7 +669,7 @@ func (g *Git) DeleteTag(name string) error {
 
 // GetDiff returns diff for files
 func (g *Git) GetDiff(staged bool, paths ...string) (string, error) {
-	args := []string{"diff"}
+	args := []string{"diff", "--submodule=log"}
 	if staged {
 		args = append(args, "--cached")
 	}
@@ -510,6 +677,11 @@ func (g *Git) GetDiff(staged bool, paths ...string) (string, error) {
 	return g.Execute(args...)
 }
 
+// GetDiffHead returns the staged and unstaged changes of files against HEAD
+func (g *Git) GetDiffHead(paths ...string) (string, error) {
+	return g.Execute(append([]string{"diff", "--submodule=log", "HEAD"}, paths...)...)
+}
+
 // GetLog returns formatted log
 func (g *Git) GetLog(format string, limit int) (string, error) {
 	return g.Execute("log", fmt.Sprintf("-%d", limit), fmt.Sprintf("--pretty=format:%s", format))
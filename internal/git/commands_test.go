@@ -0,0 +1,136 @@
+package git
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestGetDiffHead(t *testing.T) {
+	g := testRepo(t)
+	writeFile(t, g, "a.txt", "staged\n")
+	run(t, g, "add", "a.txt")
+
+	unstaged, err := g.GetDiff(false, "--", "a.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if unstaged != "" {
+		t.Errorf("GetDiff(false) = %q, want no unstaged changes", unstaged)
+	}
+
+	diff, err := g.GetDiffHead("--", "a.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(diff, "-a\n+staged\n") {
+		t.Errorf("GetDiffHead() = %q, want the staged change", diff)
+	}
+}
+
+func TestParseBranchLine(t *testing.T) {
+	line := func(fields ...string) string { return strings.Join(fields, "\x00") }
+	date := time.Unix(1700000000, 0)
+	tests := []struct {
+		name   string
+		line   string
+		remote bool
+		want   Branch
+		ok     bool
+	}{
+		{
+			name: "current in sync",
+			line: line("*", "main", "", "origin/main", "origin", "", "1700000000", "Ann"),
+			want: Branch{Name: "main", Current: true, Upstream: "origin/main", Remote: "origin", LastCommit: date, Author: "Ann"},
+			ok:   true,
+		},
+		{
+			name: "ahead",
+			line: line(" ", "feature", "", "origin/feature", "origin", "ahead 2", "1700000000", "Ann"),
+			want: Branch{Name: "feature", Upstream: "origin/feature", Remote: "origin", Ahead: 2, LastCommit: date, Author: "Ann"},
+			ok:   true,
+		},
+		{
+			name: "behind",
+			line: line(" ", "feature", "", "up/feature", "up", "behind 13", "1700000000", "Ann"),
+			want: Branch{Name: "feature", Upstream: "up/feature", Remote: "up", Behind: 13, LastCommit: date, Author: "Ann"},
+			ok:   true,
+		},
+		{
+			name: "diverged",
+			line: line(" ", "feature", "", "origin/feature", "origin", "ahead 1, behind 4", "1700000000", "Ann"),
+			want: Branch{Name: "feature", Upstream: "origin/feature", Remote: "origin", Ahead: 1, Behind: 4, LastCommit: date, Author: "Ann"},
+			ok:   true,
+		},
+		{
+			name: "gone",
+			line: line(" ", "old", "", "origin/old", "origin", "gone", "1700000000", "Bob"),
+			want: Branch{Name: "old", Upstream: "origin/old", Remote: "origin", Gone: true, LastCommit: date, Author: "Bob"},
+			ok:   true,
+		},
+		{
+			name: "no upstream",
+			line: line(" ", "local", "", "", "", "", "", "Bob"),
+			want: Branch{Name: "local", Author: "Bob"},
+			ok:   true,
+		},
+		{
+			name:   "remote-tracking",
+			line:   line(" ", "upstream/fix/a", "", "", "", "", "1700000000", "Ann"),
+			remote: true,
+			want:   Branch{Name: "upstream/fix/a", Remote: "upstream", IsRemote: true, LastCommit: date, Author: "Ann"},
+			ok:     true,
+		},
+		{
+			name:   "symbolic ref",
+			line:   line(" ", "origin/HEAD", "refs/remotes/origin/main", "", "", "", "1700000000", "Ann"),
+			remote: true,
+		},
+		{name: "short", line: line("*", "main")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := parseBranchLine(tt.line, tt.remote)
+			if ok != tt.ok || got != tt.want {
+				t.Errorf("parseBranchLine() = %+v, %v, want %+v, %v", got, ok, tt.want, tt.ok)
+			}
+		})
+	}
+}
+
+func TestGetBranchesTracking(t *testing.T) {
+	origin := testRepo(t)
+	run(t, origin, "branch", "gone")
+	g := New(t.TempDir())
+	run(t, g, "clone", "-q", origin.repoPath, ".")
+	run(t, g, "branch", "--track", "gone", "origin/gone")
+
+	// One commit on each side of main, and gone deleted on the remote
+	commitFile(t, origin, "b.txt", "b\n", "remote")
+	commitFile(t, g, "c.txt", "c\n", "local")
+	run(t, origin, "branch", "-D", "gone")
+	run(t, g, "fetch", "-q", "--prune")
+
+	branches, err := g.GetBranches()
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := make(map[string]Branch)
+	for _, b := range branches {
+		got[b.Name] = b
+	}
+	if b := got["main"]; !b.Current || b.Upstream != "origin/main" || b.Ahead != 1 || b.Behind != 1 {
+		t.Errorf("main = %+v, want current, 1 ahead and 1 behind origin/main", b)
+	}
+	if b := got["gone"]; !b.Gone || b.Current {
+		t.Errorf("gone = %+v, want its upstream gone", b)
+	}
+
+	remotes, err := g.GetRemoteBranches()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(remotes) != 1 || remotes[0].Name != "origin/main" || !remotes[0].IsRemote {
+		t.Errorf("GetRemoteBranches() = %+v, want only origin/main", remotes)
+	}
+}
@@ -0,0 +1,217 @@
+package git
+
+import (
+	"fmt"
+	"strings"
+)
+
+// LogFilter selects the commits listed by FilterCommits
+type LogFilter struct {
+	Range        string // Revision range, e.g. "main..feature"; HEAD when empty
+	Message      string // Extended regex matched against the message
+	Author       string // Extended regex matched against the author
+	Since        string // Any date git understands, e.g. "2024-01-31" or "2 weeks ago"
+	Until        string
+	Paths        []string // Only commits touching these paths
+	Pickaxe      string   // Only commits changing the number of occurrences (-S)
+	PickaxeRegex bool     // Pickaxe is a regex matched against added or removed lines (-G)
+	FirstParent  bool
+	NoMerges     bool
+	Limit        int // No limit when zero
+}
+
+// Empty reports whether the filter selects every commit
+func (f LogFilter) Empty() bool {
+	return f.Range == "" && f.Message == "" && f.Author == "" && f.Since == "" &&
+		f.Until == "" && len(f.Paths) == 0 && f.Pickaxe == "" && !f.FirstParent && !f.NoMerges
+}
+
+// Args returns the git log arguments for the filter
+func (f LogFilter) Args() []string {
+	var args []string
+	if f.Limit > 0 {
+		args = append(args, fmt.Sprintf("-%d", f.Limit))
+	}
+	if f.Message != "" || f.Author != "" {
+		args = append(args, "--extended-regexp", "--regexp-ignore-case")
+	}
+	if f.Message != "" {
+		args = append(args, "--grep="+f.Message)
+	}
+	if f.Author != "" {
+		args = append(args, "--author="+f.Author)
+	}
+	if f.Since != "" {
+		args = append(args, "--since="+f.Since)
+	}
+	if f.Until != "" {
+		args = append(args, "--until="+f.Until)
+	}
+	if f.Pickaxe != "" {
+		if f.PickaxeRegex {
+			args = append(args, "-G"+f.Pickaxe)
+		} else {
+			args = append(args, "-S"+f.Pickaxe)
+		}
+	}
+	if f.FirstParent {
+		args = append(args, "--first-parent")
+	}
+	if f.NoMerges {
+		args = append(args, "--no-merges")
+	}
+	if len(f.Paths) > 0 {
+		// Rewrite parents to the nearest commits touching the paths, so
+		// the graph stays connected
+		args = append(args, "--parents")
+	}
+	if f.Range != "" {
+		// Never let a range such as "--output=x" be read as an option
+		args = append(args, "--end-of-options", f.Range)
+	}
+	return append(append(args, "--"), f.Paths...)
+}
+
+// String returns the filter as a query ParseLogFilter accepts
+func (f LogFilter) String() string {
+	var terms []string
+	add := func(key, value string) {
+		if value != "" {
+			terms = append(terms, key+quoteTerm(value))
+		}
+	}
+	if f.Range != "" {
+		terms = append(terms, quoteTerm(f.Range))
+	}
+	add("author:", f.Author)
+	add("since:", f.Since)
+	add("until:", f.Until)
+	for _, p := range f.Paths {
+		add("path:", p)
+	}
+	if f.PickaxeRegex {
+		add("G:", f.Pickaxe)
+	} else {
+		add("S:", f.Pickaxe)
+	}
+	if f.FirstParent {
+		terms = append(terms, "--first-parent")
+	}
+	if f.NoMerges {
+		terms = append(terms, "--no-merges")
+	}
+	add("", f.Message)
+	return strings.Join(terms, " ")
+}
+
+// filterKeys are the term prefixes ParseLogFilter understands
+var filterKeys = map[string]bool{
+	"author": true, "since": true, "until": true, "path": true, "S": true, "G": true, "range": true,
+}
+
+// ParseLogFilter parses a search query such as
+//
+//	main..feature author:alice since:"2 weeks ago" path:cmd/ S:Deprecated --no-merges fix.*crash
+//
+// Terms are author:, since:, until:, path: (repeatable), S: and G: for the
+// pickaxe, range: or any term containing "..", and the --first-parent and
+// --no-merges flags. Remaining words, including any other "word:" terms,
+// form the message regex. Double quotes group words.
+func ParseLogFilter(query string) (LogFilter, error) {
+	terms, err := splitTerms(query)
+	if err != nil {
+		return LogFilter{}, err
+	}
+
+	var f LogFilter
+	var words []string
+	for _, term := range terms {
+		key, value, found := strings.Cut(term, ":")
+		if !found || !filterKeys[key] {
+			key, value = "", term
+		}
+
+		switch key {
+		case "author":
+			f.Author = value
+		case "since":
+			f.Since = value
+		case "until":
+			f.Until = value
+		case "path":
+			f.Paths = append(f.Paths, value)
+		case "S", "G":
+			if f.Pickaxe != "" {
+				return LogFilter{}, fmt.Errorf("only one of S: and G: may be given")
+			}
+			f.Pickaxe = value
+			f.PickaxeRegex = key == "G"
+		case "range":
+			f.Range = value
+		case "":
+			switch {
+			case value == "--first-parent":
+				f.FirstParent = true
+			case value == "--no-merges":
+				f.NoMerges = true
+			case strings.HasPrefix(value, "--"):
+				return LogFilter{}, fmt.Errorf("unknown flag %s", value)
+			case strings.Contains(value, "..") && !strings.ContainsAny(value, " *+?[(|\\"):
+				// Looks like a range rather than a regex
+				f.Range = value
+			default:
+				words = append(words, value)
+			}
+		}
+	}
+	f.Message = strings.Join(words, " ")
+	if strings.HasPrefix(f.Range, "-") {
+		return LogFilter{}, fmt.Errorf("range %s must not start with -", f.Range)
+	}
+
+	return f, nil
+}
+
+// splitTerms splits a query on spaces outside double quotes
+func splitTerms(query string) ([]string, error) {
+	var terms []string
+	var cur strings.Builder
+	inQuote, started := false, false
+
+	for _, r := range query {
+		switch {
+		case r == '"':
+			inQuote = !inQuote
+			started = true
+		case r == ' ' && !inQuote:
+			if started {
+				terms = append(terms, cur.String())
+				cur.Reset()
+				started = false
+			}
+		default:
+			cur.WriteRune(r)
+			started = true
+		}
+	}
+	if inQuote {
+		return nil, fmt.Errorf("unterminated quote in %q", query)
+	}
+	if started {
+		terms = append(terms, cur.String())
+	}
+	return terms, nil
+}
+
+// quoteTerm quotes the value of a term when it contains spaces
+func quoteTerm(value string) string {
+	if strings.Contains(value, " ") {
+		return `"` + value + `"`
+	}
+	return value
+}
+
+// FilterCommits returns the commits selected by f, newest first
+func (g *Git) FilterCommits(f LogFilter) ([]Commit, error) {
+	return g.logCommits(f.Args()...)
+}
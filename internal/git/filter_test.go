@@ -0,0 +1,116 @@
+package git
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseLogFilter(t *testing.T) {
+	tests := []struct {
+		query   string
+		want    LogFilter
+		wantErr string
+	}{
+		{"", LogFilter{}, ""},
+		{"fix crash", LogFilter{Message: "fix crash"}, ""},
+		{"author:alice", LogFilter{Author: "alice"}, ""},
+		{`since:"2 weeks ago" until:2024-01-31`, LogFilter{Since: "2 weeks ago", Until: "2024-01-31"}, ""},
+		{"path:cmd/ path:internal/git", LogFilter{Paths: []string{"cmd/", "internal/git"}}, ""},
+		{"S:Deprecated", LogFilter{Pickaxe: "Deprecated"}, ""},
+		{"G:func\\s+New", LogFilter{Pickaxe: "func\\s+New", PickaxeRegex: true}, ""},
+		{"main..feature", LogFilter{Range: "main..feature"}, ""},
+		{"range:v1.0.0...v2.0.0", LogFilter{Range: "v1.0.0...v2.0.0"}, ""},
+		{"fix.*crash", LogFilter{Message: "fix.*crash"}, ""},
+		{"a..b|c", LogFilter{Message: "a..b|c"}, ""},
+		{"--first-parent --no-merges", LogFilter{FirstParent: true, NoMerges: true}, ""},
+		{"fixes: ticket", LogFilter{Message: "fixes: ticket"}, ""},
+		{`"two words" author:"Ann Lee"`, LogFilter{Message: "two words", Author: "Ann Lee"}, ""},
+		{"  spaced   out  ", LogFilter{Message: "spaced out"}, ""},
+		{
+			`main..feature author:alice since:"2 weeks ago" path:cmd/ S:Deprecated --no-merges fix.*crash`,
+			LogFilter{Range: "main..feature", Author: "alice", Since: "2 weeks ago", Paths: []string{"cmd/"},
+				Pickaxe: "Deprecated", NoMerges: true, Message: "fix.*crash"},
+			"",
+		},
+		{"S:a G:b", LogFilter{}, "only one of S: and G:"},
+		{"--all", LogFilter{}, "unknown flag --all"},
+		{"range:--output=x", LogFilter{}, "range --output=x must not start with -"},
+		{"-p..main", LogFilter{}, "range -p..main must not start with -"},
+		{`author:"Ann`, LogFilter{}, "unterminated quote"},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseLogFilter(tt.query)
+		if tt.wantErr != "" {
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("ParseLogFilter(%q) error = %v, want %q", tt.query, err, tt.wantErr)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("ParseLogFilter(%q): %v", tt.query, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("ParseLogFilter(%q) = %+v, want %+v", tt.query, got, tt.want)
+		}
+
+		// String gives back an equivalent query
+		again, err := ParseLogFilter(got.String())
+		if err != nil || !reflect.DeepEqual(again, got) {
+			t.Errorf("ParseLogFilter(%q.String() = %q) = %+v, %v, want %+v", tt.query, got.String(), again, err, got)
+		}
+	}
+}
+
+func TestLogFilterArgs(t *testing.T) {
+	tests := []struct {
+		filter LogFilter
+		want   []string
+	}{
+		{LogFilter{}, []string{"--"}},
+		{LogFilter{Limit: 50}, []string{"-50", "--"}},
+		{LogFilter{Message: "fix"}, []string{"--extended-regexp", "--regexp-ignore-case", "--grep=fix", "--"}},
+		{LogFilter{Author: "ann", Since: "2 weeks ago", Until: "2024-01-31"}, []string{
+			"--extended-regexp", "--regexp-ignore-case", "--author=ann", "--since=2 weeks ago", "--until=2024-01-31", "--",
+		}},
+		{LogFilter{Pickaxe: "Deprecated"}, []string{"-SDeprecated", "--"}},
+		{LogFilter{Pickaxe: "func New", PickaxeRegex: true}, []string{"-Gfunc New", "--"}},
+		{LogFilter{FirstParent: true, NoMerges: true, Range: "main..topic"}, []string{
+			"--first-parent", "--no-merges", "--end-of-options", "main..topic", "--",
+		}},
+		{LogFilter{Paths: []string{"a.go", "dir/"}, Limit: 10}, []string{"-10", "--parents", "--", "a.go", "dir/"}},
+	}
+
+	for _, tt := range tests {
+		if got := tt.filter.Args(); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%+v.Args() = %q, want %q", tt.filter, got, tt.want)
+		}
+	}
+}
+
+func TestLogFilterEmpty(t *testing.T) {
+	if !(LogFilter{Limit: 100}).Empty() {
+		t.Errorf("a filter with only a limit should be empty")
+	}
+	for _, f := range []LogFilter{{Message: "x"}, {Paths: []string{"a"}}, {NoMerges: true}, {Range: "a..b"}} {
+		if f.Empty() {
+			t.Errorf("%+v.Empty() = true, want false", f)
+		}
+	}
+}
+
+func TestFilterCommitsOptionRange(t *testing.T) {
+	g := testRepo(t)
+	out := filepath.Join(t.TempDir(), "out")
+
+	if _, err := g.FilterCommits(LogFilter{Range: "--output=" + out}); err == nil {
+		t.Error("FilterCommits() with an option as the range succeeded")
+	}
+	if _, err := os.Stat(out); !os.IsNotExist(err) {
+		t.Errorf("the range was read as an option and wrote %s", out)
+	}
+}
@@ -0,0 +1,87 @@
+package git
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// isolateGit keeps tests away from the user's git configuration
+func isolateGit(t *testing.T) {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("XDG_CONFIG_HOME", filepath.Join(home, ".config"))
+	t.Setenv("GIT_CONFIG_GLOBAL", filepath.Join(home, ".gitconfig"))
+	t.Setenv("GIT_CONFIG_NOSYSTEM", "1")
+	t.Setenv("GIT_AUTHOR_NAME", "Ann")
+	t.Setenv("GIT_AUTHOR_EMAIL", "ann@example.com")
+	t.Setenv("GIT_COMMITTER_NAME", "Ann")
+	t.Setenv("GIT_COMMITTER_EMAIL", "ann@example.com")
+}
+
+// testRepo creates a repository on branch main with one commit
+func testRepo(t *testing.T) *Git {
+	t.Helper()
+	isolateGit(t)
+	g := New(t.TempDir())
+	run(t, g, "init", "-q", "-b", "main")
+	commitFile(t, g, "a.txt", "a\n", "first")
+	return g
+}
+
+// run runs git, failing the test on errors
+func run(t *testing.T, g *Git, args ...string) string {
+	t.Helper()
+	out, err := g.Execute(args...)
+	if err != nil {
+		t.Fatalf("git %s: %v", strings.Join(args, " "), err)
+	}
+	return out
+}
+
+// writeFile writes a file of the repository
+func writeFile(t *testing.T, g *Git, name, content string) {
+	t.Helper()
+	path := filepath.Join(g.repoPath, name)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// readFile reads a file of the repository
+func readFile(t *testing.T, g *Git, name string) string {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join(g.repoPath, name))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
+
+// commitFile writes and commits a file, returning the commit hash
+func commitFile(t *testing.T, g *Git, name, content, message string) string {
+	t.Helper()
+	writeFile(t, g, name, content)
+	run(t, g, "add", name)
+	run(t, g, "commit", "-q", "-m", message)
+	return strings.TrimSpace(run(t, g, "rev-parse", "HEAD"))
+}
+
+// addSubmodule adds the repository at url as a submodule at path and
+// commits it
+func addSubmodule(t *testing.T, g *Git, url, path string) {
+	t.Helper()
+	// Local clones are refused by default since git 2.38.1
+	run(t, g, "config", "--global", "protocol.file.allow", "always")
+	run(t, g, "submodule", "add", "-q", url, path)
+	run(t, g, "commit", "-q", "-m", "Add "+path)
+}
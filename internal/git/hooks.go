@@ -0,0 +1,240 @@
+package git
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// HookNames are the client-side hooks git runs
+var HookNames = []string{
+	"applypatch-msg", "pre-applypatch", "post-applypatch",
+	"pre-commit", "pre-merge-commit", "prepare-commit-msg", "commit-msg", "post-commit",
+	"pre-rebase", "post-checkout", "post-merge", "pre-push", "pre-auto-gc",
+	"post-rewrite", "reference-transaction", "push-to-checkout",
+}
+
+// CommitHooks are the hooks a commit can run
+var CommitHooks = []string{"pre-commit", "prepare-commit-msg", "commit-msg", "post-commit"}
+
+// MergeHooks are the hooks a merge, or a pull that merges, can run
+var MergeHooks = []string{"pre-merge-commit", "prepare-commit-msg", "commit-msg", "post-merge"}
+
+// managedMarker marks hooks written by InstallHooks
+const managedMarker = "# Managed by gitflow-tui"
+
+// disabledSuffix is appended to the file name of disabled hooks
+const disabledSuffix = ".disabled"
+
+// Hook is a script in the hooks directory
+type Hook struct {
+	Name       string // e.g. pre-commit
+	Path       string
+	Enabled    bool // Disabled hooks are renamed to <name>.disabled
+	Executable bool // Git skips hooks that are not executable
+	Managed    bool // Installed from the project hook config
+}
+
+// HooksDir returns the hooks directory, honouring core.hooksPath
+func (g *Git) HooksDir() (string, error) {
+	out, err := g.Execute("rev-parse", "--git-path", "hooks")
+	if err != nil {
+		return "", err
+	}
+	dir := strings.TrimSpace(out)
+	if !filepath.IsAbs(dir) {
+		dir = filepath.Join(g.repoPath, dir)
+	}
+	return dir, nil
+}
+
+// GetHooks returns the installed hooks, enabled or not, by name. Sample
+// hooks are left out.
+func (g *Git) GetHooks() ([]Hook, error) {
+	dir, err := g.HooksDir()
+	if err != nil {
+		return nil, err
+	}
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var hooks []Hook
+	for _, e := range entries {
+		if e.IsDir() || strings.HasSuffix(e.Name(), ".sample") {
+			continue
+		}
+		name := strings.TrimSuffix(e.Name(), disabledSuffix)
+		if !isHookName(name) {
+			continue
+		}
+
+		path := filepath.Join(dir, e.Name())
+		info, err := e.Info()
+		if err != nil {
+			continue
+		}
+		hooks = append(hooks, Hook{
+			Name:       name,
+			Path:       path,
+			Enabled:    name == e.Name(),
+			Executable: info.Mode()&0111 != 0,
+			Managed:    isManaged(path),
+		})
+	}
+
+	sort.Slice(hooks, func(i, j int) bool { return hooks[i].Name < hooks[j].Name })
+	return hooks, nil
+}
+
+// HasEnabledHook reports whether any of the named hooks would run
+func (g *Git) HasEnabledHook(names ...string) bool {
+	hooks, _ := g.GetHooks()
+	for _, h := range hooks {
+		for _, name := range names {
+			if h.Name == name && h.Enabled && h.Executable {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// SetHookEnabled enables or disables a hook by renaming it. Enabling also
+// makes it executable.
+func (g *Git) SetHookEnabled(name string, enabled bool) error {
+	dir, err := g.HooksDir()
+	if err != nil {
+		return err
+	}
+	active := filepath.Join(dir, name)
+	disabled := active + disabledSuffix
+
+	if !enabled {
+		return os.Rename(active, disabled)
+	}
+	if _, err := os.Stat(active); err == nil {
+		if _, err := os.Stat(disabled); err == nil {
+			return fmt.Errorf("%s is already enabled; remove %s first", name, filepath.Base(disabled))
+		}
+	} else if err := os.Rename(disabled, active); err != nil {
+		return err
+	}
+	return os.Chmod(active, 0755)
+}
+
+// CreateHook creates an empty executable shell hook and returns its path
+func (g *Git) CreateHook(name string) (string, error) {
+	if !isHookName(name) {
+		return "", fmt.Errorf("unknown hook %q", name)
+	}
+	dir, err := g.HooksDir()
+	if err != nil {
+		return "", err
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", err
+	}
+	path := filepath.Join(dir, name)
+	if _, err := os.Stat(path); err == nil {
+		return "", fmt.Errorf("%s already exists", name)
+	}
+	return path, os.WriteFile(path, []byte("#!/bin/sh\n\n"), 0755)
+}
+
+// InstallHooks writes a script for each hook running its commands in order
+// and stopping at the first failure. Managed hooks missing from hooks are
+// removed; hooks that were not installed by InstallHooks are never
+// overwritten. It returns the names of the installed hooks.
+func (g *Git) InstallHooks(hooks map[string][]string, source string) ([]string, error) {
+	dir, err := g.HooksDir()
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+
+	var names []string
+	for name := range hooks {
+		if !isHookName(name) {
+			return nil, fmt.Errorf("unknown hook %q", name)
+		}
+		path := filepath.Join(dir, name)
+		if _, err := os.Stat(path); err == nil && !isManaged(path) {
+			return nil, fmt.Errorf("%s exists and was not installed by gitflow-tui; disable or remove it first", name)
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		var b strings.Builder
+		b.WriteString("#!/bin/sh\n")
+		fmt.Fprintf(&b, "%s from %s; edit that file and reinstall instead\n", managedMarker, source)
+		b.WriteString("set -e\n\n")
+		for _, command := range hooks[name] {
+			b.WriteString(command + "\n")
+		}
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(b.String()), 0755); err != nil {
+			return nil, err
+		}
+	}
+
+	// Drop managed hooks the config no longer lists
+	existing, err := g.GetHooks()
+	if err != nil {
+		return nil, err
+	}
+	for _, h := range existing {
+		if _, ok := hooks[h.Name]; !ok && h.Managed {
+			if err := os.Remove(h.Path); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return names, nil
+}
+
+// RunHook runs a hook directly, writing its output to w
+func (g *Git) RunHook(name string, w io.Writer, args ...string) error {
+	dir, err := g.HooksDir()
+	if err != nil {
+		return err
+	}
+	cmd := exec.Command(filepath.Join(dir, name), args...)
+	cmd.Dir = g.repoPath
+	cmd.Stdout = w
+	cmd.Stderr = w
+	return cmd.Run()
+}
+
+// CommitWithOutput commits like CommitWithOptions, writing the output of
+// git and its hooks to w as it is produced
+func (g *Git) CommitWithOutput(message string, opts CommitOptions, w io.Writer) error {
+	return g.ExecuteTo(w, commitArgs(message, opts)...)
+}
+
+func isHookName(name string) bool {
+	for _, h := range HookNames {
+		if h == name {
+			return true
+		}
+	}
+	return false
+}
+
+// isManaged reports whether the hook at path was written by InstallHooks
+func isManaged(path string) bool {
+	data, err := os.ReadFile(path)
+	return err == nil && strings.Contains(string(data), managedMarker)
+}
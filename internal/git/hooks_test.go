@@ -0,0 +1,223 @@
+package git
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// writeHook writes a file to the hooks directory
+func writeHook(t *testing.T, g *Git, name, content string, mode os.FileMode) string {
+	t.Helper()
+	dir, err := g.HooksDir()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, []byte(content), mode); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestGetHooks(t *testing.T) {
+	g := testRepo(t)
+	dir, err := g.HooksDir()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// git init may install samples; start from an empty directory
+	os.RemoveAll(dir)
+
+	writeHook(t, g, "pre-commit", "#!/bin/sh\n", 0755)
+	writeHook(t, g, "commit-msg.disabled", "#!/bin/sh\n", 0755)
+	writeHook(t, g, "pre-push", "#!/bin/sh\n", 0644)
+	writeHook(t, g, "post-merge", "#!/bin/sh\n"+managedMarker+" from hooks.yaml\n", 0755)
+	writeHook(t, g, "pre-rebase.sample", "#!/bin/sh\n", 0755)
+	writeHook(t, g, "notes.txt", "not a hook\n", 0644)
+
+	hooks, err := g.GetHooks()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []Hook
+	for _, h := range hooks {
+		if filepath.Dir(h.Path) != dir {
+			t.Errorf("%s: path %s is not in %s", h.Name, h.Path, dir)
+		}
+		h.Path = filepath.Base(h.Path)
+		got = append(got, h)
+	}
+	want := []Hook{
+		{Name: "commit-msg", Path: "commit-msg.disabled", Enabled: false, Executable: true},
+		{Name: "post-merge", Path: "post-merge", Enabled: true, Executable: true, Managed: true},
+		{Name: "pre-commit", Path: "pre-commit", Enabled: true, Executable: true},
+		{Name: "pre-push", Path: "pre-push", Enabled: true, Executable: false},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("GetHooks() = %+v, want %+v", got, want)
+	}
+
+	tests := []struct {
+		names []string
+		want  bool
+	}{
+		{CommitHooks, true},
+		{[]string{"commit-msg"}, false}, // Disabled
+		{[]string{"pre-push"}, false},   // Not executable
+		{MergeHooks, true},
+		{[]string{"post-checkout"}, false},
+	}
+	for _, tt := range tests {
+		if got := g.HasEnabledHook(tt.names...); got != tt.want {
+			t.Errorf("HasEnabledHook(%v) = %v, want %v", tt.names, got, tt.want)
+		}
+	}
+}
+
+func TestHooksPath(t *testing.T) {
+	g := testRepo(t)
+	run(t, g, "config", "core.hooksPath", ".githooks")
+	writeFile(t, g, ".githooks/pre-commit", "#!/bin/sh\n")
+
+	dir, err := g.HooksDir()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := filepath.Join(g.repoPath, ".githooks"); dir != want {
+		t.Errorf("HooksDir() = %s, want %s", dir, want)
+	}
+	hooks, err := g.GetHooks()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(hooks) != 1 || hooks[0].Name != "pre-commit" {
+		t.Errorf("GetHooks() = %+v, want the pre-commit hook in core.hooksPath", hooks)
+	}
+}
+
+func TestSetHookEnabled(t *testing.T) {
+	g := testRepo(t)
+	path := writeHook(t, g, "pre-commit", "#!/bin/sh\n", 0644)
+
+	if err := g.SetHookEnabled("pre-commit", false); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("disabled hook still at %s", path)
+	}
+	if _, err := os.Stat(path + disabledSuffix); err != nil {
+		t.Errorf("disabled hook missing: %v", err)
+	}
+
+	if err := g.SetHookEnabled("pre-commit", true); err != nil {
+		t.Fatal(err)
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Mode()&0111 == 0 {
+		t.Errorf("enabled hook mode = %v, want executable", info.Mode())
+	}
+
+	// Enabling when both versions exist would lose one of them
+	writeHook(t, g, "pre-commit"+disabledSuffix, "#!/bin/sh\nexit 1\n", 0755)
+	if err := g.SetHookEnabled("pre-commit", true); err == nil {
+		t.Error("SetHookEnabled() with both files present succeeded")
+	}
+
+	if err := g.SetHookEnabled("commit-msg", false); err == nil {
+		t.Error("SetHookEnabled() on a missing hook succeeded")
+	}
+}
+
+func TestInstallHooks(t *testing.T) {
+	g := testRepo(t)
+	own := writeHook(t, g, "pre-commit", "#!/bin/sh\necho mine\n", 0755)
+
+	// Never overwrite a hook the user wrote
+	_, err := g.InstallHooks(map[string][]string{"pre-commit": {"go vet ./..."}}, "hooks.yaml")
+	if err == nil || !strings.Contains(err.Error(), "not installed by gitflow-tui") {
+		t.Fatalf("InstallHooks() error = %v, want a refusal", err)
+	}
+	if got := readFile(t, g, ".git/hooks/pre-commit"); got != "#!/bin/sh\necho mine\n" {
+		t.Errorf("own hook = %q, want it untouched", got)
+	}
+
+	if _, err := g.InstallHooks(map[string][]string{"not-a-hook": {"true"}}, "hooks.yaml"); err == nil {
+		t.Error("InstallHooks() with an unknown hook succeeded")
+	}
+
+	names, err := g.InstallHooks(map[string][]string{
+		"commit-msg": {"lint --edit \"$1\""},
+		"pre-push":   {"go vet ./...", "go test ./..."},
+	}, "hooks.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(names, []string{"commit-msg", "pre-push"}) {
+		t.Errorf("InstallHooks() = %v", names)
+	}
+	want := "#!/bin/sh\n" + managedMarker + " from hooks.yaml; edit that file and reinstall instead\nset -e\n\ngo vet ./...\ngo test ./...\n"
+	if got := readFile(t, g, ".git/hooks/pre-push"); got != want {
+		t.Errorf("pre-push = %q, want %q", got, want)
+	}
+
+	// Reinstalling drops managed hooks no longer listed, keeping others
+	if _, err := g.InstallHooks(map[string][]string{"pre-push": {"make check"}}, "hooks.yaml"); err != nil {
+		t.Fatal(err)
+	}
+	hooks, err := g.GetHooks()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, h := range hooks {
+		if !strings.HasSuffix(h.Path, ".sample") {
+			got = append(got, h.Name)
+		}
+	}
+	if !reflect.DeepEqual(got, []string{"pre-commit", "pre-push"}) {
+		t.Errorf("hooks after reinstall = %v, want pre-commit and pre-push", got)
+	}
+	if _, err := os.Stat(own); err != nil {
+		t.Errorf("own hook removed: %v", err)
+	}
+	if got := readFile(t, g, ".git/hooks/pre-push"); !strings.Contains(got, "make check\n") {
+		t.Errorf("pre-push = %q, want the new command", got)
+	}
+}
+
+func TestPushWithOutput(t *testing.T) {
+	g := testRepo(t)
+	remote := New(t.TempDir())
+	run(t, remote, "init", "-q", "--bare")
+	run(t, g, "remote", "add", "origin", remote.repoPath)
+	writeHook(t, g, "pre-push", "#!/bin/sh\necho checking before push\nexit 1\n", 0755)
+
+	var out bytes.Buffer
+	if err := g.PushWithOutput("origin", "main", false, &out); err == nil {
+		t.Fatal("PushWithOutput() succeeded despite the failing pre-push hook")
+	}
+	if !strings.Contains(out.String(), "checking before push") {
+		t.Errorf("output = %q, want the hook output", out.String())
+	}
+
+	if err := g.SetHookEnabled("pre-push", false); err != nil {
+		t.Fatal(err)
+	}
+	out.Reset()
+	if err := g.PushWithOutput("origin", "main", false, &out); err != nil {
+		t.Fatalf("PushWithOutput(): %v: %s", err, out.String())
+	}
+	if got := strings.TrimSpace(run(t, remote, "rev-parse", "main")); got != strings.TrimSpace(run(t, g, "rev-parse", "HEAD")) {
+		t.Errorf("remote main = %s, want the pushed commit", got)
+	}
+}
@@ -0,0 +1,73 @@
+package git
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CommitsBetween returns the commits reachable from to but not from from,
+// newest first, including their message bodies. An empty from returns all
+// commits reachable from to; an empty to means HEAD.
+func (g *Git) CommitsBetween(from, to string) ([]Commit, error) {
+	if to == "" {
+		to = "HEAD"
+	}
+	rev := to
+	if from != "" {
+		rev = from + ".." + to
+	}
+
+	format := "%H%x00%h%x00%an%x00%ae%x00%at%x00%P%x00%s%x00%b%x1e"
+	out, err := g.Execute("log", "--format="+format, rev, "--")
+	if err != nil {
+		return nil, err
+	}
+
+	var commits []Commit
+	for _, record := range strings.Split(out, "\x1e") {
+		parts := strings.SplitN(strings.TrimLeft(record, "\n"), "\x00", 8)
+		if len(parts) < 8 {
+			continue
+		}
+
+		commit := Commit{
+			Hash:      parts[0],
+			ShortHash: parts[1],
+			Author:    parts[2],
+			Email:     parts[3],
+			Parents:   strings.Fields(parts[5]),
+			Message:   parts[6],
+			Body:      strings.TrimSpace(parts[7]),
+		}
+		if ts, err := strconv.ParseInt(parts[4], 10, 64); err == nil {
+			commit.Date = time.Unix(ts, 0)
+		}
+		commits = append(commits, commit)
+	}
+
+	return commits, nil
+}
+
+// LastCommitMessage returns the full message of HEAD
+func (g *Git) LastCommitMessage() (string, error) {
+	out, err := g.Execute("log", "-1", "--format=%B")
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(out), nil
+}
+
+// Identity returns the committer as "Name <email>"
+func (g *Git) Identity() (string, error) {
+	out, err := g.Execute("var", "GIT_COMMITTER_IDENT")
+	if err != nil {
+		return "", err
+	}
+	// The ident ends with a timestamp and timezone
+	ident := strings.TrimSpace(out)
+	if i := strings.LastIndex(ident, ">"); i >= 0 {
+		ident = ident[:i+1]
+	}
+	return ident, nil
+}
@@ -0,0 +1,87 @@
+package git
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ReflogEntry is a position a ref was moved to
+type ReflogEntry struct {
+	Selector    string // e.g. "HEAD@{2}"
+	Hash        string
+	ShortHash   string
+	Date        time.Time // When the ref moved
+	Action      string    // e.g. "commit", "reset", "checkout"
+	Description string    // e.g. "moving to HEAD~1"
+	Message     string    // Subject of the commit
+}
+
+// Reflog returns the most recent limit moves of ref, newest first; ref
+// is "HEAD" or a branch name, and limit zero returns all of them
+func (g *Git) Reflog(ref string, limit int) ([]ReflogEntry, error) {
+	args := []string{"reflog", "show", "--date=unix", "--format=%H%x00%h%x00%gd%x00%gs%x00%s"}
+	if limit > 0 {
+		args = append(args, fmt.Sprintf("-%d", limit))
+	}
+	out, err := g.Execute(append(args, ref, "--")...)
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []ReflogEntry
+	for _, line := range strings.Split(out, "\n") {
+		parts := strings.SplitN(line, "\x00", 5)
+		if len(parts) < 5 {
+			continue
+		}
+
+		entry := ReflogEntry{
+			Selector:  fmt.Sprintf("%s@{%d}", ref, len(entries)),
+			Hash:      parts[0],
+			ShortHash: parts[1],
+			Message:   parts[4],
+		}
+		// With --date=unix the selector holds the time, e.g. HEAD@{1700000000}
+		if i := strings.LastIndex(parts[2], "@{"); i >= 0 {
+			if ts, err := strconv.ParseInt(strings.TrimSuffix(parts[2][i+2:], "}"), 10, 64); err == nil {
+				entry.Date = time.Unix(ts, 0)
+			}
+		}
+		action, description, found := strings.Cut(parts[3], ": ")
+		if !found {
+			action, description = "", parts[3]
+		}
+		entry.Action, entry.Description = action, description
+
+		entries = append(entries, entry)
+	}
+
+	return entries, nil
+}
+
+// DanglingCommits returns commits no ref reaches, newest first. With
+// --lost-found git fsck ignores reflogs, so this includes commits a reflog
+// still lists, such as one dropped by a reset. It also writes them to
+// .git/lost-found.
+func (g *Git) DanglingCommits() ([]Commit, error) {
+	out, err := g.Execute("fsck", "--lost-found", "--no-progress")
+	if err != nil {
+		return nil, err
+	}
+
+	var hashes []string
+	for _, line := range strings.Split(out, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 3 && fields[0] == "dangling" && fields[1] == "commit" {
+			hashes = append(hashes, fields[2])
+		}
+	}
+	if len(hashes) == 0 {
+		return nil, nil
+	}
+
+	args := append([]string{"--no-walk=sorted"}, hashes...)
+	return g.logCommits(append(args, "--")...)
+}
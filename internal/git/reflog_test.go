@@ -0,0 +1,77 @@
+package git
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestReflog(t *testing.T) {
+	g := testRepo(t)
+	first := strings.TrimSpace(run(t, g, "rev-parse", "HEAD"))
+	second := commitFile(t, g, "b.txt", "b\n", "second")
+	run(t, g, "reset", "-q", "--hard", "HEAD~1")
+
+	entries, err := g.Reflog("HEAD", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 3 {
+		t.Fatalf("Reflog() returned %d entries, want 3: %+v", len(entries), entries)
+	}
+
+	reset := entries[0]
+	if reset.Selector != "HEAD@{0}" || reset.Hash != first || reset.Action != "reset" ||
+		reset.Description != "moving to HEAD~1" || reset.Message != "first" {
+		t.Errorf("entries[0] = %+v, want the reset to %s", reset, first)
+	}
+	if !strings.HasPrefix(first, reset.ShortHash) || reset.Date.IsZero() {
+		t.Errorf("entries[0] = %+v, want a short hash and a date", reset)
+	}
+	commit := entries[1]
+	if commit.Selector != "HEAD@{1}" || commit.Hash != second || commit.Action != "commit" ||
+		commit.Description != "second" || commit.Message != "second" {
+		t.Errorf("entries[1] = %+v, want the commit of %s", commit, second)
+	}
+	if entries[2].Action != "commit (initial)" {
+		t.Errorf("entries[2].Action = %q, want the initial commit", entries[2].Action)
+	}
+
+	entries, err = g.Reflog("main", 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 || entries[0].Selector != "main@{0}" || entries[0].Hash != first {
+		t.Errorf("Reflog(main, 1) = %+v, want the reset", entries)
+	}
+}
+
+func TestDanglingCommits(t *testing.T) {
+	g := testRepo(t)
+	commits, err := g.DanglingCommits()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(commits) != 0 {
+		t.Errorf("DanglingCommits() = %+v, want none", commits)
+	}
+
+	commitFile(t, g, "b.txt", "b\n", "second")
+	third := commitFile(t, g, "c.txt", "c\n", "third")
+	run(t, g, "reset", "-q", "--hard", "HEAD~2")
+
+	// The reflog still lists both dropped commits; only the tip is
+	// dangling, since second is reachable from it
+	commits, err = g.DanglingCommits()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(commits) != 1 || commits[0].Hash != third || commits[0].Message != "third" {
+		t.Errorf("DanglingCommits() = %+v, want %s", commits, third)
+	}
+	if strings.TrimSpace(run(t, g, "rev-parse", "HEAD@{1}")) != third {
+		t.Error("the reflog no longer lists the dropped commit")
+	}
+	if got := readFile(t, g, ".git/lost-found/commit/"+third); got == "" {
+		t.Error("git fsck did not write the commit to lost-found")
+	}
+}
@@ -0,0 +1,162 @@
+package git
+
+import (
+	"bufio"
+	"strings"
+)
+
+// RemoteConfig is the configuration of a remote
+type RemoteConfig struct {
+	Name          string
+	FetchURL      string
+	PushURLs      []string // Empty when pushing to FetchURL
+	FetchRefspecs []string
+	PushRefspecs  []string
+}
+
+// PushURL returns the URL pushes go to
+func (r RemoteConfig) PushURL() string {
+	if len(r.PushURLs) > 0 {
+		return r.PushURLs[0]
+	}
+	return r.FetchURL
+}
+
+// GetRemoteConfigs returns the configuration of all remotes in the order
+// git lists them
+func (g *Git) GetRemoteConfigs() ([]RemoteConfig, error) {
+	out, err := g.Execute("remote")
+	if err != nil {
+		return nil, err
+	}
+
+	var remotes []RemoteConfig
+	index := make(map[string]int)
+	for _, name := range strings.Fields(out) {
+		index[name] = len(remotes)
+		remotes = append(remotes, RemoteConfig{Name: name})
+	}
+	if len(remotes) == 0 {
+		return nil, nil
+	}
+
+	// No matching keys makes git exit with status 1
+	out, _ = g.Execute("config", "--get-regexp", `^remote\.`)
+	scanner := bufio.NewScanner(strings.NewReader(out))
+	for scanner.Scan() {
+		key, value, _ := strings.Cut(scanner.Text(), " ")
+
+		// Remote names may contain dots: remote.<name>.<setting>
+		key = strings.TrimPrefix(key, "remote.")
+		dot := strings.LastIndex(key, ".")
+		if dot < 0 {
+			continue
+		}
+		i, ok := index[key[:dot]]
+		if !ok {
+			continue
+		}
+
+		r := &remotes[i]
+		switch key[dot+1:] {
+		case "url":
+			r.FetchURL = value
+		case "pushurl":
+			r.PushURLs = append(r.PushURLs, value)
+		case "fetch":
+			r.FetchRefspecs = append(r.FetchRefspecs, value)
+		case "push":
+			r.PushRefspecs = append(r.PushRefspecs, value)
+		}
+	}
+
+	return remotes, nil
+}
+
+// AddRemote adds a remote
+func (g *Git) AddRemote(name, url string) error {
+	_, err := g.Execute("remote", "add", name, url)
+	return err
+}
+
+// RenameRemote renames a remote and its remote-tracking branches
+func (g *Git) RenameRemote(oldName, newName string) error {
+	_, err := g.Execute("remote", "rename", oldName, newName)
+	return err
+}
+
+// RemoveRemote removes a remote and its remote-tracking branches
+func (g *Git) RemoveRemote(name string) error {
+	_, err := g.Execute("remote", "remove", name)
+	return err
+}
+
+// SetRemoteURL sets the fetch URL, or the push URL when push is set. An
+// empty push URL makes pushes use the fetch URL again.
+func (g *Git) SetRemoteURL(name, url string, push bool) error {
+	if !push {
+		_, err := g.Execute("remote", "set-url", name, url)
+		return err
+	}
+
+	if url == "" {
+		_, err := g.Execute("config", "--unset-all", "remote."+name+".pushurl")
+		// Status 5 means there was no push URL to remove
+		if err != nil && !strings.HasPrefix(err.Error(), "exit status 5") {
+			return err
+		}
+		return nil
+	}
+
+	// Replace all push URLs with the new one
+	g.Execute("config", "--unset-all", "remote."+name+".pushurl")
+	_, err := g.Execute("remote", "set-url", "--push", name, url)
+	return err
+}
+
+// SetRemoteRefspecs replaces the fetch refspecs, or the push refspecs
+// when push is set
+func (g *Git) SetRemoteRefspecs(name string, refspecs []string, push bool) error {
+	key := "remote." + name + ".fetch"
+	if push {
+		key = "remote." + name + ".push"
+	}
+
+	if _, err := g.Execute("config", "--unset-all", key); err != nil && !strings.HasPrefix(err.Error(), "exit status 5") {
+		return err
+	}
+	for _, spec := range refspecs {
+		if _, err := g.Execute("config", "--add", key, spec); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// PruneRemote deletes remote-tracking branches whose branch no longer
+// exists on the remote and returns their names. With dryRun it only
+// reports them.
+func (g *Git) PruneRemote(name string, dryRun bool) ([]string, error) {
+	args := []string{"remote", "prune"}
+	if dryRun {
+		args = append(args, "--dry-run")
+	}
+	out, err := g.Execute(append(args, name)...)
+	if err != nil {
+		return nil, err
+	}
+
+	// Lines look like " * [pruned] origin/feature"
+	var pruned []string
+	scanner := bufio.NewScanner(strings.NewReader(out))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if !strings.HasPrefix(line, "* [") {
+			continue
+		}
+		if i := strings.Index(line, "] "); i >= 0 {
+			pruned = append(pruned, line[i+2:])
+		}
+	}
+	return pruned, nil
+}
@@ -0,0 +1,417 @@
+package git
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// SignatureStatus is the %G? verification result of a commit or tag
+type SignatureStatus string
+
+const (
+	SigGood         SignatureStatus = "G"
+	SigBad          SignatureStatus = "B"
+	SigUnknownValid SignatureStatus = "U" // Good signature, unknown validity
+	SigExpired      SignatureStatus = "X"
+	SigExpiredKey   SignatureStatus = "Y"
+	SigRevokedKey   SignatureStatus = "R"
+	SigCannotVerify SignatureStatus = "E"
+	SigNone         SignatureStatus = "N"
+	SigUnchecked    SignatureStatus = "S" // Signed, not verified yet
+)
+
+// Signed reports whether the object carries any signature
+func (s SignatureStatus) Signed() bool {
+	return s != "" && s != SigNone
+}
+
+// Verified reports whether the signature is good
+func (s SignatureStatus) Verified() bool {
+	return s == SigGood || s == SigUnknownValid
+}
+
+// Description returns a human readable description of the status
+func (s SignatureStatus) Description() string {
+	switch s {
+	case SigGood:
+		return "good signature"
+	case SigBad:
+		return "bad signature"
+	case SigUnknownValid:
+		return "good signature, unknown validity"
+	case SigExpired:
+		return "good signature, expired"
+	case SigExpiredKey:
+		return "good signature, expired key"
+	case SigRevokedKey:
+		return "good signature, revoked key"
+	case SigCannotVerify:
+		return "signature cannot be checked"
+	case SigUnchecked:
+		return "signed, not verified yet"
+	default:
+		return "not signed"
+	}
+}
+
+// SigningFormat is the gpg.format setting
+type SigningFormat string
+
+const (
+	FormatOpenPGP SigningFormat = "openpgp"
+	FormatSSH     SigningFormat = "ssh"
+	FormatX509    SigningFormat = "x509"
+)
+
+// SigningConfig holds the signing related git configuration
+type SigningConfig struct {
+	Format             SigningFormat
+	Key                string
+	SignCommits        bool
+	SignTags           bool
+	AllowedSignersFile string
+}
+
+// CommitOptions holds options for creating a commit
+type CommitOptions struct {
+	Amend   bool
+	Sign    bool
+	Key     string // Overrides user.signingkey when set
+	SignOff bool   // Add a Signed-off-by trailer
+}
+
+// AllowedSigner is an entry of an SSH allowed signers file
+type AllowedSigner struct {
+	Principals string
+	Options    string
+	Key        string
+}
+
+// getConfig returns a config value or an empty string when unset. A
+// scope such as --local may come before the key
+func (g *Git) getConfig(args ...string) string {
+	out, err := g.Execute(append([]string{"config", "--get"}, args...)...)
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(out)
+}
+
+// configScope returns the git config option for the repository or the
+// global configuration
+func configScope(global bool) string {
+	if global {
+		return "--global"
+	}
+	return "--local"
+}
+
+// readSigningConfig reads the signing configuration using the given
+// config options, e.g. a scope
+func (g *Git) readSigningConfig(opts ...string) *SigningConfig {
+	get := func(key string) string {
+		return g.getConfig(append(opts, key)...)
+	}
+	return &SigningConfig{
+		Format:             SigningFormat(get("gpg.format")),
+		Key:                get("user.signingkey"),
+		SignCommits:        get("commit.gpgsign") == "true",
+		SignTags:           get("tag.gpgsign") == "true",
+		AllowedSignersFile: get("gpg.ssh.allowedSignersFile"),
+	}
+}
+
+// GetSigningConfig returns the effective signing configuration
+func (g *Git) GetSigningConfig() (*SigningConfig, error) {
+	cfg := g.readSigningConfig()
+	if cfg.Format == "" {
+		cfg.Format = FormatOpenPGP
+	}
+	return cfg, nil
+}
+
+// GetScopedSigningConfig returns the signing configuration set in the
+// repository, or in the global configuration when global is set. Unset
+// values are empty or false
+func (g *Git) GetScopedSigningConfig(global bool) (*SigningConfig, error) {
+	return g.readSigningConfig(configScope(global)), nil
+}
+
+// values returns the config keys and values of the signing configuration
+func (c SigningConfig) values() [][2]string {
+	return [][2]string{
+		{"gpg.format", string(c.Format)},
+		{"user.signingkey", c.Key},
+		{"commit.gpgsign", fmt.Sprintf("%t", c.SignCommits)},
+		{"tag.gpgsign", fmt.Sprintf("%t", c.SignTags)},
+		{"gpg.ssh.allowedSignersFile", c.AllowedSignersFile},
+	}
+}
+
+// SetSigningConfig writes the signing configuration to the repository,
+// or to the global configuration when global is set. Only the values that
+// differ from GetScopedSigningConfig are written, so settings inherited
+// from another scope are not copied or overridden
+func (g *Git) SetSigningConfig(cfg SigningConfig, global bool) error {
+	switch cfg.Format {
+	case "", FormatOpenPGP, FormatSSH, FormatX509:
+	default:
+		return fmt.Errorf("unsupported signing format: %s", cfg.Format)
+	}
+
+	scope := configScope(global)
+	current, err := g.GetScopedSigningConfig(global)
+	if err != nil {
+		return err
+	}
+	old := current.values()
+	for i, v := range cfg.values() {
+		if v[1] == old[i][1] {
+			continue
+		}
+		if v[1] == "" {
+			// Unsetting a missing key exits with 5, which is fine
+			g.Execute("config", scope, "--unset", v[0])
+			continue
+		}
+		if _, err := g.Execute("config", scope, v[0], v[1]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// CommitWithOptions creates a new commit
+func (g *Git) CommitWithOptions(message string, opts CommitOptions) error {
+	_, err := g.Execute(commitArgs(message, opts)...)
+	return err
+}
+
+// commitArgs returns the git arguments for a commit
+func commitArgs(message string, opts CommitOptions) []string {
+	args := []string{"commit", "-m", message}
+	if opts.Amend {
+		args = append(args, "--amend")
+	}
+	if opts.SignOff {
+		args = append(args, "--signoff")
+	}
+	if opts.Sign {
+		if opts.Key != "" {
+			args = append(args, "--gpg-sign="+opts.Key)
+		} else {
+			args = append(args, "-S")
+		}
+	}
+	return args
+}
+
+// CreateSignedTag creates a signed annotated tag
+func (g *Git) CreateSignedTag(name, message, key string) error {
+	if message == "" {
+		message = name
+	}
+	args := []string{"tag", "-m", message}
+	if key != "" {
+		args = append(args, "-u", key)
+	} else {
+		args = append(args, "-s")
+	}
+	args = append(args, name)
+	_, err := g.Execute(args...)
+	return err
+}
+
+// VerifyCommit checks the signature of a commit
+func (g *Git) VerifyCommit(hash string) error {
+	_, err := g.Execute("verify-commit", hash)
+	return err
+}
+
+// VerifySignature fills the signature fields of c
+func (g *Git) VerifySignature(c *Commit) error {
+	out, err := g.Execute("show", "--no-patch", "--format=%G?%x00%GS%x00%GK", c.Hash, "--")
+	if err != nil {
+		return err
+	}
+	parts := strings.Split(strings.TrimSpace(out), "\x00")
+	if len(parts) < 3 {
+		return fmt.Errorf("unexpected signature output for %s", c.ShortHash)
+	}
+	c.Signature = SignatureStatus(parts[0])
+	c.Signer, c.SignKey = parts[1], parts[2]
+	return nil
+}
+
+// VerifySignatures returns the signature status of the given commits,
+// checked in one call
+func (g *Git) VerifySignatures(hashes []string) (map[string]SignatureStatus, error) {
+	if len(hashes) == 0 {
+		return nil, nil
+	}
+	args := append([]string{"log", "--no-walk=unsorted", "--format=%H%x00%G?"}, hashes...)
+	out, err := g.Execute(append(args, "--")...)
+	if err != nil {
+		return nil, err
+	}
+	return parseSignatures(out), nil
+}
+
+// parseSignatures parses "hash NUL status" lines
+func parseSignatures(out string) map[string]SignatureStatus {
+	statuses := make(map[string]SignatureStatus)
+	for _, line := range strings.Split(out, "\n") {
+		hash, status, ok := strings.Cut(line, "\x00")
+		if ok && hash != "" {
+			statuses[hash] = SignatureStatus(status)
+		}
+	}
+	return statuses
+}
+
+// VerifyTag checks the signature of a tag
+func (g *Git) VerifyTag(name string) error {
+	_, err := g.Execute("verify-tag", name)
+	return err
+}
+
+// allowedSignersPath returns the configured allowed signers file
+func (g *Git) allowedSignersPath() (string, error) {
+	path := g.getConfig("gpg.ssh.allowedSignersFile")
+	if path == "" {
+		return "", fmt.Errorf("gpg.ssh.allowedSignersFile is not set")
+	}
+	if strings.HasPrefix(path, "~/") {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", err
+		}
+		path = filepath.Join(home, path[2:])
+	}
+	// git reads a relative path from the top of the working tree
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(g.repoPath, path)
+	}
+	return path, nil
+}
+
+// GetAllowedSigners returns the entries of the allowed signers file
+func (g *Git) GetAllowedSigners() ([]AllowedSigner, error) {
+	path, err := g.allowedSignersPath()
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var signers []AllowedSigner
+	scanner := bufio.NewScanner(strings.NewReader(string(data)))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if signer, ok := parseAllowedSigner(line); ok {
+			signers = append(signers, signer)
+		}
+	}
+
+	return signers, nil
+}
+
+// parseAllowedSigner parses "principals [options] keytype key"
+func parseAllowedSigner(line string) (AllowedSigner, bool) {
+	fields := strings.Fields(line)
+	if len(fields) < 3 {
+		return AllowedSigner{}, false
+	}
+
+	signer := AllowedSigner{Principals: fields[0]}
+	rest := fields[1:]
+	if !isKeyType(rest[0]) {
+		signer.Options = rest[0]
+		rest = rest[1:]
+	}
+	if len(rest) < 2 || !isKeyType(rest[0]) {
+		return AllowedSigner{}, false
+	}
+	signer.Key = strings.Join(rest, " ")
+	return signer, true
+}
+
+// isKeyType reports whether s looks like an SSH key type
+func isKeyType(s string) bool {
+	return strings.HasPrefix(s, "ssh-") || strings.HasPrefix(s, "ecdsa-") || strings.HasPrefix(s, "sk-")
+}
+
+// String formats the signer as an allowed signers line
+func (s AllowedSigner) String() string {
+	if s.Options != "" {
+		return fmt.Sprintf("%s %s %s", s.Principals, s.Options, s.Key)
+	}
+	return fmt.Sprintf("%s %s", s.Principals, s.Key)
+}
+
+// AddAllowedSigner appends a signer to the allowed signers file
+func (g *Git) AddAllowedSigner(signer AllowedSigner) error {
+	path, err := g.allowedSignersPath()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return err
+	}
+
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	_, err = fmt.Fprintln(f, signer.String())
+	return err
+}
+
+// RemoveAllowedSigner removes all entries for the given principals,
+// keeping comments, blank lines and lines it cannot parse as they are
+func (g *Git) RemoveAllowedSigner(principals string) error {
+	path, err := g.allowedSignersPath()
+	if err != nil {
+		return err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+
+	var lines []string
+	scanner := bufio.NewScanner(strings.NewReader(string(data)))
+	for scanner.Scan() {
+		line := scanner.Text()
+		trimmed := strings.TrimSpace(line)
+		if trimmed != "" && !strings.HasPrefix(trimmed, "#") {
+			if s, ok := parseAllowedSigner(trimmed); ok && s.Principals == principals {
+				continue
+			}
+		}
+		lines = append(lines, line)
+	}
+
+	content := strings.Join(lines, "\n")
+	if content != "" {
+		content += "\n"
+	}
+	return os.WriteFile(path, []byte(content), 0644)
+}
@@ -0,0 +1,165 @@
+package git
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSetSigningConfig(t *testing.T) {
+	g := testRepo(t)
+	run(t, g, "config", "--global", "gpg.format", "ssh")
+	run(t, g, "config", "--global", "gpg.ssh.allowedSignersFile", "~/.ssh/allowed_signers")
+	run(t, g, "config", "--global", "commit.gpgsign", "true")
+
+	effective, err := g.GetSigningConfig()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if effective.Format != FormatSSH || !effective.SignCommits {
+		t.Fatalf("GetSigningConfig() = %+v, want the global values", effective)
+	}
+
+	local, err := g.GetScopedSigningConfig(false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if *local != (SigningConfig{}) {
+		t.Fatalf("GetScopedSigningConfig(false) = %+v, want nothing set", local)
+	}
+
+	cfg := *local
+	cfg.Key = "~/.ssh/id_ed25519.pub"
+	if err := g.SetSigningConfig(cfg, false); err != nil {
+		t.Fatal(err)
+	}
+	got := strings.TrimSpace(run(t, g, "config", "--local", "--list"))
+	var signing []string
+	for _, line := range strings.Split(got, "\n") {
+		if !strings.HasPrefix(line, "core.") {
+			signing = append(signing, line)
+		}
+	}
+	want := []string{"user.signingkey=~/.ssh/id_ed25519.pub"}
+	if strings.Join(signing, "\n") != strings.Join(want, "\n") {
+		t.Errorf("local config = %q, want only %q", signing, want)
+	}
+
+	effective, _ = g.GetSigningConfig()
+	if !effective.SignCommits || effective.Format != FormatSSH {
+		t.Errorf("global settings were overridden: %+v", effective)
+	}
+
+	cfg.Key = ""
+	cfg.SignCommits = true
+	if err := g.SetSigningConfig(cfg, false); err != nil {
+		t.Fatal(err)
+	}
+	local, _ = g.GetScopedSigningConfig(false)
+	if *local != (SigningConfig{SignCommits: true}) {
+		t.Errorf("GetScopedSigningConfig(false) = %+v, want only commit.gpgsign", local)
+	}
+
+	if err := g.SetSigningConfig(SigningConfig{Format: "pgp"}, false); err == nil {
+		t.Error("SetSigningConfig accepted an unsupported format")
+	}
+}
+
+func TestParseAllowedSigner(t *testing.T) {
+	tests := []struct {
+		line string
+		want AllowedSigner
+		ok   bool
+	}{
+		{
+			"ann@example.com ssh-ed25519 AAAAC3Nza ann",
+			AllowedSigner{Principals: "ann@example.com", Key: "ssh-ed25519 AAAAC3Nza ann"},
+			true,
+		},
+		{
+			`*@example.com namespaces="git" ecdsa-sha2-nistp256 AAAAE2Vj`,
+			AllowedSigner{Principals: "*@example.com", Options: `namespaces="git"`, Key: "ecdsa-sha2-nistp256 AAAAE2Vj"},
+			true,
+		},
+		{
+			"bob@example.com,bo@example.com sk-ssh-ed25519@openssh.com AAAAGnNr",
+			AllowedSigner{Principals: "bob@example.com,bo@example.com", Key: "sk-ssh-ed25519@openssh.com AAAAGnNr"},
+			true,
+		},
+		{"ann@example.com ssh-ed25519", AllowedSigner{}, false},
+		{"ann@example.com cert-authority ssh-ed25519", AllowedSigner{}, false},
+		{"", AllowedSigner{}, false},
+	}
+	for _, tt := range tests {
+		got, ok := parseAllowedSigner(tt.line)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("parseAllowedSigner(%q) = %+v, %v, want %+v, %v", tt.line, got, ok, tt.want, tt.ok)
+		}
+		if ok && got.String() != tt.line {
+			t.Errorf("String() = %q, want %q", got.String(), tt.line)
+		}
+	}
+}
+
+func TestRemoveAllowedSigner(t *testing.T) {
+	g := testRepo(t)
+	run(t, g, "config", "gpg.ssh.allowedSignersFile", "allowed_signers")
+	writeFile(t, g, "allowed_signers", strings.Join([]string{
+		"# Team keys",
+		"ann@example.com ssh-ed25519 AAAA1",
+		"",
+		"bob@example.com ssh-ed25519 AAAA2",
+		"ann@example.com namespaces=\"git\" ssh-rsa AAAA3",
+		"not a valid line",
+		"",
+	}, "\n"))
+
+	if err := g.RemoveAllowedSigner("ann@example.com"); err != nil {
+		t.Fatal(err)
+	}
+	want := strings.Join([]string{
+		"# Team keys",
+		"",
+		"bob@example.com ssh-ed25519 AAAA2",
+		"not a valid line",
+		"",
+	}, "\n")
+	if got := readFile(t, g, "allowed_signers"); got != want {
+		t.Errorf("allowed signers = %q, want %q", got, want)
+	}
+
+	signers, err := g.GetAllowedSigners()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(signers) != 1 || signers[0].Principals != "bob@example.com" {
+		t.Errorf("GetAllowedSigners() = %+v, want only bob", signers)
+	}
+}
+
+func TestParseSignatures(t *testing.T) {
+	out := "aaa\x00G\nbbb\x00N\nccc\x00\n\n"
+	got := parseSignatures(out)
+	want := map[string]SignatureStatus{"aaa": SigGood, "bbb": SigNone, "ccc": ""}
+	if len(got) != len(want) {
+		t.Fatalf("parseSignatures() = %v, want %v", got, want)
+	}
+	for hash, status := range want {
+		if got[hash] != status {
+			t.Errorf("parseSignatures()[%s] = %q, want %q", hash, got[hash], status)
+		}
+	}
+}
+
+func TestVerifySignatures(t *testing.T) {
+	g := testRepo(t)
+	first := strings.TrimSpace(run(t, g, "rev-parse", "HEAD"))
+	second := commitFile(t, g, "b.txt", "b\n", "second")
+
+	got, err := g.VerifySignatures([]string{second, first})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 2 || got[first] != SigNone || got[second] != SigNone {
+		t.Errorf("VerifySignatures() = %v, want both unsigned", got)
+	}
+}
@@ -0,0 +1,223 @@
+package git
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// StashOptions configures a stash push
+type StashOptions struct {
+	Message          string
+	Paths            []string // Only stash these paths
+	IncludeUntracked bool
+	KeepIndex        bool
+}
+
+// Hunk is one hunk of an unstaged diff
+type Hunk struct {
+	Path       string
+	Header     string // "@@ -a,b +c,d @@" line
+	Lines      []string
+	fileHeader string
+}
+
+// StashPush stashes changes according to opts
+func (g *Git) StashPush(opts StashOptions) error {
+	args := []string{"stash", "push"}
+	if opts.Message != "" {
+		args = append(args, "-m", opts.Message)
+	}
+	if opts.IncludeUntracked {
+		args = append(args, "--include-untracked")
+	}
+	if opts.KeepIndex {
+		args = append(args, "--keep-index")
+	}
+	if len(opts.Paths) > 0 {
+		args = append(args, "--")
+		args = append(args, opts.Paths...)
+	}
+	_, err := g.Execute(args...)
+	return err
+}
+
+// StashShow returns the diff a stash entry records
+func (g *Git) StashShow(index int) (string, error) {
+	return g.Execute("stash", "show", "--patch", "--stat", fmt.Sprintf("stash@{%d}", index))
+}
+
+// StashBranch creates a branch at the stash's base commit, checks it out,
+// applies the stash and drops it if it applied cleanly
+func (g *Git) StashBranch(name string, index int) error {
+	_, err := g.Execute("stash", "branch", name, fmt.Sprintf("stash@{%d}", index))
+	return err
+}
+
+// RenameStash changes the message of a stash entry by storing a copy of
+// its commit with the new message and dropping the old entry. The renamed
+// entry becomes stash@{0}.
+func (g *Git) RenameStash(stash Stash, message string) error {
+	if stash.Branch != "" {
+		message = "On " + stash.Branch + ": " + message
+	}
+
+	// Storing the same commit again would not add a new entry, so copy
+	// it with the same tree and parents
+	out, err := g.Execute("log", "-1", "--format=%T %P", stash.Hash)
+	if err != nil {
+		return err
+	}
+	fields := strings.Fields(out)
+	if len(fields) == 0 {
+		return fmt.Errorf("cannot read stash@{%d}", stash.Index)
+	}
+	args := []string{"commit-tree", fields[0], "-m", message}
+	for _, parent := range fields[1:] {
+		args = append(args, "-p", parent)
+	}
+	commit, err := g.Execute(args...)
+	if err != nil {
+		return err
+	}
+
+	if _, err := g.Execute("stash", "store", "-m", message, strings.TrimSpace(commit)); err != nil {
+		return err
+	}
+	// Storing pushed the old entry down by one
+	return g.StashDrop(stash.Index + 1)
+}
+
+// GetHunks returns the hunks of the unstaged changes to paths
+func (g *Git) GetHunks(paths ...string) ([]Hunk, error) {
+	args := append([]string{"diff", "--no-color", "--no-ext-diff", "--"}, paths...)
+	out, err := g.Execute(args...)
+	if err != nil {
+		return nil, err
+	}
+
+	var hunks []Hunk
+	var fileHeader []string
+	var path string
+	inHeader := false
+
+	scanner := bufio.NewScanner(strings.NewReader(out))
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case strings.HasPrefix(line, "diff --git "):
+			fileHeader = []string{line}
+			path = ""
+			inHeader = true
+		case strings.HasPrefix(line, "@@"):
+			inHeader = false
+			hunks = append(hunks, Hunk{
+				Path:       path,
+				Header:     line,
+				fileHeader: strings.Join(fileHeader, "\n"),
+			})
+		case inHeader:
+			fileHeader = append(fileHeader, line)
+			if strings.HasPrefix(line, "+++ b/") {
+				path = strings.TrimPrefix(line, "+++ b/")
+			} else if strings.HasPrefix(line, "--- a/") && path == "" {
+				path = strings.TrimPrefix(line, "--- a/")
+			}
+		case len(hunks) > 0:
+			h := &hunks[len(hunks)-1]
+			h.Lines = append(h.Lines, line)
+		}
+	}
+
+	return hunks, nil
+}
+
+// BuildPatch joins hunks into a patch, grouping them by file
+func BuildPatch(hunks []Hunk) string {
+	var b strings.Builder
+	lastHeader := ""
+	for _, h := range hunks {
+		if h.fileHeader != lastHeader {
+			b.WriteString(h.fileHeader + "\n")
+			lastHeader = h.fileHeader
+		}
+		b.WriteString(h.Header + "\n")
+		for _, line := range h.Lines {
+			b.WriteString(line + "\n")
+		}
+	}
+	return b.String()
+}
+
+// StashHunks stashes only the given unstaged hunks and removes them from
+// the working tree. Staged changes are recorded in the stash as they are
+// and stay staged.
+func (g *Git) StashHunks(message string, hunks []Hunk) error {
+	if len(hunks) == 0 {
+		return fmt.Errorf("no hunks selected")
+	}
+	patch := BuildPatch(hunks)
+
+	branch, err := g.GetCurrentBranch()
+	if err != nil {
+		return err
+	}
+	head, err := g.Execute("log", "-1", "--format=%H%n%h %s")
+	if err != nil {
+		return err
+	}
+	headHash, headSubject, _ := strings.Cut(strings.TrimSpace(head), "\n")
+	if message == "" {
+		message = "WIP on " + branch + ": " + headSubject
+	} else {
+		message = "On " + branch + ": " + message
+	}
+
+	indexTree, err := g.Execute("write-tree")
+	if err != nil {
+		return err
+	}
+	indexTree = strings.TrimSpace(indexTree)
+
+	// Build the working tree state in a scratch index: the real index
+	// plus the selected hunks
+	tmp, err := os.CreateTemp("", "gitflow-stash-index-")
+	if err != nil {
+		return err
+	}
+	tmp.Close()
+	os.Remove(tmp.Name())
+	defer os.Remove(tmp.Name())
+	env := []string{"GIT_INDEX_FILE=" + tmp.Name()}
+
+	if _, err := g.executeWith(env, "", "read-tree", indexTree); err != nil {
+		return err
+	}
+	if _, err := g.executeWith(env, patch, "apply", "--cached", "-"); err != nil {
+		return err
+	}
+	workTree, err := g.executeWith(env, "", "write-tree")
+	if err != nil {
+		return err
+	}
+
+	// A stash is a commit of the working tree whose parents are HEAD and
+	// a commit of the index
+	indexCommit, err := g.Execute("commit-tree", indexTree, "-p", headHash, "-m", "index on "+branch+": "+headSubject)
+	if err != nil {
+		return err
+	}
+	stashCommit, err := g.Execute("commit-tree", strings.TrimSpace(workTree),
+		"-p", headHash, "-p", strings.TrimSpace(indexCommit), "-m", message)
+	if err != nil {
+		return err
+	}
+	if _, err := g.Execute("stash", "store", "-m", message, strings.TrimSpace(stashCommit)); err != nil {
+		return err
+	}
+
+	_, err = g.executeWith(nil, patch, "apply", "-R", "-")
+	return err
+}
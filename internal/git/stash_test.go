@@ -0,0 +1,186 @@
+package git
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// numberedLines returns lines "1" to "n", with the given lines replaced
+func numberedLines(n int, replace map[int]string) string {
+	var b strings.Builder
+	for i := 1; i <= n; i++ {
+		if line, ok := replace[i]; ok {
+			b.WriteString(line + "\n")
+		} else {
+			fmt.Fprintf(&b, "%d\n", i)
+		}
+	}
+	return b.String()
+}
+
+func TestGetHunks(t *testing.T) {
+	g := testRepo(t)
+	commitFile(t, g, "long.txt", numberedLines(30, nil), "long")
+	commitFile(t, g, "dir/b.txt", "b\n", "b")
+
+	writeFile(t, g, "long.txt", numberedLines(30, map[int]string{2: "two", 28: "twenty-eight"}))
+	writeFile(t, g, "dir/b.txt", "b\nmore\n")
+	writeFile(t, g, "a.txt", "staged\n")
+	run(t, g, "add", "a.txt")
+
+	hunks, err := g.GetHunks()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, h := range hunks {
+		got = append(got, h.Path+" "+h.Header)
+	}
+	want := []string{
+		"dir/b.txt @@ -1 +1,2 @@",
+		"long.txt @@ -1,5 +1,5 @@",
+		"long.txt @@ -25,6 +25,6 @@",
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Fatalf("GetHunks() = %q, want %q", got, want)
+	}
+	if lines := strings.Join(hunks[1].Lines, "\n"); !strings.Contains(lines, "-2\n+two") {
+		t.Errorf("hunk lines = %q, want the change to line 2", lines)
+	}
+
+	only, err := g.GetHunks("dir")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(only) != 1 || only[0].Path != "dir/b.txt" {
+		t.Errorf("GetHunks(dir) = %+v, want the dir/b.txt hunk", only)
+	}
+}
+
+func TestBuildPatch(t *testing.T) {
+	a := "diff --git a/a b/a\n--- a/a\n+++ b/a"
+	b := "diff --git a/b b/b\n--- a/b\n+++ b/b"
+	hunks := []Hunk{
+		{Path: "a", Header: "@@ -1 +1 @@", Lines: []string{"-x", "+y"}, fileHeader: a},
+		{Path: "a", Header: "@@ -9 +9 @@", Lines: []string{"-z", "+w"}, fileHeader: a},
+		{Path: "b", Header: "@@ -1 +1,2 @@", Lines: []string{" b", "+c"}, fileHeader: b},
+	}
+	want := a + "\n@@ -1 +1 @@\n-x\n+y\n@@ -9 +9 @@\n-z\n+w\n" + b + "\n@@ -1 +1,2 @@\n b\n+c\n"
+	if got := BuildPatch(hunks); got != want {
+		t.Errorf("BuildPatch() = %q, want %q", got, want)
+	}
+	if got := BuildPatch(nil); got != "" {
+		t.Errorf("BuildPatch(nil) = %q, want empty", got)
+	}
+}
+
+func TestStashHunks(t *testing.T) {
+	g := testRepo(t)
+	commitFile(t, g, "long.txt", numberedLines(30, nil), "long")
+	commitFile(t, g, "b.txt", "b\n", "b")
+
+	changed := numberedLines(30, map[int]string{2: "two", 28: "twenty-eight"})
+	writeFile(t, g, "long.txt", changed)
+	writeFile(t, g, "b.txt", "b\nmore\n")
+	writeFile(t, g, "a.txt", "staged\n")
+	run(t, g, "add", "a.txt")
+
+	hunks, err := g.GetHunks()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Stash b.txt and the first long.txt hunk, keeping line 28
+	if err := g.StashHunks("partial", []Hunk{hunks[0], hunks[1]}); err != nil {
+		t.Fatal(err)
+	}
+
+	if got, want := readFile(t, g, "long.txt"), numberedLines(30, map[int]string{28: "twenty-eight"}); got != want {
+		t.Errorf("long.txt = %q, want only the unselected hunk left", got)
+	}
+	if got := readFile(t, g, "b.txt"); got != "b\n" {
+		t.Errorf("b.txt = %q, want the stashed change removed", got)
+	}
+	if staged := run(t, g, "diff", "--cached", "--name-only"); staged != "a.txt\n" {
+		t.Errorf("staged = %q, want a.txt to stay staged", staged)
+	}
+
+	stashes, err := g.GetStash()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(stashes) != 1 || stashes[0].Branch != "main" || stashes[0].Message != "partial" {
+		t.Fatalf("GetStash() = %+v, want one entry on main", stashes)
+	}
+	files := run(t, g, "diff", "--name-only", "stash@{0}^1", "stash@{0}")
+	if files != "a.txt\nb.txt\nlong.txt\n" {
+		t.Errorf("stash changes files %q", files)
+	}
+	if got := run(t, g, "show", "stash@{0}:long.txt"); got != numberedLines(30, map[int]string{2: "two"}) {
+		t.Errorf("stashed long.txt = %q, want only the selected hunk", got)
+	}
+	if got := run(t, g, "show", "stash@{0}^2:a.txt"); got != "staged\n" {
+		t.Errorf("stashed index a.txt = %q, want the staged content", got)
+	}
+
+	// The stash applies back onto a clean tree
+	run(t, g, "reset", "-q", "--hard")
+	run(t, g, "stash", "pop", "-q")
+	if got := readFile(t, g, "long.txt"); got != numberedLines(30, map[int]string{2: "two"}) {
+		t.Errorf("popped long.txt = %q", got)
+	}
+
+	if err := g.StashHunks("none", nil); err == nil {
+		t.Error("StashHunks() without hunks succeeded")
+	}
+}
+
+func TestRenameStash(t *testing.T) {
+	g := testRepo(t)
+	writeFile(t, g, "a.txt", "first stash\n")
+	run(t, g, "stash", "push", "-q", "-m", "older")
+	writeFile(t, g, "a.txt", "second stash\n")
+	writeFile(t, g, "new.txt", "untracked\n")
+	run(t, g, "stash", "push", "-q", "--include-untracked")
+
+	stashes, err := g.GetStash()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(stashes) != 2 {
+		t.Fatalf("GetStash() = %+v, want 2 entries", stashes)
+	}
+	target := stashes[0]
+	before := run(t, g, "log", "-1", "--format=%T %P", target.Hash)
+
+	if err := g.RenameStash(target, "renamed"); err != nil {
+		t.Fatal(err)
+	}
+
+	stashes, err = g.GetStash()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(stashes) != 2 {
+		t.Fatalf("GetStash() = %+v, want 2 entries after the rename", stashes)
+	}
+	renamed := stashes[0]
+	if renamed.Message != "renamed" || renamed.Branch != "main" || renamed.Hash == target.Hash {
+		t.Errorf("renamed stash = %+v", renamed)
+	}
+	if after := run(t, g, "log", "-1", "--format=%T %P", renamed.Hash); after != before {
+		t.Errorf("tree and parents = %q, want %q", after, before)
+	}
+	if stashes[1].Message != "older" {
+		t.Errorf("stash@{1} = %+v, want the older entry untouched", stashes[1])
+	}
+
+	// Renaming an older entry keeps the other one
+	if err := g.RenameStash(stashes[1], "oldest"); err != nil {
+		t.Fatal(err)
+	}
+	stashes, _ = g.GetStash()
+	if len(stashes) != 2 || stashes[0].Message != "oldest" || stashes[1].Message != "renamed" {
+		t.Errorf("GetStash() = %+v, want oldest then renamed", stashes)
+	}
+}
@@ -0,0 +1,253 @@
+package git
+
+import (
+	"bufio"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Submodule is a repository nested in this one
+type Submodule struct {
+	Name        string
+	Path        string // Relative to the top-level repository
+	URL         string
+	Branch      string // Branch to follow, from .gitmodules
+	Recorded    string // Commit the parent repository records
+	Current     string // Commit checked out, empty when not initialized
+	Describe    string // e.g. "heads/main" or "v1.2.0"
+	Initialized bool
+	Conflict    bool
+	Depth       int // 0 for submodules of this repository
+}
+
+// OutOfSync reports whether the checked-out commit differs from the
+// recorded one
+func (s Submodule) OutOfSync() bool {
+	return s.Initialized && s.Recorded != "" && s.Current != s.Recorded
+}
+
+// GetSubmodules returns the submodules, including nested ones when
+// recursive is set, in path order
+func (g *Git) GetSubmodules(recursive bool) ([]Submodule, error) {
+	args := []string{"submodule", "status"}
+	if recursive {
+		args = append(args, "--recursive")
+	}
+	out, err := g.Execute(args...)
+	if err != nil {
+		return nil, err
+	}
+
+	var subs []Submodule
+	for _, line := range strings.Split(out, "\n") {
+		if s, ok := parseSubmoduleLine(line); ok {
+			subs = append(subs, s)
+		}
+	}
+	sort.Slice(subs, func(i, j int) bool { return subs[i].Path < subs[j].Path })
+
+	// Nested submodules are described by their parent submodule
+	type parentInfo struct {
+		links   map[string]string
+		modules map[string]gitmodule
+	}
+	parents := make(map[string]parentInfo)
+	for i := range subs {
+		s := &subs[i]
+		parent := ""
+		for _, p := range subs[:i] {
+			if strings.HasPrefix(s.Path, p.Path+"/") {
+				parent = p.Path
+				s.Depth++
+			}
+		}
+
+		info, ok := parents[parent]
+		if !ok {
+			pg := New(filepath.Join(g.repoPath, parent))
+			info.links = pg.gitlinks()
+			info.modules = pg.gitmodules()
+			parents[parent] = info
+		}
+
+		rel := strings.TrimPrefix(s.Path, parent+"/")
+		if s.Recorded == "" {
+			s.Recorded = info.links[rel]
+		}
+		mod := info.modules[rel]
+		s.Name, s.URL, s.Branch = mod.name, mod.url, mod.branch
+		if s.Name == "" {
+			s.Name = rel
+		}
+	}
+
+	return subs, nil
+}
+
+// parseSubmoduleLine parses a line of git submodule status, e.g.
+// "+HASH path with spaces (heads/main)"; the describe part is missing
+// for uninitialized submodules
+func parseSubmoduleLine(line string) (Submodule, bool) {
+	if len(line) < 2 {
+		return Submodule{}, false
+	}
+	hash, rest, found := strings.Cut(line[1:], " ")
+	if !found || rest == "" {
+		return Submodule{}, false
+	}
+
+	s := Submodule{Path: rest}
+	if i := strings.LastIndex(rest, " ("); i > 0 && line[0] != '-' && strings.HasSuffix(rest, ")") {
+		s.Path, s.Describe = rest[:i], rest[i+2:len(rest)-1]
+	}
+	switch line[0] {
+	case '-':
+		s.Recorded = hash
+	case '+':
+		s.Current, s.Initialized = hash, true
+	case 'U':
+		s.Current, s.Initialized, s.Conflict = hash, true, true
+	default:
+		s.Current, s.Recorded, s.Initialized = hash, hash, true
+	}
+	return s, true
+}
+
+// gitlinks returns the commits the index records for submodule paths
+func (g *Git) gitlinks() map[string]string {
+	links := make(map[string]string)
+	out, err := g.Execute("ls-files", "--stage")
+	if err != nil {
+		return links
+	}
+	for _, line := range strings.Split(out, "\n") {
+		meta, path, found := strings.Cut(line, "\t")
+		fields := strings.Fields(meta)
+		if found && len(fields) == 3 && fields[0] == "160000" {
+			links[path] = fields[1]
+		}
+	}
+	return links
+}
+
+// gitmodule is a submodule's entry in .gitmodules
+type gitmodule struct {
+	name, path, url, branch string
+}
+
+// gitmodules reads .gitmodules, keyed by submodule path
+func (g *Git) gitmodules() map[string]gitmodule {
+	out, err := g.Execute("config", "-f", ".gitmodules", "--get-regexp", `^submodule\.`)
+	if err != nil {
+		return nil
+	}
+
+	// Settings are keyed by name ("submodule.NAME.url")
+	byName := make(map[string]*gitmodule)
+	for _, line := range strings.Split(out, "\n") {
+		key, value, _ := strings.Cut(line, " ")
+		i := strings.LastIndex(key, ".")
+		if i < 0 || !strings.HasPrefix(key, "submodule.") {
+			continue
+		}
+		name := key[len("submodule."):i]
+		if byName[name] == nil {
+			byName[name] = &gitmodule{name: name}
+		}
+		switch key[i+1:] {
+		case "path":
+			byName[name].path = value
+		case "url":
+			byName[name].url = value
+		case "branch":
+			byName[name].branch = value
+		}
+	}
+
+	modules := make(map[string]gitmodule)
+	for _, mod := range byName {
+		modules[mod.path] = *mod
+	}
+	return modules
+}
+
+// submoduleChanges describes how each changed submodule differs from the
+// recorded commit, e.g. "new commits, untracked content". It returns nil
+// without a .gitmodules file.
+func (g *Git) submoduleChanges() map[string]string {
+	if _, err := os.Stat(filepath.Join(g.repoPath, ".gitmodules")); err != nil {
+		return nil
+	}
+	out, err := g.Execute("status", "--porcelain=v2")
+	if err != nil {
+		return nil
+	}
+
+	changes := make(map[string]string)
+	scanner := bufio.NewScanner(strings.NewReader(out))
+	for scanner.Scan() {
+		// "1 XY SCMU mH mI mW hH hI path"
+		fields := strings.SplitN(scanner.Text(), " ", 9)
+		if len(fields) < 9 || fields[0] != "1" || !strings.HasPrefix(fields[2], "S") {
+			continue
+		}
+		var parts []string
+		if fields[2][1] == 'C' {
+			parts = append(parts, "new commits")
+		}
+		if fields[2][2] == 'M' {
+			parts = append(parts, "modified content")
+		}
+		if fields[2][3] == 'U' {
+			parts = append(parts, "untracked content")
+		}
+		if len(parts) > 0 {
+			changes[fields[8]] = strings.Join(parts, ", ")
+		}
+	}
+	return changes
+}
+
+// InitSubmodules registers submodules in .git/config so update clones
+// them; all submodules when no paths are given
+func (g *Git) InitSubmodules(paths ...string) error {
+	_, err := g.Execute(append([]string{"submodule", "init", "--"}, paths...)...)
+	return err
+}
+
+// UpdateSubmodules checks out the recorded commits, cloning submodules
+// that are missing, and writes git's progress to w
+func (g *Git) UpdateSubmodules(w io.Writer, init, recursive bool, paths ...string) error {
+	args := []string{"submodule", "update"}
+	if init {
+		args = append(args, "--init")
+	}
+	if recursive {
+		args = append(args, "--recursive")
+	}
+	return g.ExecuteTo(w, append(append(args, "--"), paths...)...)
+}
+
+// SyncSubmodules copies submodule URLs from .gitmodules to .git/config,
+// e.g. after a submodule moved
+func (g *Git) SyncSubmodules(recursive bool, paths ...string) error {
+	args := []string{"submodule", "sync"}
+	if recursive {
+		args = append(args, "--recursive")
+	}
+	_, err := g.Execute(append(append(args, "--"), paths...)...)
+	return err
+}
+
+// Superproject returns the working tree of the repository this one is a
+// submodule of, or "" when it is not a submodule
+func (g *Git) Superproject() (string, error) {
+	out, err := g.Execute("rev-parse", "--show-superproject-working-tree")
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(out), nil
+}
@@ -0,0 +1,127 @@
+package git
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseSubmoduleLine(t *testing.T) {
+	hash := strings.Repeat("a", 40)
+	tests := []struct {
+		line string
+		want Submodule
+		ok   bool
+	}{
+		{" " + hash + " lib (heads/main)", Submodule{Path: "lib", Current: hash, Recorded: hash,
+			Describe: "heads/main", Initialized: true}, true},
+		{"+" + hash + " my lib (v1.2.0-3-gabcdef)", Submodule{Path: "my lib", Current: hash,
+			Describe: "v1.2.0-3-gabcdef", Initialized: true}, true},
+		{"U" + hash + " a (b) (heads/x)", Submodule{Path: "a (b)", Current: hash, Describe: "heads/x",
+			Initialized: true, Conflict: true}, true},
+		// Uninitialized submodules have no describe part
+		{"-" + hash + " vendor/odd (name)", Submodule{Path: "vendor/odd (name)", Recorded: hash}, true},
+		{"-" + hash + " deps/x", Submodule{Path: "deps/x", Recorded: hash}, true},
+		{"-" + hash, Submodule{}, false},
+		{"", Submodule{}, false},
+	}
+	for _, tt := range tests {
+		got, ok := parseSubmoduleLine(tt.line)
+		if ok != tt.ok || !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("parseSubmoduleLine(%q) = %+v, %v, want %+v, %v", tt.line, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestGetSubmodules(t *testing.T) {
+	inner := testRepo(t)
+	lib := testRepo(t)
+	addSubmodule(t, lib, inner.repoPath, "deps/inner")
+
+	g := testRepo(t)
+	run(t, g, "config", "--global", "protocol.file.allow", "always")
+	run(t, g, "submodule", "add", "-q", "--name", "lib", "-b", "main", lib.repoPath, "my lib")
+	run(t, g, "commit", "-q", "-m", "Add lib")
+	run(t, g, "submodule", "update", "-q", "--init", "--recursive")
+	addSubmodule(t, g, inner.repoPath, "vendor/inner")
+	run(t, g, "submodule", "deinit", "-q", "vendor/inner")
+
+	libHead := strings.TrimSpace(run(t, lib, "rev-parse", "HEAD"))
+	innerHead := strings.TrimSpace(run(t, inner, "rev-parse", "HEAD"))
+	libWt := New(g.repoPath + "/my lib")
+	ahead := commitFile(t, libWt, "b.txt", "b\n", "ahead")
+
+	subs, err := g.GetSubmodules(true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []Submodule{
+		{Name: "lib", Path: "my lib", URL: lib.repoPath, Branch: "main", Recorded: libHead, Current: ahead,
+			Describe: "heads/main", Initialized: true},
+		{Name: "deps/inner", Path: "my lib/deps/inner", URL: inner.repoPath, Recorded: innerHead,
+			Current: innerHead, Describe: "heads/main", Initialized: true, Depth: 1},
+		{Name: "vendor/inner", Path: "vendor/inner", URL: inner.repoPath, Recorded: innerHead},
+	}
+	if !reflect.DeepEqual(subs, want) {
+		t.Errorf("GetSubmodules(true) =\n%+v\nwant\n%+v", subs, want)
+	}
+	if !subs[0].OutOfSync() || subs[1].OutOfSync() || subs[2].OutOfSync() {
+		t.Errorf("OutOfSync() = %v, %v, %v, want only lib", subs[0].OutOfSync(), subs[1].OutOfSync(), subs[2].OutOfSync())
+	}
+
+	subs, err = g.GetSubmodules(false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(subs) != 2 || subs[0].Path != "my lib" || subs[1].Path != "vendor/inner" {
+		t.Errorf("GetSubmodules(false) = %+v, want lib and vendor/inner", subs)
+	}
+}
+
+func TestGitmodules(t *testing.T) {
+	g := testRepo(t)
+	if modules := g.gitmodules(); modules != nil {
+		t.Errorf("gitmodules() without .gitmodules = %+v, want nil", modules)
+	}
+
+	commitFile(t, g, ".gitmodules", `[submodule "lib"]
+	path = third party/lib
+	url = https://example.com/lib.git
+	branch = stable
+[submodule "tools.v2"]
+	path = tools
+	url = ../tools.git
+`, "Add .gitmodules")
+	want := map[string]gitmodule{
+		"third party/lib": {name: "lib", path: "third party/lib", url: "https://example.com/lib.git", branch: "stable"},
+		"tools":           {name: "tools.v2", path: "tools", url: "../tools.git"},
+	}
+	if got := g.gitmodules(); !reflect.DeepEqual(got, want) {
+		t.Errorf("gitmodules() = %+v, want %+v", got, want)
+	}
+}
+
+func TestSubmoduleChanges(t *testing.T) {
+	g := testRepo(t)
+	if changes := g.submoduleChanges(); changes != nil {
+		t.Errorf("submoduleChanges() without submodules = %v, want nil", changes)
+	}
+
+	inner := testRepo(t)
+	lib := testRepo(t)
+	addSubmodule(t, lib, inner.repoPath, "deps/inner")
+	addSubmodule(t, g, lib.repoPath, "my lib")
+	addSubmodule(t, g, inner.repoPath, "clean")
+	run(t, g, "submodule", "update", "-q", "--init", "--recursive")
+
+	libWt := New(g.repoPath + "/my lib")
+	commitFile(t, libWt, "b.txt", "b\n", "ahead")
+	writeFile(t, libWt, "untracked.txt", "u\n")
+	// Changes in a nested submodule show as modified content
+	writeFile(t, New(libWt.repoPath+"/deps/inner"), "a.txt", "changed\n")
+
+	want := map[string]string{"my lib": "new commits, modified content, untracked content"}
+	if got := g.submoduleChanges(); !reflect.DeepEqual(got, want) {
+		t.Errorf("submoduleChanges() = %v, want %v", got, want)
+	}
+}
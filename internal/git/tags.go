@@ -0,0 +1,182 @@
+package git
+
+import (
+	"bufio"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// TagSync compares local tags with the tags on a remote
+type TagSync struct {
+	Remote     string
+	LocalOnly  []string
+	RemoteOnly []string
+	Differ     []string // Same name, different commit
+}
+
+// GetTags returns all tags, newest first
+func (g *Git) GetTags() ([]Tag, error) {
+	format := strings.Join([]string{
+		"%(refname:short)",
+		"%(objecttype)",
+		"%(objectname)",
+		"%(*objectname)",
+		"%(taggername)",
+		"%(taggeremail:trim)",
+		"%(creatordate:unix)",
+		"%(if)%(contents:signature)%(then)signed%(end)",
+		"%(contents:subject)",
+	}, "%00")
+	out, err := g.Execute("for-each-ref", "--sort=-creatordate", "--format="+format, "refs/tags")
+	if err != nil {
+		return nil, err
+	}
+
+	var tags []Tag
+	scanner := bufio.NewScanner(strings.NewReader(out))
+	for scanner.Scan() {
+		parts := strings.Split(scanner.Text(), "\x00")
+		if len(parts) < 9 {
+			continue
+		}
+
+		tag := Tag{
+			Name:      parts[0],
+			Annotated: parts[1] == "tag",
+			Hash:      parts[2],
+			Tagger:    parts[4],
+			Email:     parts[5],
+			Message:   parts[8],
+			Signature: SigNone,
+		}
+		if tag.Annotated && parts[3] != "" {
+			tag.Hash = parts[3]
+		}
+		if ts, err := strconv.ParseInt(parts[6], 10, 64); err == nil {
+			tag.Date = time.Unix(ts, 0)
+		}
+		// Verifying is slow; VerifyTagSignature does it on demand
+		if parts[7] == "signed" {
+			tag.Signature = SigUnchecked
+		}
+
+		tags = append(tags, tag)
+	}
+
+	return tags, nil
+}
+
+// VerifyTagSignature verifies a signed tag, returning its status and
+// git's complaint when the signature is not good
+func (g *Git) VerifyTagSignature(name string) (SignatureStatus, error) {
+	err := g.VerifyTag(name)
+	switch {
+	case err == nil:
+		return SigGood, nil
+	case strings.Contains(err.Error(), "BAD signature"), strings.Contains(err.Error(), "bad signature"):
+		return SigBad, err
+	default:
+		return SigCannotVerify, err
+	}
+}
+
+// GetRemoteTags returns the tags on a remote, mapped to the commits they
+// point to
+func (g *Git) GetRemoteTags(remote string) (map[string]string, error) {
+	out, err := g.Execute("ls-remote", "--tags", remote)
+	if err != nil {
+		return nil, err
+	}
+
+	tags := make(map[string]string)
+	scanner := bufio.NewScanner(strings.NewReader(out))
+	for scanner.Scan() {
+		hash, ref, ok := strings.Cut(scanner.Text(), "\t")
+		if !ok {
+			continue
+		}
+		name := strings.TrimPrefix(ref, "refs/tags/")
+
+		// Annotated tags are listed twice; the peeled "^{}" entry is the commit
+		if peeled, ok := strings.CutSuffix(name, "^{}"); ok {
+			tags[peeled] = hash
+		} else if _, seen := tags[name]; !seen {
+			tags[name] = hash
+		}
+	}
+	return tags, nil
+}
+
+// CompareTags compares local tags with those on remote
+func (g *Git) CompareTags(remote string) (*TagSync, error) {
+	local, err := g.GetTags()
+	if err != nil {
+		return nil, err
+	}
+	remoteTags, err := g.GetRemoteTags(remote)
+	if err != nil {
+		return nil, err
+	}
+	return SyncTags(remote, local, remoteTags), nil
+}
+
+// SyncTags compares local tags with remoteTags as returned by GetRemoteTags
+func SyncTags(remote string, local []Tag, remoteTags map[string]string) *TagSync {
+	sync := &TagSync{Remote: remote}
+	localNames := make(map[string]bool)
+	for _, t := range local {
+		localNames[t.Name] = true
+		hash, ok := remoteTags[t.Name]
+		switch {
+		case !ok:
+			sync.LocalOnly = append(sync.LocalOnly, t.Name)
+		case hash != t.Hash:
+			sync.Differ = append(sync.Differ, t.Name)
+		}
+	}
+	for name := range remoteTags {
+		if !localNames[name] {
+			sync.RemoteOnly = append(sync.RemoteOnly, name)
+		}
+	}
+
+	sort.Strings(sync.LocalOnly)
+	sort.Strings(sync.RemoteOnly)
+	sort.Strings(sync.Differ)
+	return sync
+}
+
+// PushTag pushes a single tag
+func (g *Git) PushTag(remote, name string) error {
+	_, err := g.Execute("push", remote, "refs/tags/"+name)
+	return err
+}
+
+// PushTags pushes all tags
+func (g *Git) PushTags(remote string) error {
+	_, err := g.Execute("push", remote, "--tags")
+	return err
+}
+
+// DeleteRemoteTag deletes a tag on a remote
+func (g *Git) DeleteRemoteTag(remote, name string) error {
+	_, err := g.Execute("push", remote, "--delete", "refs/tags/"+name)
+	return err
+}
+
+// FetchTag fetches a single tag from a remote
+func (g *Git) FetchTag(remote, name string) error {
+	_, err := g.Execute("fetch", remote, "refs/tags/"+name+":refs/tags/"+name)
+	return err
+}
+
+// LatestTag returns the most recent tag reachable from rev
+func (g *Git) LatestTag(rev string) (string, error) {
+	out, err := g.Execute("describe", "--tags", "--abbrev=0", rev)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(out), nil
+}
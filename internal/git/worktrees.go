@@ -0,0 +1,125 @@
+package git
+
+import (
+	"path/filepath"
+	"strings"
+)
+
+// Worktree is a working tree attached to the repository
+type Worktree struct {
+	Path           string
+	Head           string
+	Branch         string // Empty when detached or bare
+	Main           bool   // The repository's main working tree
+	Bare           bool
+	Detached       bool
+	Locked         bool
+	LockReason     string
+	Prunable       bool // Its directory is gone; prune removes it
+	PrunableReason string
+}
+
+// Name returns the worktree's directory name
+func (w Worktree) Name() string {
+	return filepath.Base(w.Path)
+}
+
+// GetWorktrees returns the main worktree followed by the linked ones
+func (g *Git) GetWorktrees() ([]Worktree, error) {
+	out, err := g.Execute("worktree", "list", "--porcelain")
+	if err != nil {
+		return nil, err
+	}
+	return parseWorktrees(out), nil
+}
+
+// parseWorktrees parses the output of git worktree list --porcelain
+func parseWorktrees(out string) []Worktree {
+	var worktrees []Worktree
+	for _, block := range strings.Split(strings.TrimSpace(out), "\n\n") {
+		var w Worktree
+		for _, line := range strings.Split(block, "\n") {
+			key, value, _ := strings.Cut(line, " ")
+			switch key {
+			case "worktree":
+				w.Path = value
+			case "HEAD":
+				w.Head = value
+			case "branch":
+				w.Branch = strings.TrimPrefix(value, "refs/heads/")
+			case "bare":
+				w.Bare = true
+			case "detached":
+				w.Detached = true
+			case "locked":
+				w.Locked, w.LockReason = true, value
+			case "prunable":
+				w.Prunable, w.PrunableReason = true, value
+			}
+		}
+		if w.Path != "" {
+			w.Main = len(worktrees) == 0
+			worktrees = append(worktrees, w)
+		}
+	}
+	return worktrees
+}
+
+// AddWorktree checks out branch in a new worktree at path. With create
+// the branch is created from startPoint, or HEAD when it is empty.
+func (g *Git) AddWorktree(path, branch string, create bool, startPoint string) error {
+	args := []string{"worktree", "add"}
+	if create {
+		args = append(args, "-b", branch, path)
+		if startPoint != "" {
+			args = append(args, startPoint)
+		}
+	} else {
+		args = append(args, path, branch)
+	}
+	_, err := g.Execute(args...)
+	return err
+}
+
+// AddTrackingWorktree creates branch from the remote-tracking branch
+// upstream, e.g. origin/feature, set to track it, and checks it out in a
+// new worktree at path
+func (g *Git) AddTrackingWorktree(path, branch, upstream string) error {
+	_, err := g.Execute("worktree", "add", "--track", "-b", branch, path, upstream)
+	return err
+}
+
+// RemoveWorktree removes a linked worktree; force also discards its
+// uncommitted changes
+func (g *Git) RemoveWorktree(path string, force bool) error {
+	args := []string{"worktree", "remove"}
+	if force {
+		args = append(args, "--force")
+	}
+	_, err := g.Execute(append(args, path)...)
+	return err
+}
+
+// LockWorktree keeps a worktree from being pruned, e.g. while it is on a
+// removable disk
+func (g *Git) LockWorktree(path, reason string) error {
+	args := []string{"worktree", "lock"}
+	if reason != "" {
+		args = append(args, "--reason", reason)
+	}
+	_, err := g.Execute(append(args, path)...)
+	return err
+}
+
+// UnlockWorktree allows a worktree to be pruned again
+func (g *Git) UnlockWorktree(path string) error {
+	_, err := g.Execute("worktree", "unlock", path)
+	return err
+}
+
+// PruneWorktrees removes the administrative files of worktrees whose
+// directories are gone
+func (g *Git) PruneWorktrees() error {
+	_, err := g.Execute("worktree", "prune")
+	return err
+}
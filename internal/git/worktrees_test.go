@@ -0,0 +1,153 @@
+package git
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseWorktrees(t *testing.T) {
+	out := `worktree /src/bare.git
+bare
+
+worktree /src/main wt
+HEAD 1111111111111111111111111111111111111111
+branch refs/heads/main
+
+worktree /src/detached
+HEAD 2222222222222222222222222222222222222222
+detached
+
+worktree /src/locked
+HEAD 3333333333333333333333333333333333333333
+branch refs/heads/feature/x
+locked
+
+worktree /src/usb
+HEAD 4444444444444444444444444444444444444444
+branch refs/heads/usb
+locked on a removable disk
+
+worktree /src/gone
+HEAD 5555555555555555555555555555555555555555
+detached
+prunable gitdir file points to non-existent location
+
+`
+	want := []Worktree{
+		{Path: "/src/bare.git", Main: true, Bare: true},
+		{Path: "/src/main wt", Head: strings.Repeat("1", 40), Branch: "main"},
+		{Path: "/src/detached", Head: strings.Repeat("2", 40), Detached: true},
+		{Path: "/src/locked", Head: strings.Repeat("3", 40), Branch: "feature/x", Locked: true},
+		{Path: "/src/usb", Head: strings.Repeat("4", 40), Branch: "usb", Locked: true, LockReason: "on a removable disk"},
+		{Path: "/src/gone", Head: strings.Repeat("5", 40), Detached: true, Prunable: true,
+			PrunableReason: "gitdir file points to non-existent location"},
+	}
+	if got := parseWorktrees(out); !reflect.DeepEqual(got, want) {
+		t.Errorf("parseWorktrees() = %+v, want %+v", got, want)
+	}
+	if got := parseWorktrees(""); got != nil {
+		t.Errorf("parseWorktrees(\"\") = %+v, want none", got)
+	}
+}
+
+func TestGetWorktrees(t *testing.T) {
+	g := testRepo(t)
+	head := strings.TrimSpace(run(t, g, "rev-parse", "HEAD"))
+	dir := t.TempDir()
+	feature := filepath.Join(dir, "feature")
+	detached := filepath.Join(dir, "detached")
+	gone := filepath.Join(dir, "gone")
+
+	if err := g.AddWorktree(feature, "feature", true, ""); err != nil {
+		t.Fatal(err)
+	}
+	run(t, g, "worktree", "add", "-q", "--detach", detached)
+	if err := g.LockWorktree(detached, "on a removable disk"); err != nil {
+		t.Fatal(err)
+	}
+	run(t, g, "worktree", "add", "-q", "--detach", gone)
+	if err := os.RemoveAll(gone); err != nil {
+		t.Fatal(err)
+	}
+
+	worktrees, err := g.GetWorktrees()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []Worktree{
+		{Path: g.repoPath, Head: head, Branch: "main", Main: true},
+		{Path: detached, Head: head, Detached: true, Locked: true, LockReason: "on a removable disk"},
+		{Path: feature, Head: head, Branch: "feature"},
+		{Path: gone, Head: head, Detached: true, Prunable: true,
+			PrunableReason: "gitdir file points to non-existent location"},
+	}
+	if !reflect.DeepEqual(worktrees, want) {
+		t.Errorf("GetWorktrees() = %+v, want %+v", worktrees, want)
+	}
+
+	if err := g.PruneWorktrees(); err != nil {
+		t.Fatal(err)
+	}
+	if worktrees, err = g.GetWorktrees(); err != nil || len(worktrees) != 3 {
+		t.Errorf("GetWorktrees() after prune = %+v, %v, want three", worktrees, err)
+	}
+}
+
+func TestFindRepository(t *testing.T) {
+	g := testRepo(t)
+	root := g.repoPath
+	gitDir := filepath.Join(root, ".git")
+	writeFile(t, g, "sub/dir/b.txt", "b\n")
+
+	linked := filepath.Join(t.TempDir(), "linked")
+	if err := g.AddWorktree(linked, "feature", true, ""); err != nil {
+		t.Fatal(err)
+	}
+
+	lib := testRepo(t)
+	addSubmodule(t, g, lib.repoPath, "lib")
+
+	bare := New(t.TempDir())
+	run(t, bare, "init", "-q", "--bare")
+
+	tests := []struct {
+		start   string
+		want    Repository
+		wantErr bool
+	}{
+		{start: root, want: Repository{Path: root, Worktree: root, GitDir: gitDir}},
+		{start: filepath.Join(root, "sub", "dir"), want: Repository{Path: root, Worktree: root, GitDir: gitDir}},
+		{start: gitDir, want: Repository{Path: root, Worktree: root, GitDir: gitDir}},
+		{start: filepath.Join(gitDir, "refs"), want: Repository{Path: root, Worktree: root, GitDir: gitDir}},
+		{start: linked, want: Repository{Path: linked, Worktree: linked,
+			GitDir: filepath.Join(gitDir, "worktrees", "linked")}},
+		{start: filepath.Join(root, "lib"), want: Repository{Path: filepath.Join(root, "lib"),
+			Worktree: filepath.Join(root, "lib"), GitDir: filepath.Join(gitDir, "modules", "lib")}},
+		// A submodule's git directory names its working tree in core.worktree
+		{start: filepath.Join(gitDir, "modules", "lib"), want: Repository{Path: filepath.Join(root, "lib"),
+			Worktree: filepath.Join(root, "lib"), GitDir: filepath.Join(gitDir, "modules", "lib")}},
+		{start: bare.repoPath, want: Repository{Path: bare.repoPath, IsBare: true, GitDir: bare.repoPath}},
+		// Its parent is not the linked worktree
+		{start: filepath.Join(gitDir, "worktrees", "linked"), wantErr: true},
+		{start: t.TempDir(), wantErr: true},
+	}
+	for _, tt := range tests {
+		repo, err := FindRepository(tt.start)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("FindRepository(%s) = %+v, want an error", tt.start, repo)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("FindRepository(%s): %v", tt.start, err)
+			continue
+		}
+		if !reflect.DeepEqual(*repo, tt.want) {
+			t.Errorf("FindRepository(%s) = %+v, want %+v", tt.start, *repo, tt.want)
+		}
+	}
+}
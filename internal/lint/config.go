@@ -0,0 +1,205 @@
+package lint
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/gitflow/tui/internal/config"
+	"gopkg.in/yaml.v3"
+)
+
+// Config is the effective rule set
+type Config struct {
+	Rules   []Rule
+	Source  string   // commitlint file the rules came from, if any
+	Ignored []string // Unsupported rules and presets
+}
+
+// configFiles are the commitlint config files read, in order. JavaScript
+// configs cannot be read.
+var configFiles = []string{".commitlintrc", ".commitlintrc.json", ".commitlintrc.yaml", ".commitlintrc.yml", "package.json"}
+
+// conventionalPreset mirrors @commitlint/config-conventional
+var conventionalPreset = map[string][]interface{}{
+	"body-leading-blank":     {1, "always"},
+	"body-max-line-length":   {2, "always", 100},
+	"footer-leading-blank":   {1, "always"},
+	"footer-max-line-length": {2, "always", 100},
+	"header-max-length":      {2, "always", 100},
+	"subject-case":           {2, "never", []interface{}{"sentence-case", "start-case", "pascal-case", "upper-case"}},
+	"subject-empty":          {2, "never"},
+	"subject-full-stop":      {2, "never", "."},
+	"type-case":              {2, "always", "lower-case"},
+	"type-empty":             {2, "never"},
+	"type-enum": {2, "always", []interface{}{
+		"build", "chore", "ci", "docs", "feat", "fix", "perf", "refactor", "revert", "style", "test",
+	}},
+}
+
+// FromCommitConfig returns the rules the commit section of the config asks for
+func FromCommitConfig(cfg config.CommitConfig) []Rule {
+	rules := []Rule{{Name: "subject-empty", Level: LevelError, Never: true}}
+	if cfg.SubjectLimit > 0 {
+		rules = append(rules, Rule{Name: "header-max-length", Level: LevelError, Value: cfg.SubjectLimit})
+	}
+	if cfg.BodyLineLimit > 0 {
+		rules = append(rules, Rule{Name: "body-max-line-length", Level: LevelError, Value: cfg.BodyLineLimit})
+	}
+	if cfg.Conventional {
+		rules = append(rules, Rule{Name: "type-empty", Level: LevelError, Never: true})
+		if len(cfg.Types) > 0 {
+			rules = append(rules, Rule{Name: "type-enum", Level: LevelError, Value: cfg.Types})
+		}
+		if len(cfg.Scopes) > 0 {
+			rules = append(rules, Rule{Name: "scope-enum", Level: LevelError, Value: cfg.Scopes})
+		}
+	}
+	return rules
+}
+
+// Load returns the rules for the repository at repoPath: those from cfg,
+// overridden by a commitlint config in the repository root if there is one
+func Load(repoPath string, cfg config.CommitConfig) (*Config, error) {
+	c := &Config{}
+	byName := make(map[string]Rule)
+	var order []string
+	set := func(r Rule) {
+		if _, ok := byName[r.Name]; !ok {
+			order = append(order, r.Name)
+		}
+		byName[r.Name] = r
+	}
+	for _, r := range FromCommitConfig(cfg) {
+		set(r)
+	}
+
+	raw, source, err := readCommitlint(repoPath)
+	if err != nil {
+		return nil, err
+	}
+	if raw != nil {
+		c.Source = source
+		rules, ignored, err := parseCommitlint(raw)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", source, err)
+		}
+		for _, r := range rules {
+			set(r)
+		}
+		c.Ignored = ignored
+	}
+
+	for _, name := range order {
+		c.Rules = append(c.Rules, byName[name])
+	}
+	return c, nil
+}
+
+// readCommitlint reads the first commitlint config file in dir
+func readCommitlint(dir string) (map[string]interface{}, string, error) {
+	for _, name := range configFiles {
+		path := filepath.Join(dir, name)
+		data, err := os.ReadFile(path)
+		if err != nil {
+			continue
+		}
+
+		raw := make(map[string]interface{})
+		if strings.HasSuffix(name, ".json") || (name == ".commitlintrc" && json.Valid(data)) {
+			err = json.Unmarshal(data, &raw)
+		} else {
+			err = yaml.Unmarshal(data, &raw)
+		}
+		if err != nil {
+			return nil, "", fmt.Errorf("%s: %w", path, err)
+		}
+
+		if name == "package.json" {
+			section, ok := raw["commitlint"].(map[string]interface{})
+			if !ok {
+				continue
+			}
+			raw = section
+		}
+		return raw, path, nil
+	}
+	return nil, "", nil
+}
+
+// parseCommitlint turns the extends and rules of a commitlint config into
+// rules, presets first
+func parseCommitlint(raw map[string]interface{}) ([]Rule, []string, error) {
+	entries := make(map[string][]interface{})
+	var ignored []string
+
+	for _, preset := range toStrings(raw["extends"]) {
+		if strings.HasSuffix(preset, "config-conventional") {
+			for name, value := range conventionalPreset {
+				entries[name] = value
+			}
+		} else {
+			ignored = append(ignored, preset)
+		}
+	}
+
+	if rules, ok := raw["rules"].(map[string]interface{}); ok {
+		for name, value := range rules {
+			list, ok := value.([]interface{})
+			if !ok {
+				return nil, nil, fmt.Errorf("rule %s: expected [level, applicable, value]", name)
+			}
+			entries[name] = list
+		}
+	}
+
+	var names []string
+	for name := range entries {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var rules []Rule
+	for _, name := range names {
+		if _, ok := checks[name]; !ok {
+			ignored = append(ignored, name)
+			continue
+		}
+		r, err := parseRule(name, entries[name])
+		if err != nil {
+			return nil, nil, err
+		}
+		rules = append(rules, r)
+	}
+	return rules, ignored, nil
+}
+
+// parseRule parses [level, "always"|"never", value]
+func parseRule(name string, entry []interface{}) (Rule, error) {
+	r := Rule{Name: name}
+	if len(entry) == 0 {
+		return r, fmt.Errorf("rule %s: missing level", name)
+	}
+	level, ok := toInt(entry[0])
+	if !ok || level < 0 || level > 2 {
+		return r, fmt.Errorf("rule %s: level must be 0, 1 or 2", name)
+	}
+	r.Level = Level(level)
+
+	if len(entry) > 1 {
+		switch entry[1] {
+		case "always":
+		case "never":
+			r.Never = true
+		default:
+			return r, fmt.Errorf("rule %s: applicable must be always or never", name)
+		}
+	}
+	if len(entry) > 2 {
+		r.Value = entry[2]
+	}
+	return r, nil
+}
@@ -0,0 +1,113 @@
+package lint
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/gitflow/tui/internal/config"
+)
+
+func TestLoad(t *testing.T) {
+	commit := config.CommitConfig{SubjectLimit: 72, Conventional: true, Types: []string{"feat", "fix"}}
+
+	tests := []struct {
+		name    string
+		file    string
+		content string
+		want    map[string]string // Rule name -> "level never value"
+		ignored []string
+		wantErr string
+	}{
+		{
+			name: "commit config only",
+			want: map[string]string{
+				"subject-empty":     "2 true <nil>",
+				"header-max-length": "2 false 72",
+				"type-empty":        "2 true <nil>",
+				"type-enum":         "2 false [feat fix]",
+			},
+		},
+		{
+			name:    "json overrides",
+			file:    ".commitlintrc.json",
+			content: `{"rules": {"header-max-length": [1, "always", 50], "scope-empty": [2, "never"], "no-such-rule": [2, "always"]}}`,
+			want: map[string]string{
+				"header-max-length": "1 false 50",
+				"scope-empty":       "2 true <nil>",
+				"type-enum":         "2 false [feat fix]",
+			},
+			ignored: []string{"no-such-rule"},
+		},
+		{
+			name:    "yaml preset",
+			file:    ".commitlintrc.yml",
+			content: "extends:\n  - '@commitlint/config-conventional'\n  - some-other-preset\nrules:\n  subject-case: [0]\n",
+			want: map[string]string{
+				"header-max-length": "2 false 100",
+				"subject-full-stop": "2 true .",
+				"subject-case":      "0 false <nil>",
+				"type-enum":         "2 false [build chore ci docs feat fix perf refactor revert style test]",
+			},
+			ignored: []string{"some-other-preset"},
+		},
+		{
+			name:    "package.json section",
+			file:    "package.json",
+			content: `{"name": "x", "commitlint": {"rules": {"body-leading-blank": [2, "always"]}}}`,
+			want:    map[string]string{"body-leading-blank": "2 false <nil>"},
+		},
+		{
+			name:    "bad level",
+			file:    ".commitlintrc",
+			content: `{"rules": {"type-empty": [3, "never"]}}`,
+			wantErr: "level must be 0, 1 or 2",
+		},
+		{
+			name:    "bad applicable",
+			file:    ".commitlintrc.json",
+			content: `{"rules": {"type-empty": [2, "sometimes"]}}`,
+			wantErr: "applicable must be always or never",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			if tt.file != "" {
+				if err := os.WriteFile(filepath.Join(dir, tt.file), []byte(tt.content), 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			cfg, err := Load(dir, commit)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Load() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Load(): %v", err)
+			}
+
+			rules := make(map[string]string)
+			for _, r := range cfg.Rules {
+				rules[r.Name] = fmt.Sprintf("%d %v %v", r.Level, r.Never, r.Value)
+			}
+			for name, want := range tt.want {
+				if got := rules[name]; got != want {
+					t.Errorf("rule %s = %q, want %q", name, got, want)
+				}
+			}
+			if strings.Join(cfg.Ignored, ",") != strings.Join(tt.ignored, ",") {
+				t.Errorf("Ignored = %v, want %v", cfg.Ignored, tt.ignored)
+			}
+			if tt.file != "" && cfg.Source != filepath.Join(dir, tt.file) {
+				t.Errorf("Source = %q, want the %s file", cfg.Source, tt.file)
+			}
+		})
+	}
+}
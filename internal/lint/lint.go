@@ -0,0 +1,347 @@
+// Package lint checks commit messages against commitlint-style rules.
+// Rules are written as in commitlint: [level, "always"|"never", value],
+// where level 0 disables the rule, 1 warns and 2 is an error.
+package lint
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/gitflow/tui/internal/release"
+)
+
+// Level is the severity of a rule
+type Level int
+
+const (
+	LevelOff Level = iota
+	LevelWarning
+	LevelError
+)
+
+// Rule is a configured rule
+type Rule struct {
+	Name  string
+	Level Level
+	Never bool        // "never" inverts the condition
+	Value interface{} // Number, string or list of strings
+}
+
+// Values returns the rule value as a list of strings
+func (r Rule) Values() []string {
+	return toStrings(r.Value)
+}
+
+// Violation is a rule a message breaks
+type Violation struct {
+	Rule    string
+	Level   Level
+	Message string
+}
+
+// String formats the violation as "✗ message [rule]", or "⚠" for warnings
+func (v Violation) String() string {
+	mark := "✗"
+	if v.Level == LevelWarning {
+		mark = "⚠"
+	}
+	return fmt.Sprintf("%s %s [%s]", mark, v.Message, v.Rule)
+}
+
+// HasErrors reports whether any violation is an error
+func HasErrors(violations []Violation) bool {
+	for _, v := range violations {
+		if v.Level == LevelError {
+			return true
+		}
+	}
+	return false
+}
+
+// message is a commit message split into its parts
+type message struct {
+	header      string
+	body        string
+	bodyLines   []string
+	bodyBlank   bool     // A blank line follows the header
+	footer      []string // Trailer lines at the end
+	footerBlank bool     // A blank line precedes the footer
+	conv        release.Conventional
+	isConv      bool
+}
+
+// typ returns the type as written; the parsed type is lowercased
+func (m message) typ() string {
+	if !m.isConv {
+		return ""
+	}
+	return strings.TrimSpace(m.header)[:len(m.conv.Type)]
+}
+
+// trailerPattern matches footer lines: "Token: value", "Token #value" and
+// "BREAKING CHANGE: value"
+var trailerPattern = regexp.MustCompile(`^(BREAKING CHANGE|[\w-]+)(: | #)`)
+
+func parse(raw string) message {
+	lines := strings.Split(strings.TrimRight(raw, "\n"), "\n")
+	m := message{header: lines[0]}
+
+	rest := lines[1:]
+	end := len(rest)
+	for end > 0 && trailerPattern.MatchString(rest[end-1]) {
+		end--
+	}
+	if end < len(rest) {
+		m.footer = rest[end:]
+		m.footerBlank = end == 0 || strings.TrimSpace(rest[end-1]) == ""
+	}
+
+	m.body = strings.TrimSpace(strings.Join(rest[:end], "\n"))
+	m.bodyBlank = len(rest) > 0 && strings.TrimSpace(rest[0]) == ""
+	if m.body != "" {
+		m.bodyLines = strings.Split(m.body, "\n")
+	}
+	m.conv, m.isConv = release.ParseConventional(m.header, strings.Join(m.footer, "\n"))
+	return m
+}
+
+// Lint checks a commit message against rules
+func Lint(raw string, rules []Rule) []Violation {
+	msg := parse(raw)
+	var violations []Violation
+	for _, r := range rules {
+		if r.Level == LevelOff {
+			continue
+		}
+		check, ok := checks[r.Name]
+		if !ok {
+			continue
+		}
+		if problem := check(msg, r); problem != "" {
+			violations = append(violations, Violation{Rule: r.Name, Level: r.Level, Message: problem})
+		}
+	}
+	return violations
+}
+
+// check returns a description of how msg breaks r, or ""
+type check func(msg message, r Rule) string
+
+// checks implements the supported rules by name
+var checks = map[string]check{
+	"type-enum":  enumCheck("type", message.typ),
+	"type-case":  caseCheck("type", message.typ),
+	"type-empty": emptyCheck("type", message.typ),
+
+	"scope-enum":  enumCheck("scope", func(m message) string { return m.conv.Scope }),
+	"scope-case":  caseCheck("scope", func(m message) string { return m.conv.Scope }),
+	"scope-empty": emptyCheck("scope", func(m message) string { return m.conv.Scope }),
+
+	"subject-case":       caseCheck("subject", subject),
+	"subject-empty":      emptyCheck("subject", subject),
+	"subject-max-length": maxLengthCheck("subject", func(m message) []string { return []string{subject(m)} }),
+	"subject-full-stop": func(m message, r Rule) string {
+		stop, _ := r.Value.(string)
+		if stop == "" {
+			stop = "."
+		}
+		ends := strings.HasSuffix(subject(m), stop)
+		return failure(r, ends, "subject must end with "+stop, "subject may not end with "+stop)
+	},
+
+	"header-max-length": maxLengthCheck("header", func(m message) []string { return []string{m.header} }),
+	"header-min-length": func(m message, r Rule) string {
+		n, _ := toInt(r.Value)
+		if utf8.RuneCountInString(m.header) < n {
+			return fmt.Sprintf("header must be at least %d characters", n)
+		}
+		return ""
+	},
+
+	"body-empty":           emptyCheck("body", func(m message) string { return m.body }),
+	"body-max-line-length": maxLengthCheck("body line", func(m message) []string { return m.bodyLines }),
+	"body-leading-blank": func(m message, r Rule) string {
+		if m.body == "" {
+			return ""
+		}
+		return failure(r, m.bodyBlank, "body must have a leading blank line", "body may not have a leading blank line")
+	},
+
+	"footer-leading-blank": func(m message, r Rule) string {
+		if len(m.footer) == 0 {
+			return ""
+		}
+		return failure(r, m.footerBlank, "footer must have a leading blank line", "footer may not have a leading blank line")
+	},
+	"footer-max-line-length": maxLengthCheck("footer line", func(m message) []string { return m.footer }),
+
+	"trailer-exists": func(m message, r Rule) string {
+		trailer, _ := r.Value.(string)
+		trailer = strings.TrimSuffix(strings.TrimSpace(trailer), ":")
+		found := false
+		for _, line := range m.footer {
+			if strings.HasPrefix(line, trailer+":") {
+				found = true
+			}
+		}
+		return failure(r, found, "message must have a "+trailer+" trailer", "message may not have a "+trailer+" trailer")
+	},
+
+	// Not part of commitlint: words the message must not contain, in
+	// either direction
+	"forbidden-words": func(m message, r Rule) string {
+		text := strings.Join(append([]string{m.header, m.body}, m.footer...), "\n")
+		for _, w := range toStrings(r.Value) {
+			if regexp.MustCompile(`(?i)\b` + regexp.QuoteMeta(w) + `\b`).MatchString(text) {
+				return fmt.Sprintf("message may not contain %q", w)
+			}
+		}
+		return ""
+	},
+}
+
+// subject is the description of a conventional header, or the header
+func subject(m message) string {
+	if m.isConv {
+		return m.conv.Description
+	}
+	return m.header
+}
+
+// failure returns always or never depending on which way r is applied
+// when ok is not what it requires
+func failure(r Rule, ok bool, always, never string) string {
+	switch {
+	case !r.Never && !ok:
+		return always
+	case r.Never && ok:
+		return never
+	}
+	return ""
+}
+
+func enumCheck(field string, get func(message) string) check {
+	return func(m message, r Rule) string {
+		value := get(m)
+		if value == "" {
+			return ""
+		}
+		allowed := toStrings(r.Value)
+		list := strings.Join(allowed, ", ")
+		return failure(r, containsString(allowed, value),
+			fmt.Sprintf("%s must be one of [%s]", field, list),
+			fmt.Sprintf("%s may not be one of [%s]", field, list))
+	}
+}
+
+func emptyCheck(field string, get func(message) string) check {
+	return func(m message, r Rule) string {
+		// "never" empty is the usual form: the field is required
+		empty := strings.TrimSpace(get(m)) == ""
+		return failure(r, empty, field+" must be empty", field+" may not be empty")
+	}
+}
+
+func caseCheck(field string, get func(message) string) check {
+	return func(m message, r Rule) string {
+		value := get(m)
+		if value == "" {
+			return ""
+		}
+		cases := toStrings(r.Value)
+		matches := false
+		for _, c := range cases {
+			matches = matches || matchesCase(value, c)
+		}
+		list := strings.Join(cases, ", ")
+		return failure(r, matches,
+			fmt.Sprintf("%s must be %s", field, list),
+			fmt.Sprintf("%s may not be %s", field, list))
+	}
+}
+
+func maxLengthCheck(field string, get func(message) []string) check {
+	return func(m message, r Rule) string {
+		limit, ok := toInt(r.Value)
+		if !ok || limit <= 0 {
+			return ""
+		}
+		for _, line := range get(m) {
+			if n := utf8.RuneCountInString(line); n > limit {
+				return fmt.Sprintf("%s is %d characters, limit is %d", field, n, limit)
+			}
+		}
+		return ""
+	}
+}
+
+// matchesCase reports whether s is written in a commitlint case
+func matchesCase(s, name string) bool {
+	first, _ := utf8.DecodeRuneInString(s)
+	rest := s[utf8.RuneLen(first):]
+	hasSeparator := strings.ContainsAny(s, " -_")
+
+	switch name {
+	case "lower-case", "lowercase":
+		return s == strings.ToLower(s)
+	case "upper-case", "uppercase":
+		return s == strings.ToUpper(s)
+	case "sentence-case", "sentencecase":
+		return unicode.IsUpper(first) && rest == strings.ToLower(rest)
+	case "start-case", "startcase":
+		for _, word := range strings.Fields(s) {
+			if r, _ := utf8.DecodeRuneInString(word); !unicode.IsUpper(r) {
+				return false
+			}
+		}
+		return true
+	case "pascal-case", "pascalcase":
+		return unicode.IsUpper(first) && !hasSeparator
+	case "camel-case", "camelcase":
+		return unicode.IsLower(first) && !hasSeparator
+	case "kebab-case":
+		return s == strings.ToLower(s) && !strings.ContainsAny(s, " _")
+	case "snake-case":
+		return s == strings.ToLower(s) && !strings.ContainsAny(s, " -")
+	}
+	return false
+}
+
+func toInt(v interface{}) (int, bool) {
+	switch n := v.(type) {
+	case int:
+		return n, true
+	case float64:
+		return int(n), true
+	}
+	return 0, false
+}
+
+// toStrings accepts a string or a list of strings
+func toStrings(v interface{}) []string {
+	switch value := v.(type) {
+	case string:
+		return []string{value}
+	case []string:
+		return value
+	case []interface{}:
+		var out []string
+		for _, item := range value {
+			out = append(out, fmt.Sprint(item))
+		}
+		return out
+	}
+	return nil
+}
+
+func containsString(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
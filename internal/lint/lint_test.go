@@ -0,0 +1,129 @@
+package lint
+
+import "testing"
+
+func TestLint(t *testing.T) {
+	always := func(name string, value interface{}) Rule {
+		return Rule{Name: name, Level: LevelError, Value: value}
+	}
+	never := func(name string, value interface{}) Rule {
+		return Rule{Name: name, Level: LevelError, Never: true, Value: value}
+	}
+	types := []interface{}{"feat", "fix"}
+
+	tests := []struct {
+		name string
+		msg  string
+		rule Rule
+		want string // Violation message, "" when the message passes
+	}{
+		{"type-enum allowed", "feat: add x", always("type-enum", types), ""},
+		{"type-enum unknown", "feet: add x", always("type-enum", types), "type must be one of [feat, fix]"},
+		{"type-enum never", "fix: x", never("type-enum", []string{"fix"}), "type may not be one of [fix]"},
+		{"type-enum skips non-conventional", "Add x", always("type-enum", types), ""},
+		{"type-case lower", "Feat: add x", always("type-case", "lower-case"), "type must be lower-case"},
+		{"type-empty never", "Add x", never("type-empty", nil), "type may not be empty"},
+		{"type-empty present", "feat: add x", never("type-empty", nil), ""},
+
+		{"scope-enum allowed", "feat(ui): x", always("scope-enum", []string{"ui", "git"}), ""},
+		{"scope-enum unknown", "feat(api): x", always("scope-enum", []string{"ui", "git"}), "scope must be one of [ui, git]"},
+		{"scope-enum without scope", "feat: x", always("scope-enum", []string{"ui"}), ""},
+		{"scope-empty never", "feat: x", never("scope-empty", nil), "scope may not be empty"},
+		{"scope-case kebab", "feat(my_scope): x", always("scope-case", "kebab-case"), "scope must be kebab-case"},
+
+		{"subject-case sentence forbidden", "feat: Add x", never("subject-case", []interface{}{"sentence-case", "upper-case"}), "subject may not be sentence-case, upper-case"},
+		{"subject-case lower ok", "feat: add x", never("subject-case", []interface{}{"sentence-case"}), ""},
+		{"subject-case start-case", "feat: Add New Thing", always("subject-case", "start-case"), ""},
+		{"subject-empty", "", never("subject-empty", nil), "subject may not be empty"},
+		{"subject-full-stop never", "feat: add x.", never("subject-full-stop", "."), "subject may not end with ."},
+		{"subject-full-stop default", "feat: add x", always("subject-full-stop", nil), "subject must end with ."},
+		{"subject-max-length", "feat: abcdef", always("subject-max-length", 5), "subject is 6 characters, limit is 5"},
+
+		{"header-max-length ok", "feat: add x", always("header-max-length", 11), ""},
+		{"header-max-length", "feat: add xy", always("header-max-length", 11), "header is 12 characters, limit is 11"},
+		{"header-max-length counts runes", "fix: ünïcödé", always("header-max-length", 12), ""},
+		{"header-max-length float from JSON", "feat: add xy", always("header-max-length", 11.0), "header is 12 characters, limit is 11"},
+		{"header-min-length", "fix: x", always("header-min-length", 10), "header must be at least 10 characters"},
+
+		{"body-empty never", "feat: x", never("body-empty", nil), "body may not be empty"},
+		{"body-max-line-length", "feat: x\n\nshort\nthis line is long", always("body-max-line-length", 10), "body line is 17 characters, limit is 10"},
+		{"body-leading-blank missing", "feat: x\nbody", always("body-leading-blank", nil), "body must have a leading blank line"},
+		{"body-leading-blank present", "feat: x\n\nbody", always("body-leading-blank", nil), ""},
+		{"body-leading-blank without body", "feat: x", always("body-leading-blank", nil), ""},
+
+		{"footer-leading-blank missing", "feat: x\n\nbody\nRefs: #1", always("footer-leading-blank", nil), "footer must have a leading blank line"},
+		{"footer-leading-blank present", "feat: x\n\nbody\n\nRefs: #1", always("footer-leading-blank", nil), ""},
+		{"footer-max-line-length", "feat: x\n\nRefs: #12345", always("footer-max-line-length", 8), "footer line is 12 characters, limit is 8"},
+
+		{"trailer-exists", "feat: x\n\nbody", always("trailer-exists", "Signed-off-by:"), "message must have a Signed-off-by trailer"},
+		{"trailer-exists present", "feat: x\n\nSigned-off-by: A <a@example.com>", always("trailer-exists", "Signed-off-by:"), ""},
+		{"trailer-exists never", "feat: x\n\nSigned-off-by: A <a@example.com>", never("trailer-exists", "Signed-off-by"), "message may not have a Signed-off-by trailer"},
+
+		{"forbidden-words", "fix: remove WIP code", always("forbidden-words", []string{"wip"}), `message may not contain "wip"`},
+		{"forbidden-words whole words", "fix: wipe cache", always("forbidden-words", []string{"wip"}), ""},
+
+		{"unknown rule ignored", "anything", always("no-such-rule", nil), ""},
+		{"disabled rule", "feet: x", Rule{Name: "type-enum", Level: LevelOff, Value: types}, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			violations := Lint(tt.msg, []Rule{tt.rule})
+			got := ""
+			if len(violations) > 0 {
+				got = violations[0].Message
+			}
+			if got != tt.want || len(violations) > 1 {
+				t.Errorf("Lint(%q, %s) = %v, want %q", tt.msg, tt.rule.Name, violations, tt.want)
+			}
+		})
+	}
+}
+
+func TestMatchesCase(t *testing.T) {
+	tests := []struct {
+		s    string
+		name string
+		want bool
+	}{
+		{"add thing", "lower-case", true},
+		{"Add thing", "lower-case", false},
+		{"ADD", "upper-case", true},
+		{"Add thing", "sentence-case", true},
+		{"Add Thing", "sentence-case", false},
+		{"Add Thing", "start-case", true},
+		{"AddThing", "pascal-case", true},
+		{"addThing", "camel-case", true},
+		{"add-thing", "kebab-case", true},
+		{"add_thing", "snake-case", true},
+		{"add_thing", "kebab-case", false},
+		{"add thing", "no-such-case", false},
+	}
+
+	for _, tt := range tests {
+		if got := matchesCase(tt.s, tt.name); got != tt.want {
+			t.Errorf("matchesCase(%q, %s) = %v, want %v", tt.s, tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestViolations(t *testing.T) {
+	rules := []Rule{
+		{Name: "type-empty", Level: LevelError, Never: true},
+		{Name: "body-leading-blank", Level: LevelWarning},
+	}
+
+	violations := Lint("add x\nbody", rules)
+	if len(violations) != 2 {
+		t.Fatalf("Lint() = %v, want 2 violations", violations)
+	}
+	if !HasErrors(violations) || HasErrors(violations[1:]) {
+		t.Errorf("HasErrors() does not tell errors from warnings: %v", violations)
+	}
+	if got := violations[0].String(); got != "✗ type may not be empty [type-empty]" {
+		t.Errorf("error String() = %q", got)
+	}
+	if got := violations[1].String(); got != "⚠ body must have a leading blank line [body-leading-blank]" {
+		t.Errorf("warning String() = %q", got)
+	}
+}
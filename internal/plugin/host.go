@@ -0,0 +1,104 @@
+package plugin
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/gitflow/tui/internal/git"
+)
+
+// Host answers queries from plugins
+type Host interface {
+	Handle(method string, params json.RawMessage) (interface{}, error)
+}
+
+// GitHost exposes the read-only git.Git queries to plugins. Methods are
+// named after the git.Git methods, e.g. "git.GetCommits". Git returns the
+// repository to query, which changes when the TUI switches repositories.
+type GitHost struct {
+	Git func() *git.Git
+}
+
+// gitMethods maps method names to the git.Git queries they call
+var gitMethods = map[string]func(g *git.Git, params json.RawMessage) (interface{}, error){
+	"git.GetCurrentBranch": func(g *git.Git, _ json.RawMessage) (interface{}, error) {
+		return g.GetCurrentBranch()
+	},
+	"git.GetBranches": func(g *git.Git, _ json.RawMessage) (interface{}, error) {
+		return g.GetBranches()
+	},
+	"git.GetCommits": func(g *git.Git, params json.RawMessage) (interface{}, error) {
+		var p struct {
+			Limit int `json:"limit"`
+		}
+		if err := decodeParams(params, &p); err != nil {
+			return nil, err
+		}
+		if p.Limit <= 0 {
+			p.Limit = 100
+		}
+		return g.GetCommits(p.Limit)
+	},
+	"git.GetStatus": func(g *git.Git, _ json.RawMessage) (interface{}, error) {
+		return g.GetStatus()
+	},
+	"git.GetRemotes": func(g *git.Git, _ json.RawMessage) (interface{}, error) {
+		return g.GetRemotes()
+	},
+	"git.GetStash": func(g *git.Git, _ json.RawMessage) (interface{}, error) {
+		return g.GetStash()
+	},
+	"git.GetTags": func(g *git.Git, _ json.RawMessage) (interface{}, error) {
+		return g.GetTags()
+	},
+	"git.GetDiff": func(g *git.Git, params json.RawMessage) (interface{}, error) {
+		var p struct {
+			Staged bool     `json:"staged"`
+			Paths  []string `json:"paths"`
+		}
+		if err := decodeParams(params, &p); err != nil {
+			return nil, err
+		}
+		return g.GetDiff(p.Staged, p.Paths...)
+	},
+	"git.GetLog": func(g *git.Git, params json.RawMessage) (interface{}, error) {
+		var p struct {
+			Format string `json:"format"`
+			Limit  int    `json:"limit"`
+		}
+		if err := decodeParams(params, &p); err != nil {
+			return nil, err
+		}
+		return g.GetLog(p.Format, p.Limit)
+	},
+}
+
+// Methods returns the names of the methods plugins may call
+func Methods() []string {
+	var names []string
+	for name := range gitMethods {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Handle implements Host
+func (h GitHost) Handle(method string, params json.RawMessage) (interface{}, error) {
+	fn, ok := gitMethods[method]
+	if !ok {
+		return nil, fmt.Errorf("unknown method %q", method)
+	}
+	return fn(h.Git(), params)
+}
+
+func decodeParams(params json.RawMessage, v interface{}) error {
+	if len(params) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(params, v); err != nil {
+		return fmt.Errorf("invalid params: %w", err)
+	}
+	return nil
+}
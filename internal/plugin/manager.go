@@ -0,0 +1,146 @@
+package plugin
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+)
+
+// Manager owns the running plugins
+type Manager struct {
+	plugins []*Plugin
+}
+
+// Discover returns the plugin executables in dir
+func Discover(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var paths []string
+	for _, e := range entries {
+		if strings.HasPrefix(e.Name(), ".") {
+			continue
+		}
+		// Stat follows symlinks, e.g. to a plugin installed elsewhere
+		info, err := os.Stat(filepath.Join(dir, e.Name()))
+		if err != nil || info.IsDir() {
+			continue
+		}
+		if isExecutable(e.Name(), info.Mode()) {
+			paths = append(paths, filepath.Join(dir, e.Name()))
+		}
+	}
+	sort.Strings(paths)
+	return paths, nil
+}
+
+func isExecutable(name string, mode os.FileMode) bool {
+	if runtime.GOOS == "windows" {
+		switch strings.ToLower(filepath.Ext(name)) {
+		case ".exe", ".bat", ".cmd":
+			return true
+		}
+		return false
+	}
+	return mode.IsRegular() && mode&0111 != 0
+}
+
+// Load starts every plugin in dir. Plugins that fail to start are
+// reported but do not prevent the others from loading.
+func Load(dir, repoPath string, host Host) (*Manager, []error) {
+	m := &Manager{}
+
+	paths, err := Discover(dir)
+	if err != nil {
+		return m, []error{err}
+	}
+
+	var errs []error
+	names := make(map[string]bool)
+	for _, path := range paths {
+		p, err := Start(path, repoPath, host)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if names[p.Name()] {
+			errs = append(errs, fmt.Errorf("%s: duplicate plugin name %q", path, p.Name()))
+			p.Close()
+			continue
+		}
+		names[p.Name()] = true
+		m.plugins = append(m.plugins, p)
+	}
+	return m, errs
+}
+
+// Plugins returns the running plugins
+func (m *Manager) Plugins() []*Plugin {
+	return m.plugins
+}
+
+// Get returns the plugin named name
+func (m *Manager) Get(name string) *Plugin {
+	for _, p := range m.plugins {
+		if p.Name() == name {
+			return p
+		}
+	}
+	return nil
+}
+
+// RunCommand runs a plugin command
+func (m *Manager) RunCommand(pluginName, command string, ctx Context) (*CommandResult, error) {
+	p := m.Get(pluginName)
+	if p == nil {
+		return nil, fmt.Errorf("plugin %q is not running", pluginName)
+	}
+
+	var result CommandResult
+	params := map[string]interface{}{"name": command, "context": ctx}
+	if err := p.Call("command.run", params, &result); err != nil {
+		return nil, fmt.Errorf("%s: %w", pluginName, err)
+	}
+	return &result, nil
+}
+
+// RenderTab asks a plugin for the text of one of its tabs
+func (m *Manager) RenderTab(pluginName, tab string, width, height int) (string, error) {
+	p := m.Get(pluginName)
+	if p == nil {
+		return "", fmt.Errorf("plugin %q is not running", pluginName)
+	}
+
+	var result struct {
+		Content string `json:"content"`
+	}
+	params := map[string]interface{}{"id": tab, "width": width, "height": height}
+	if err := p.Call("tab.render", params, &result); err != nil {
+		return "", fmt.Errorf("%s: %w", pluginName, err)
+	}
+	return result.Content, nil
+}
+
+// Emit notifies subscribed plugins of a repository event
+func (m *Manager) Emit(event string, data interface{}) {
+	for _, p := range m.plugins {
+		if p.Subscribed(event) {
+			p.Notify("event", map[string]interface{}{"type": event, "data": data})
+		}
+	}
+}
+
+// Close stops all plugins
+func (m *Manager) Close() {
+	for _, p := range m.plugins {
+		p.Close()
+	}
+}
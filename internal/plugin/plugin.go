@@ -0,0 +1,317 @@
+// Package plugin runs external plugin executables. Plugins talk to the
+// host with newline-delimited JSON messages over stdin and stdout: the
+// host calls initialize, command.run and tab.render, sends event
+// notifications, and answers the plugin's git.* queries.
+package plugin
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// APIVersion is the protocol version sent to plugins on initialize
+const APIVersion = 1
+
+// callTimeout bounds how long the host waits for a plugin to answer, and
+// closeTimeout how long a closed plugin has to exit before it is killed
+var (
+	callTimeout  = 10 * time.Second
+	closeTimeout = time.Second
+)
+
+// Repository events plugins can subscribe to
+const (
+	EventCommitCreated  = "commit.created"
+	EventBranchSwitched = "branch.switched"
+	EventPushFinished   = "push.finished"
+)
+
+// Message is a request, response or notification. Requests carry an ID
+// and a method, responses the ID of their request and a result or error,
+// and notifications only a method.
+type Message struct {
+	ID     int64           `json:"id,omitempty"`
+	Method string          `json:"method,omitempty"`
+	Params json.RawMessage `json:"params,omitempty"`
+	Result json.RawMessage `json:"result,omitempty"`
+	Error  *Error          `json:"error,omitempty"`
+}
+
+// Error is returned in a response when a call fails
+type Error struct {
+	Message string `json:"message"`
+}
+
+func (e *Error) Error() string { return e.Message }
+
+// CommandInfo describes a command a plugin registers
+type CommandInfo struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Key         string `json:"key"`
+}
+
+// TabInfo describes a tab a plugin adds
+type TabInfo struct {
+	ID    string `json:"id"`
+	Title string `json:"title"`
+}
+
+// Manifest is the plugin's answer to initialize
+type Manifest struct {
+	Name     string        `json:"name"`
+	Version  string        `json:"version"`
+	Commands []CommandInfo `json:"commands"`
+	Tabs     []TabInfo     `json:"tabs"`
+	Events   []string      `json:"events"`
+}
+
+// Context describes the UI selection a command runs with
+type Context struct {
+	RepoPath      string `json:"repo_path"`
+	CurrentBranch string `json:"current_branch"`
+	Commit        string `json:"commit,omitempty"`
+	Branch        string `json:"branch,omitempty"`
+	File          string `json:"file,omitempty"`
+}
+
+// CommandResult is returned by command.run. Output, when set, is shown
+// in a panel; otherwise Message goes to the status bar.
+type CommandResult struct {
+	Message string `json:"message"`
+	Output  string `json:"output"`
+}
+
+// Plugin is a running plugin process
+type Plugin struct {
+	Path     string
+	Manifest Manifest
+
+	cmd      *exec.Cmd
+	stdin    io.WriteCloser
+	stdout   io.ReadCloser
+	readDone chan struct{} // Closed when readLoop returns
+	host     Host
+	writeMu  sync.Mutex
+
+	mu      sync.Mutex
+	nextID  int64
+	pending map[int64]chan Message
+	closed  bool // Close was called
+	exited  bool // The plugin closed stdout
+}
+
+// Start launches the plugin at path and initializes it
+func Start(path, repoPath string, host Host) (*Plugin, error) {
+	cmd := exec.Command(path)
+	cmd.Dir = repoPath
+
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, err
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, err
+	}
+
+	p := &Plugin{
+		Path:     path,
+		cmd:      cmd,
+		stdin:    stdin,
+		stdout:   stdout,
+		readDone: make(chan struct{}),
+		host:     host,
+		pending:  make(map[int64]chan Message),
+	}
+	go p.readLoop(stdout)
+
+	params := map[string]interface{}{
+		"api_version": APIVersion,
+		"repo_path":   repoPath,
+	}
+	if err := p.Call("initialize", params, &p.Manifest); err != nil {
+		p.Close()
+		return nil, fmt.Errorf("%s: initialize: %w", filepath.Base(path), err)
+	}
+	if p.Manifest.Name == "" {
+		p.Manifest.Name = strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	}
+
+	return p, nil
+}
+
+// Name returns the plugin name
+func (p *Plugin) Name() string {
+	return p.Manifest.Name
+}
+
+// Subscribed reports whether the plugin subscribed to event
+func (p *Plugin) Subscribed(event string) bool {
+	for _, e := range p.Manifest.Events {
+		if e == event {
+			return true
+		}
+	}
+	return false
+}
+
+// Call sends a request and decodes the result into result
+func (p *Plugin) Call(method string, params, result interface{}) error {
+	p.mu.Lock()
+	if p.closed || p.exited {
+		p.mu.Unlock()
+		return errors.New("plugin is not running")
+	}
+	p.nextID++
+	id := p.nextID
+	ch := make(chan Message, 1)
+	p.pending[id] = ch
+	p.mu.Unlock()
+
+	if err := p.send(id, method, params); err != nil {
+		p.forget(id)
+		return err
+	}
+
+	select {
+	case resp, ok := <-ch:
+		if !ok {
+			return errors.New("plugin exited")
+		}
+		if resp.Error != nil {
+			return resp.Error
+		}
+		if result != nil && len(resp.Result) > 0 {
+			return json.Unmarshal(resp.Result, result)
+		}
+		return nil
+	case <-time.After(callTimeout):
+		p.forget(id)
+		return fmt.Errorf("%s timed out", method)
+	}
+}
+
+// Notify sends a notification, which the plugin does not answer
+func (p *Plugin) Notify(method string, params interface{}) error {
+	return p.send(0, method, params)
+}
+
+// Close stops the plugin. Closing stdin asks it to exit; it is killed
+// if it does not close stdout in time. Wait closes stdout, so it is only
+// called once readLoop is done reading.
+func (p *Plugin) Close() {
+	p.mu.Lock()
+	if p.closed {
+		p.mu.Unlock()
+		return
+	}
+	p.closed = true
+	p.mu.Unlock()
+
+	p.stdin.Close()
+	select {
+	case <-p.readDone:
+	case <-time.After(closeTimeout):
+		p.cmd.Process.Kill()
+		// A child of the plugin may still hold stdout open
+		p.stdout.Close()
+		<-p.readDone
+	}
+	p.cmd.Wait()
+}
+
+func (p *Plugin) send(id int64, method string, params interface{}) error {
+	msg := Message{ID: id, Method: method}
+	if params != nil {
+		data, err := json.Marshal(params)
+		if err != nil {
+			return err
+		}
+		msg.Params = data
+	}
+	return p.write(msg)
+}
+
+func (p *Plugin) write(msg Message) error {
+	data, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+
+	p.writeMu.Lock()
+	defer p.writeMu.Unlock()
+	_, err = p.stdin.Write(append(data, '\n'))
+	return err
+}
+
+func (p *Plugin) forget(id int64) {
+	p.mu.Lock()
+	delete(p.pending, id)
+	p.mu.Unlock()
+}
+
+// readLoop routes responses to their callers and serves plugin requests
+// until the plugin closes stdout
+func (p *Plugin) readLoop(r io.Reader) {
+	defer close(p.readDone)
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+
+	for scanner.Scan() {
+		var msg Message
+		if err := json.Unmarshal(scanner.Bytes(), &msg); err != nil {
+			continue
+		}
+
+		if msg.Method != "" {
+			go p.serve(msg)
+			continue
+		}
+
+		p.mu.Lock()
+		ch, ok := p.pending[msg.ID]
+		delete(p.pending, msg.ID)
+		p.mu.Unlock()
+		if ok {
+			ch <- msg
+		}
+	}
+
+	p.mu.Lock()
+	p.exited = true
+	for id, ch := range p.pending {
+		close(ch)
+		delete(p.pending, id)
+	}
+	p.mu.Unlock()
+}
+
+// serve answers a request from the plugin
+func (p *Plugin) serve(req Message) {
+	result, err := p.host.Handle(req.Method, req.Params)
+	if req.ID == 0 {
+		return
+	}
+
+	resp := Message{ID: req.ID}
+	if err != nil {
+		resp.Error = &Error{Message: err.Error()}
+	} else if data, merr := json.Marshal(result); merr != nil {
+		resp.Error = &Error{Message: merr.Error()}
+	} else {
+		resp.Result = data
+	}
+	p.write(resp)
+}
@@ -0,0 +1,246 @@
+package plugin
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// helperEnv makes the test binary act as a plugin, see helperPlugin
+const helperEnv = "GITFLOW_TEST_PLUGIN"
+
+func TestMain(m *testing.M) {
+	if os.Getenv(helperEnv) != "" {
+		helperPlugin()
+		os.Exit(0)
+	}
+	os.Exit(m.Run())
+}
+
+// helperPlugin is a plugin named after the executable. Names containing
+// "sub" subscribe to commit.created, and "stubborn" ones keep running
+// after stdin is closed. Its commands are:
+//
+//	events  returns the events received so far
+//	query   asks the host for git.Echo and returns the answer
+//	fail    answers with an error
+//	hang    never answers
+//	exit    exits without answering
+func helperPlugin() {
+	name := filepath.Base(os.Args[0])
+	enc := json.NewEncoder(os.Stdout)
+	reply := func(id int64, result interface{}) {
+		data, _ := json.Marshal(result)
+		enc.Encode(Message{ID: id, Result: data})
+	}
+
+	var events []string
+	scanner := bufio.NewScanner(os.Stdin)
+	for scanner.Scan() {
+		var msg Message
+		if err := json.Unmarshal(scanner.Bytes(), &msg); err != nil {
+			continue
+		}
+		switch msg.Method {
+		case "initialize":
+			manifest := Manifest{Name: name, Commands: []CommandInfo{{Name: "events"}}}
+			if strings.Contains(name, "sub") {
+				manifest.Events = []string{EventCommitCreated}
+			}
+			reply(msg.ID, manifest)
+		case "event":
+			var params struct {
+				Type string `json:"type"`
+			}
+			json.Unmarshal(msg.Params, &params)
+			events = append(events, params.Type)
+		case "command.run":
+			var params struct {
+				Name string `json:"name"`
+			}
+			json.Unmarshal(msg.Params, &params)
+			switch params.Name {
+			case "events":
+				reply(msg.ID, CommandResult{Output: strings.Join(events, ",")})
+			case "query":
+				enc.Encode(Message{ID: 1000, Method: "git.Echo", Params: json.RawMessage(`"hello"`)})
+				var resp Message
+				if scanner.Scan() {
+					json.Unmarshal(scanner.Bytes(), &resp)
+				}
+				reply(msg.ID, CommandResult{Message: string(resp.Result)})
+			case "fail":
+				enc.Encode(Message{ID: msg.ID, Error: &Error{Message: "boom"}})
+			case "exit":
+				os.Exit(0)
+			}
+		}
+	}
+	if strings.Contains(name, "stubborn") {
+		time.Sleep(time.Minute)
+	}
+}
+
+// echoHost answers git.Echo with its params
+type echoHost struct{}
+
+func (echoHost) Handle(method string, params json.RawMessage) (interface{}, error) {
+	if method != "git.Echo" {
+		return nil, errors.New("unknown method " + method)
+	}
+	var s string
+	err := json.Unmarshal(params, &s)
+	return s, err
+}
+
+// installHelpers links the test binary into a plugin directory under the
+// given names
+func installHelpers(t *testing.T, names ...string) string {
+	t.Helper()
+	t.Setenv(helperEnv, "1")
+	exe, err := os.Executable()
+	if err != nil {
+		t.Fatal(err)
+	}
+	dir := t.TempDir()
+	for _, name := range names {
+		if err := os.Symlink(exe, filepath.Join(dir, name)); err != nil {
+			t.Skip("symlinks not supported:", err)
+		}
+	}
+	return dir
+}
+
+// withTimeouts shortens the call and close timeouts for a test
+func withTimeouts(t *testing.T, call, close time.Duration) {
+	t.Helper()
+	oldCall, oldClose := callTimeout, closeTimeout
+	callTimeout, closeTimeout = call, close
+	t.Cleanup(func() { callTimeout, closeTimeout = oldCall, oldClose })
+}
+
+func TestCall(t *testing.T) {
+	dir := installHelpers(t, "helper")
+	withTimeouts(t, 300*time.Millisecond, time.Second)
+
+	p, err := Start(filepath.Join(dir, "helper"), dir, echoHost{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer p.Close()
+	if p.Name() != "helper" || len(p.Manifest.Commands) != 1 {
+		t.Fatalf("Manifest = %+v", p.Manifest)
+	}
+
+	tests := []struct {
+		command string
+		want    CommandResult
+		wantErr string
+	}{
+		{command: "query", want: CommandResult{Message: `"hello"`}},
+		{command: "fail", wantErr: "boom"},
+		{command: "hang", wantErr: "command.run timed out"},
+		// The plugin still answers after a timed out call
+		{command: "events", want: CommandResult{}},
+	}
+	for _, tt := range tests {
+		var got CommandResult
+		err := p.Call("command.run", map[string]string{"name": tt.command}, &got)
+		if tt.wantErr != "" {
+			if err == nil || err.Error() != tt.wantErr {
+				t.Errorf("%s: error = %v, want %q", tt.command, err, tt.wantErr)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: %v", tt.command, err)
+		} else if got != tt.want {
+			t.Errorf("%s = %+v, want %+v", tt.command, got, tt.want)
+		}
+	}
+}
+
+func TestPluginExit(t *testing.T) {
+	dir := installHelpers(t, "helper")
+	p, err := Start(filepath.Join(dir, "helper"), dir, echoHost{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer p.Close()
+
+	err = p.Call("command.run", map[string]string{"name": "exit"}, nil)
+	if err == nil || err.Error() != "plugin exited" {
+		t.Errorf("Call() error = %v, want plugin exited", err)
+	}
+
+	// readLoop has marked the plugin as gone
+	select {
+	case <-p.readDone:
+	case <-time.After(5 * time.Second):
+		t.Fatal("readLoop did not end after the plugin exited")
+	}
+	if err := p.Call("command.run", map[string]string{"name": "events"}, nil); err == nil || err.Error() != "plugin is not running" {
+		t.Errorf("Call() after exit error = %v, want plugin is not running", err)
+	}
+}
+
+func TestClose(t *testing.T) {
+	dir := installHelpers(t, "helper", "stubborn")
+	withTimeouts(t, time.Second, 200*time.Millisecond)
+
+	for _, name := range []string{"helper", "stubborn"} {
+		p, err := Start(filepath.Join(dir, name), dir, echoHost{})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		start := time.Now()
+		p.Close()
+		if elapsed := time.Since(start); elapsed > 5*time.Second {
+			t.Errorf("%s: Close took %s", name, elapsed)
+		}
+		if p.cmd.ProcessState == nil {
+			t.Errorf("%s: process was not waited for", name)
+		}
+		if err := p.Call("command.run", map[string]string{"name": "events"}, nil); err == nil {
+			t.Errorf("%s: Call after Close succeeded", name)
+		}
+		p.Close()
+	}
+}
+
+func TestEmit(t *testing.T) {
+	dir := installHelpers(t, "a-sub", "b", "c-sub")
+	m, errs := Load(dir, dir, echoHost{})
+	defer m.Close()
+	if len(errs) > 0 {
+		t.Fatal(errs)
+	}
+	if len(m.Plugins()) != 3 {
+		t.Fatalf("Load() started %d plugins, want 3", len(m.Plugins()))
+	}
+
+	m.Emit(EventCommitCreated, map[string]string{"hash": "abc"})
+	m.Emit(EventPushFinished, nil)
+	m.Emit(EventCommitCreated, nil)
+
+	want := map[string]string{"a-sub": "commit.created,commit.created", "b": "", "c-sub": "commit.created,commit.created"}
+	for name, events := range want {
+		result, err := m.RunCommand(name, "events", Context{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if result.Output != events {
+			t.Errorf("%s received %q, want %q", name, result.Output, events)
+		}
+	}
+
+	if _, err := m.RunCommand("missing", "events", Context{}); err == nil {
+		t.Error("RunCommand() on a missing plugin succeeded")
+	}
+}
@@ -0,0 +1,366 @@
+package release
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gitflow/tui/internal/git"
+	"github.com/gitflow/tui/internal/remoteurl"
+)
+
+// Changelog formats accepted by Render
+const (
+	FormatMarkdown       = "markdown"
+	FormatJSON           = "json"
+	FormatKeepAChangelog = "keepachangelog"
+)
+
+// Entry is one change in a changelog
+type Entry struct {
+	Hash         string   `json:"hash"`
+	ShortHash    string   `json:"short_hash"`
+	Type         string   `json:"type,omitempty"`
+	Scope        string   `json:"scope,omitempty"`
+	Description  string   `json:"description"`
+	Breaking     bool     `json:"breaking,omitempty"`
+	BreakingNote string   `json:"breaking_note,omitempty"`
+	Author       string   `json:"author"`
+	References   []string `json:"references,omitempty"` // Issue and PR numbers
+}
+
+// Group holds the entries of one commit type
+type Group struct {
+	Type    string  `json:"type"`
+	Title   string  `json:"title"`
+	Entries []Entry `json:"entries"`
+}
+
+// Changelog lists the changes between two refs
+type Changelog struct {
+	Title    string    `json:"title"` // Version, or "Unreleased"
+	From     string    `json:"from,omitempty"`
+	To       string    `json:"to"`
+	Date     time.Time `json:"date"`
+	RepoURL  string    `json:"repo_url,omitempty"`
+	Breaking []Entry   `json:"breaking,omitempty"`
+	Groups   []Group   `json:"groups"`
+	Authors  []string  `json:"authors"`
+}
+
+// groupOrder orders commit types in a changelog; other types and
+// non-conventional commits go last
+var groupOrder = []struct {
+	typ   string
+	title string
+}{
+	{"feat", "Features"},
+	{"fix", "Bug Fixes"},
+	{"perf", "Performance"},
+	{"refactor", "Refactoring"},
+	{"revert", "Reverts"},
+	{"docs", "Documentation"},
+	{"test", "Tests"},
+	{"build", "Build"},
+	{"ci", "CI"},
+	{"chore", "Chores"},
+	{"", "Other Changes"},
+}
+
+// referencePattern matches issue and PR numbers such as "#42"
+var referencePattern = regexp.MustCompile(`(?:^|[\s(,])#(\d+)\b`)
+
+// trailingReference matches the "(#42)" forges append to squash merges
+var trailingReference = regexp.MustCompile(`\s*\(#\d+\)$`)
+
+// Generate builds the changelog of the commits in from..to. An empty to
+// means HEAD; an empty from means the latest tag before to, or the whole
+// history when there is none.
+func Generate(g *git.Git, from, to string) (*Changelog, error) {
+	if to == "" {
+		to = "HEAD"
+	}
+	if from == "" {
+		rev := to + "^"
+		if to == "HEAD" {
+			rev = to
+		}
+		from, _ = g.LatestTag(rev)
+	}
+
+	commits, err := g.CommitsBetween(from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	c := Build(commits)
+	c.From, c.To = from, to
+	c.Title = "Unreleased"
+	if to != "HEAD" {
+		c.Title = to
+		if len(commits) > 0 {
+			c.Date = commits[0].Date
+		}
+	}
+	c.RepoURL = repoURL(g)
+	return c, nil
+}
+
+// Build groups commits, newest first, into a changelog. Merge commits are
+// left out.
+func Build(commits []git.Commit) *Changelog {
+	c := &Changelog{Date: time.Now()}
+	groups := make([][]Entry, len(groupOrder))
+	seen := make(map[string]bool)
+
+	for _, commit := range commits {
+		if len(commit.Parents) > 1 {
+			continue
+		}
+
+		e := Entry{
+			Hash:        commit.Hash,
+			ShortHash:   commit.ShortHash,
+			Description: commit.Message,
+			Author:      commit.Author,
+		}
+		for _, m := range referencePattern.FindAllStringSubmatch(commit.Message+"\n"+commit.Body, -1) {
+			e.References = appendUnique(e.References, m[1])
+		}
+
+		group := len(groupOrder) - 1
+		if conv, ok := ParseConventional(commit.Message, commit.Body); ok {
+			e.Type, e.Scope, e.Description = conv.Type, conv.Scope, conv.Description
+			e.Breaking, e.BreakingNote = conv.Breaking, conv.BreakingNote
+			for i, o := range groupOrder {
+				if o.typ == conv.Type {
+					group = i
+				}
+			}
+		}
+		e.Description = trailingReference.ReplaceAllString(e.Description, "")
+
+		if e.Breaking {
+			c.Breaking = append(c.Breaking, e)
+		}
+		groups[group] = append(groups[group], e)
+		if !seen[e.Author] {
+			seen[e.Author] = true
+			c.Authors = append(c.Authors, e.Author)
+		}
+	}
+
+	for i, entries := range groups {
+		if len(entries) == 0 {
+			continue
+		}
+		// Keep each scope together, newest first within it
+		sort.SliceStable(entries, func(a, b int) bool { return entries[a].Scope < entries[b].Scope })
+		c.Groups = append(c.Groups, Group{Type: groupOrder[i].typ, Title: groupOrder[i].title, Entries: entries})
+	}
+	sort.Strings(c.Authors)
+	return c
+}
+
+// Empty reports whether the changelog has no entries
+func (c *Changelog) Empty() bool {
+	return len(c.Groups) == 0
+}
+
+// Render formats the changelog as markdown, json or keepachangelog
+func (c *Changelog) Render(format string) (string, error) {
+	switch format {
+	case FormatMarkdown, "":
+		return c.Markdown(), nil
+	case FormatJSON:
+		data, err := json.MarshalIndent(c, "", "  ")
+		return string(data) + "\n", err
+	case FormatKeepAChangelog:
+		return c.KeepAChangelog(), nil
+	}
+	return "", fmt.Errorf("unknown changelog format %q (want markdown, json or keepachangelog)", format)
+}
+
+// Markdown renders the changelog grouped by commit type
+func (c *Changelog) Markdown() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "## %s (%s)\n\n", c.Title, c.Date.Format("2006-01-02"))
+	if c.Empty() {
+		b.WriteString("No changes.\n")
+		return b.String()
+	}
+
+	if len(c.Breaking) > 0 {
+		b.WriteString("### ⚠ Breaking Changes\n\n")
+		for _, e := range c.Breaking {
+			note := e.Description
+			if e.BreakingNote != "" {
+				note = e.BreakingNote
+			}
+			b.WriteString(c.line(e, note) + "\n")
+		}
+		b.WriteString("\n")
+	}
+	for _, g := range c.Groups {
+		fmt.Fprintf(&b, "### %s\n\n", g.Title)
+		for _, e := range g.Entries {
+			b.WriteString(c.line(e, e.Description) + "\n")
+		}
+		b.WriteString("\n")
+	}
+	c.writeAuthors(&b)
+	return b.String()
+}
+
+// KeepAChangelog renders the changelog with the sections of
+// https://keepachangelog.com. Documentation, test, build, CI and chore
+// commits are left out.
+func (c *Changelog) KeepAChangelog() string {
+	sections := []struct {
+		title string
+		types []string
+	}{
+		{"Added", []string{"feat"}},
+		{"Changed", []string{"perf", "refactor", ""}},
+		{"Removed", []string{"revert"}},
+		{"Fixed", []string{"fix"}},
+	}
+
+	var b strings.Builder
+	if c.Title == "Unreleased" {
+		b.WriteString("## [Unreleased]\n\n")
+	} else {
+		fmt.Fprintf(&b, "## [%s] - %s\n\n", strings.TrimPrefix(c.Title, "v"), c.Date.Format("2006-01-02"))
+	}
+
+	for _, s := range sections {
+		var lines []string
+		for _, g := range c.Groups {
+			for _, t := range s.types {
+				if g.Type != t {
+					continue
+				}
+				for _, e := range g.Entries {
+					text := e.Description
+					if e.Breaking {
+						text = "**BREAKING:** " + text
+					}
+					lines = append(lines, c.line(e, text))
+				}
+			}
+		}
+		if len(lines) > 0 {
+			fmt.Fprintf(&b, "### %s\n\n%s\n\n", s.title, strings.Join(lines, "\n"))
+		}
+	}
+	return b.String()
+}
+
+// Text renders the changelog as plain text for tag messages. It avoids
+// lines starting with "#", which git strips from them.
+func (c *Changelog) Text() string {
+	var b strings.Builder
+	section := func(title string, lines []string) {
+		if len(lines) > 0 {
+			fmt.Fprintf(&b, "%s:\n%s\n\n", title, strings.Join(lines, "\n"))
+		}
+	}
+
+	var breaking []string
+	for _, e := range c.Breaking {
+		note := e.Description
+		if e.BreakingNote != "" {
+			note = e.BreakingNote
+		}
+		breaking = append(breaking, fmt.Sprintf("- %s (%s)", note, e.ShortHash))
+	}
+	section("Breaking changes", breaking)
+
+	for _, g := range c.Groups {
+		var lines []string
+		for _, e := range g.Entries {
+			text := e.Description
+			if e.Scope != "" {
+				text = e.Scope + ": " + text
+			}
+			lines = append(lines, fmt.Sprintf("- %s (%s)", text, e.ShortHash))
+		}
+		section(g.Title, lines)
+	}
+	return strings.TrimSpace(b.String())
+}
+
+// line renders one markdown list item with its scope, links and author
+func (c *Changelog) line(e Entry, text string) string {
+	s := "- "
+	if e.Scope != "" {
+		s += "**" + e.Scope + ":** "
+	}
+	s += text
+
+	for _, ref := range e.References {
+		if c.RepoURL != "" {
+			s += fmt.Sprintf(" ([#%s](%s))", ref, c.issueURL(ref))
+		} else {
+			s += " (#" + ref + ")"
+		}
+	}
+	if c.RepoURL != "" {
+		s += fmt.Sprintf(" ([%s](%s/commit/%s))", e.ShortHash, c.RepoURL, e.Hash)
+	} else {
+		s += " (" + e.ShortHash + ")"
+	}
+	return s + " — " + e.Author
+}
+
+// issueURL links an issue or PR number; GitHub redirects issue links to
+// pull requests
+func (c *Changelog) issueURL(number string) string {
+	if strings.Contains(c.RepoURL, "gitlab") {
+		return c.RepoURL + "/-/issues/" + number
+	}
+	return c.RepoURL + "/issues/" + number
+}
+
+func (c *Changelog) writeAuthors(b *strings.Builder) {
+	if len(c.Authors) == 0 {
+		return
+	}
+	b.WriteString("### Contributors\n\n")
+	for _, a := range c.Authors {
+		b.WriteString("- " + a + "\n")
+	}
+	b.WriteString("\n")
+}
+
+// repoURL returns the web URL of origin, or of the first remote
+func repoURL(g *git.Git) string {
+	remotes, err := g.GetRemotes()
+	if err != nil || len(remotes) == 0 {
+		return ""
+	}
+	raw := remotes[0].URL
+	for _, r := range remotes {
+		if r.Name == "origin" {
+			raw = r.URL
+			break
+		}
+	}
+	u, err := remoteurl.Parse(raw)
+	if err != nil {
+		return ""
+	}
+	return u.WebURL()
+}
+
+func appendUnique(list []string, s string) []string {
+	for _, v := range list {
+		if v == s {
+			return list
+		}
+	}
+	return append(list, s)
+}
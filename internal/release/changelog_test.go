@@ -0,0 +1,144 @@
+package release
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gitflow/tui/internal/git"
+)
+
+// testCommits are newest first, as git log lists them
+var testCommits = []git.Commit{
+	{Hash: "a1", ShortHash: "a1", Message: "feat(ui): add palette (#12)", Author: "Bea"},
+	{Hash: "b2", ShortHash: "b2", Message: "Merge branch 'topic'", Author: "Al", Parents: []string{"x", "y"}},
+	{Hash: "c3", ShortHash: "c3", Message: "fix: crash on empty repo", Body: "Fixes #7", Author: "Al"},
+	{Hash: "d4", ShortHash: "d4", Message: "feat(api)!: drop v1 endpoints", Author: "Al"},
+	{Hash: "e5", ShortHash: "e5", Message: "Update README", Author: "Cy"},
+	{Hash: "f6", ShortHash: "f6", Message: "feat: add search", Author: "Bea"},
+}
+
+func TestBuild(t *testing.T) {
+	c := Build(testCommits)
+
+	type group struct {
+		title  string
+		hashes []string
+	}
+	want := []group{
+		{"Features", []string{"f6", "d4", "a1"}},
+		{"Bug Fixes", []string{"c3"}},
+		{"Other Changes", []string{"e5"}},
+	}
+	if len(c.Groups) != len(want) {
+		t.Fatalf("Build() has %d groups, want %d: %+v", len(c.Groups), len(want), c.Groups)
+	}
+	for i, g := range c.Groups {
+		var hashes []string
+		for _, e := range g.Entries {
+			hashes = append(hashes, e.Hash)
+		}
+		if g.Title != want[i].title || strings.Join(hashes, ",") != strings.Join(want[i].hashes, ",") {
+			t.Errorf("group %d = %s %v, want %s %v", i, g.Title, hashes, want[i].title, want[i].hashes)
+		}
+	}
+
+	if len(c.Breaking) != 1 || c.Breaking[0].Hash != "d4" {
+		t.Errorf("Breaking = %+v, want d4 only", c.Breaking)
+	}
+	if got := strings.Join(c.Authors, ","); got != "Al,Bea,Cy" {
+		t.Errorf("Authors = %s, want Al,Bea,Cy", got)
+	}
+
+	palette := c.Groups[0].Entries[2]
+	if palette.Description != "add palette" || strings.Join(palette.References, ",") != "12" {
+		t.Errorf("entry a1 = %q refs %v, want trailing reference moved to References", palette.Description, palette.References)
+	}
+	if fix := c.Groups[1].Entries[0]; strings.Join(fix.References, ",") != "7" {
+		t.Errorf("entry c3 refs = %v, want [7] from the body", fix.References)
+	}
+}
+
+func TestBuildEmpty(t *testing.T) {
+	c := Build(nil)
+	if !c.Empty() {
+		t.Errorf("Build(nil).Empty() = false")
+	}
+	out, _ := c.Render(FormatMarkdown)
+	if !strings.Contains(out, "No changes.") {
+		t.Errorf("empty markdown = %q, want No changes.", out)
+	}
+}
+
+func TestRender(t *testing.T) {
+	c := Build(testCommits)
+	c.Title = "v2.0.0"
+	c.Date = time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		format string
+		want   []string
+		absent []string
+	}{
+		{FormatMarkdown, []string{
+			"## v2.0.0 (2024-05-01)",
+			"### ⚠ Breaking Changes\n\n- **api:** drop v1 endpoints (d4) — Al",
+			"### Features\n\n- add search (f6) — Bea\n- **api:** drop v1 endpoints (d4) — Al\n- **ui:** add palette (#12) (a1) — Bea",
+			"### Bug Fixes\n\n- crash on empty repo (#7) (c3) — Al",
+			"### Contributors\n\n- Al\n- Bea\n- Cy",
+		}, []string{"Merge branch"}},
+		{"", []string{"## v2.0.0 (2024-05-01)"}, nil},
+		{FormatKeepAChangelog, []string{
+			"## [2.0.0] - 2024-05-01",
+			"### Added\n\n- add search (f6) — Bea\n- **api:** **BREAKING:** drop v1 endpoints (d4) — Al",
+			"### Changed\n\n- Update README (e5) — Cy",
+			"### Fixed\n\n- crash on empty repo (#7) (c3) — Al",
+		}, []string{"Contributors"}},
+	}
+
+	for _, tt := range tests {
+		out, err := c.Render(tt.format)
+		if err != nil {
+			t.Fatalf("Render(%q): %v", tt.format, err)
+		}
+		for _, w := range tt.want {
+			if !strings.Contains(out, w) {
+				t.Errorf("Render(%q) is missing %q in:\n%s", tt.format, w, out)
+			}
+		}
+		for _, a := range tt.absent {
+			if strings.Contains(out, a) {
+				t.Errorf("Render(%q) contains %q", tt.format, a)
+			}
+		}
+	}
+
+	if _, err := c.Render("html"); err == nil {
+		t.Errorf("Render(html) succeeded, want an error")
+	}
+}
+
+func TestRenderLinks(t *testing.T) {
+	c := Build(testCommits[:1])
+	c.RepoURL = "https://gitlab.com/o/r"
+	out, _ := c.Render(FormatMarkdown)
+	want := "- **ui:** add palette ([#12](https://gitlab.com/o/r/-/issues/12)) ([a1](https://gitlab.com/o/r/commit/a1)) — Bea"
+	if !strings.Contains(out, want) {
+		t.Errorf("Render with RepoURL is missing %q in:\n%s", want, out)
+	}
+}
+
+func TestRenderJSON(t *testing.T) {
+	out, err := Build(testCommits).Render(FormatJSON)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded Changelog
+	if err := json.Unmarshal([]byte(out), &decoded); err != nil {
+		t.Fatalf("Render(json) is not valid JSON: %v", err)
+	}
+	if len(decoded.Groups) != 3 || len(decoded.Breaking) != 1 {
+		t.Errorf("decoded changelog has %d groups and %d breaking changes, want 3 and 1", len(decoded.Groups), len(decoded.Breaking))
+	}
+}
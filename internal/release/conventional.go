@@ -0,0 +1,56 @@
+package release
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Conventional is a commit message parsed as a conventional commit:
+// "type(scope)!: description"
+type Conventional struct {
+	Type         string
+	Scope        string
+	Description  string
+	Breaking     bool
+	BreakingNote string // Text of a BREAKING CHANGE footer
+}
+
+var headerPattern = regexp.MustCompile(`^([A-Za-z]+)(?:\(([^()]*)\))?(!)?: +(.+)$`)
+
+// ParseConventional parses a commit subject and body. It reports false
+// when the subject does not follow the convention.
+func ParseConventional(subject, body string) (Conventional, bool) {
+	match := headerPattern.FindStringSubmatch(strings.TrimSpace(subject))
+	if match == nil {
+		return Conventional{}, false
+	}
+
+	c := Conventional{
+		Type:        strings.ToLower(match[1]),
+		Scope:       match[2],
+		Breaking:    match[3] == "!",
+		Description: match[4],
+	}
+	for _, line := range strings.Split(body, "\n") {
+		for _, token := range []string{"BREAKING CHANGE:", "BREAKING-CHANGE:"} {
+			if note, ok := strings.CutPrefix(line, token); ok {
+				c.Breaking = true
+				c.BreakingNote = strings.TrimSpace(note)
+			}
+		}
+	}
+	return c, true
+}
+
+// Bump returns the version increment the commit calls for
+func (c Conventional) Bump() Bump {
+	switch {
+	case c.Breaking:
+		return BumpMajor
+	case c.Type == "feat":
+		return BumpMinor
+	case c.Type == "fix" || c.Type == "perf":
+		return BumpPatch
+	}
+	return BumpNone
+}
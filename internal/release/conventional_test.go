@@ -0,0 +1,41 @@
+package release
+
+import "testing"
+
+func TestParseConventional(t *testing.T) {
+	tests := []struct {
+		subject string
+		body    string
+		want    Conventional
+		ok      bool
+		bump    Bump
+	}{
+		{"feat: add login", "", Conventional{Type: "feat", Description: "add login"}, true, BumpMinor},
+		{"fix(auth): refresh tokens", "", Conventional{Type: "fix", Scope: "auth", Description: "refresh tokens"}, true, BumpPatch},
+		{"perf: cache tags", "", Conventional{Type: "perf", Description: "cache tags"}, true, BumpPatch},
+		{"Docs: typo", "", Conventional{Type: "docs", Description: "typo"}, true, BumpNone},
+		{"feat(api)!: drop v1", "", Conventional{Type: "feat", Scope: "api", Description: "drop v1", Breaking: true}, true, BumpMajor},
+		{"refactor: rename config", "Details.\n\nBREAKING CHANGE: keys renamed", Conventional{
+			Type: "refactor", Description: "rename config", Breaking: true, BreakingNote: "keys renamed",
+		}, true, BumpMajor},
+		{"chore: bump", "BREAKING-CHANGE: needs Go 1.21", Conventional{
+			Type: "chore", Description: "bump", Breaking: true, BreakingNote: "needs Go 1.21",
+		}, true, BumpMajor},
+		{"fix: a | b", "", Conventional{Type: "fix", Description: "a | b"}, true, BumpPatch},
+		{"  feat: padded  ", "", Conventional{Type: "feat", Description: "padded"}, true, BumpMinor},
+		{"Add login", "", Conventional{}, false, BumpNone},
+		{"feat:missing space", "", Conventional{}, false, BumpNone},
+		{"feat(a(b)): nested", "", Conventional{}, false, BumpNone},
+		{"fix2: digits", "", Conventional{}, false, BumpNone},
+	}
+
+	for _, tt := range tests {
+		got, ok := ParseConventional(tt.subject, tt.body)
+		if ok != tt.ok || got != tt.want {
+			t.Errorf("ParseConventional(%q, %q) = %+v, %v, want %+v, %v", tt.subject, tt.body, got, ok, tt.want, tt.ok)
+		}
+		if got.Bump() != tt.bump {
+			t.Errorf("ParseConventional(%q).Bump() = %s, want %s", tt.subject, got.Bump(), tt.bump)
+		}
+	}
+}
@@ -0,0 +1,94 @@
+package release
+
+import "github.com/gitflow/tui/internal/git"
+
+// Plan describes the next release
+type Plan struct {
+	Previous string  // Latest release tag, empty if there is none
+	Current  Version // Version of Previous, or 0.0.0
+	Since    string  // Tag the commits are counted from
+	Next     Version
+	Bump     Bump
+	Commits  []git.Commit // Non-merge commits since Since, newest first
+}
+
+// LatestVersion returns the tag with the highest semantic version,
+// including pre-releases only when pre is set
+func LatestVersion(tags []git.Tag, pre bool) (string, Version, bool) {
+	var name string
+	var latest Version
+	found := false
+	for _, t := range tags {
+		v, ok := ParseVersion(t.Name)
+		if !ok || (v.Pre != "" && !pre) {
+			continue
+		}
+		if !found || v.Compare(latest) > 0 {
+			name, latest, found = t.Name, v, true
+		}
+	}
+	return name, latest, found
+}
+
+// PlanRelease proposes the next version from the commits since the latest
+// release tag. With pre set the next version is a pre-release.
+func PlanRelease(g *git.Git, pre string) (*Plan, error) {
+	tags, err := g.GetTags()
+	if err != nil {
+		return nil, err
+	}
+
+	plan := &Plan{Current: Version{Prefix: "v"}}
+	if name, v, ok := LatestVersion(tags, true); ok {
+		plan.Previous, plan.Current = name, v
+	}
+	plan.Since = plan.Previous
+
+	// A release after pre-releases lists everything since the last release
+	if plan.Current.Pre != "" && pre == "" {
+		plan.Since, _, _ = LatestVersion(tags, false)
+	}
+
+	commits, err := g.CommitsBetween(plan.Since, "HEAD")
+	if err != nil {
+		return nil, err
+	}
+	for _, c := range commits {
+		if len(c.Parents) > 1 {
+			continue
+		}
+		plan.Commits = append(plan.Commits, c)
+		if conv, ok := ParseConventional(c.Message, c.Body); ok && conv.Bump() > plan.Bump {
+			plan.Bump = conv.Bump()
+		}
+	}
+
+	// Anything worth releasing is at least a patch
+	if plan.Bump == BumpNone {
+		plan.Bump = BumpPatch
+	}
+	plan.Next = plan.Current.Next(plan.Bump, pre)
+	return plan, nil
+}
+
+// Notes returns plain text release notes for the plan
+func (p *Plan) Notes() string {
+	return Build(p.Commits).Text()
+}
+
+// Create tags HEAD with the plan's next version as an annotated tag whose
+// message holds the release notes, and pushes it to remote if set
+func Create(g *git.Git, p *Plan, remote string) error {
+	name := p.Next.String()
+	message := name
+	if notes := p.Notes(); notes != "" {
+		message += "\n\n" + notes
+	}
+	if err := g.CreateTag(name, message); err != nil {
+		return err
+	}
+	if remote != "" {
+		return g.PushTag(remote, name)
+	}
+	return nil
+}
@@ -0,0 +1,182 @@
+package release
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Version is a semantic version, optionally written with a "v" prefix
+type Version struct {
+	Prefix string
+	Major  int
+	Minor  int
+	Patch  int
+	Pre    string // Pre-release identifiers, e.g. "rc.1"
+}
+
+// ParseVersion parses "v1.2.3", "1.2.3-rc.1" and the like. Build metadata
+// is accepted and dropped.
+func ParseVersion(s string) (Version, bool) {
+	var v Version
+	if strings.HasPrefix(s, "v") {
+		v.Prefix = "v"
+		s = s[1:]
+	}
+	s, _, _ = strings.Cut(s, "+")
+	core, pre, hasPre := strings.Cut(s, "-")
+	if hasPre {
+		if pre == "" {
+			return Version{}, false
+		}
+		v.Pre = pre
+	}
+
+	parts := strings.Split(core, ".")
+	if len(parts) != 3 {
+		return Version{}, false
+	}
+	nums := []*int{&v.Major, &v.Minor, &v.Patch}
+	for i, p := range parts {
+		n, err := strconv.Atoi(p)
+		if err != nil || n < 0 || (len(p) > 1 && p[0] == '0') {
+			return Version{}, false
+		}
+		*nums[i] = n
+	}
+	return v, true
+}
+
+// String formats the version with its prefix
+func (v Version) String() string {
+	s := fmt.Sprintf("%s%d.%d.%d", v.Prefix, v.Major, v.Minor, v.Patch)
+	if v.Pre != "" {
+		s += "-" + v.Pre
+	}
+	return s
+}
+
+// Compare returns -1, 0 or 1 by semver precedence
+func (v Version) Compare(o Version) int {
+	for _, d := range []int{v.Major - o.Major, v.Minor - o.Minor, v.Patch - o.Patch} {
+		if d != 0 {
+			return sign(d)
+		}
+	}
+
+	// A pre-release sorts before the release
+	switch {
+	case v.Pre == o.Pre:
+		return 0
+	case v.Pre == "":
+		return 1
+	case o.Pre == "":
+		return -1
+	}
+
+	a, b := strings.Split(v.Pre, "."), strings.Split(o.Pre, ".")
+	for i := 0; i < len(a) && i < len(b); i++ {
+		if c := compareIdentifier(a[i], b[i]); c != 0 {
+			return c
+		}
+	}
+	return sign(len(a) - len(b))
+}
+
+// compareIdentifier compares pre-release identifiers: numbers numerically
+// and below alphanumerics, which compare as strings
+func compareIdentifier(a, b string) int {
+	x, errA := strconv.Atoi(a)
+	y, errB := strconv.Atoi(b)
+	switch {
+	case errA == nil && errB == nil:
+		return sign(x - y)
+	case errA == nil:
+		return -1
+	case errB == nil:
+		return 1
+	}
+	return strings.Compare(a, b)
+}
+
+func sign(n int) int {
+	switch {
+	case n < 0:
+		return -1
+	case n > 0:
+		return 1
+	}
+	return 0
+}
+
+// Bump is the kind of version increment
+type Bump int
+
+const (
+	BumpNone Bump = iota
+	BumpPatch
+	BumpMinor
+	BumpMajor
+)
+
+// String returns the bump name
+func (b Bump) String() string {
+	switch b {
+	case BumpPatch:
+		return "patch"
+	case BumpMinor:
+		return "minor"
+	case BumpMajor:
+		return "major"
+	}
+	return "none"
+}
+
+// Next returns the version after v for bump. With pre set the result is a
+// pre-release: "rc" gives "-rc.1", and "-rc.2" when v already is rc.1.
+// A pre-release of v carries the bump its base version implies, 1.3.0-rc.2
+// a minor one, so releasing a minor or patch after it gives 1.3.0 while a
+// major bump gives 2.0.0.
+func (v Version) Next(bump Bump, pre string) Version {
+	base := v
+	base.Pre = ""
+
+	next := base
+	if v.Pre == "" || bump > v.carriedBump() {
+		switch bump {
+		case BumpMajor:
+			next.Major, next.Minor, next.Patch = v.Major+1, 0, 0
+		case BumpMinor:
+			next.Minor, next.Patch = v.Minor+1, 0
+		default:
+			next.Patch = v.Patch + 1
+		}
+	}
+	if pre == "" {
+		return next
+	}
+
+	// Counting on only continues for the same version
+	sameVersion := next == base
+	next.Pre = pre + ".1"
+	if v.Pre != "" && sameVersion {
+		if n, ok := strings.CutPrefix(v.Pre, pre+"."); ok {
+			if i, err := strconv.Atoi(n); err == nil {
+				next.Pre = fmt.Sprintf("%s.%d", pre, i+1)
+			}
+		}
+	}
+	return next
+}
+
+// carriedBump returns the bump that leads to v's version from the
+// previous release: x.0.0 is a major, x.y.0 a minor and x.y.z a patch
+func (v Version) carriedBump() Bump {
+	switch {
+	case v.Minor == 0 && v.Patch == 0:
+		return BumpMajor
+	case v.Patch == 0:
+		return BumpMinor
+	}
+	return BumpPatch
+}
@@ -0,0 +1,101 @@
+package release
+
+import "testing"
+
+func TestParseVersion(t *testing.T) {
+	tests := []struct {
+		in   string
+		want Version
+		ok   bool
+	}{
+		{"1.2.3", Version{Major: 1, Minor: 2, Patch: 3}, true},
+		{"v1.2.3", Version{Prefix: "v", Major: 1, Minor: 2, Patch: 3}, true},
+		{"v1.3.0-rc.1", Version{Prefix: "v", Major: 1, Minor: 3, Pre: "rc.1"}, true},
+		{"1.0.0-alpha+build.5", Version{Major: 1, Pre: "alpha"}, true},
+		{"0.0.0", Version{}, true},
+		{"1.2", Version{}, false},
+		{"1.2.3.4", Version{}, false},
+		{"01.2.3", Version{}, false},
+		{"1.2.3-", Version{}, false},
+		{"v1.x.3", Version{}, false},
+		{"release-1", Version{}, false},
+	}
+
+	for _, tt := range tests {
+		got, ok := ParseVersion(tt.in)
+		if ok != tt.ok || got != tt.want {
+			t.Errorf("ParseVersion(%q) = %+v, %v, want %+v, %v", tt.in, got, ok, tt.want, tt.ok)
+		}
+		if ok && got.String() != tt.in && tt.in != "1.0.0-alpha+build.5" {
+			t.Errorf("ParseVersion(%q).String() = %q", tt.in, got.String())
+		}
+	}
+}
+
+func TestCompare(t *testing.T) {
+	// Each version sorts before the next, as in the semver specification
+	ordered := []string{
+		"1.0.0-alpha",
+		"1.0.0-alpha.1",
+		"1.0.0-alpha.beta",
+		"1.0.0-beta",
+		"1.0.0-beta.2",
+		"1.0.0-beta.11",
+		"1.0.0-rc.1",
+		"1.0.0",
+		"1.0.1",
+		"1.1.0",
+		"2.0.0",
+	}
+
+	for i := range ordered {
+		for j := range ordered {
+			a, _ := ParseVersion(ordered[i])
+			b, _ := ParseVersion(ordered[j])
+			want := sign(i - j)
+			if got := a.Compare(b); got != want {
+				t.Errorf("Compare(%s, %s) = %d, want %d", ordered[i], ordered[j], got, want)
+			}
+		}
+	}
+
+	a, _ := ParseVersion("v1.2.3")
+	b, _ := ParseVersion("1.2.3")
+	if got := a.Compare(b); got != 0 {
+		t.Errorf("Compare(v1.2.3, 1.2.3) = %d, want 0", got)
+	}
+}
+
+func TestNext(t *testing.T) {
+	tests := []struct {
+		from string
+		bump Bump
+		pre  string
+		want string
+	}{
+		{"v1.2.3", BumpPatch, "", "v1.2.4"},
+		{"v1.2.3", BumpMinor, "", "v1.3.0"},
+		{"v1.2.3", BumpMajor, "", "v2.0.0"},
+		{"v1.2.3", BumpNone, "", "v1.2.4"},
+		{"v1.2.3", BumpMinor, "rc", "v1.3.0-rc.1"},
+		{"v1.3.0-rc.1", BumpMinor, "rc", "v1.3.0-rc.2"},
+		{"v1.3.0-rc.1", BumpPatch, "rc", "v1.3.0-rc.2"},
+		{"v1.3.0-rc.2", BumpMinor, "", "v1.3.0"},
+		{"v1.3.0-rc.1", BumpMajor, "rc", "v2.0.0-rc.1"},
+		{"v1.3.0-rc.1", BumpMajor, "", "v2.0.0"},
+		{"v1.3.1-rc.1", BumpMinor, "rc", "v1.4.0-rc.1"},
+		{"v2.0.0-rc.1", BumpMajor, "rc", "v2.0.0-rc.2"},
+		{"v1.3.0-beta.2", BumpMinor, "rc", "v1.3.0-rc.1"},
+		{"v1.3.0-rc.x", BumpMinor, "rc", "v1.3.0-rc.1"},
+	}
+
+	for _, tt := range tests {
+		v, ok := ParseVersion(tt.from)
+		if !ok {
+			t.Fatalf("ParseVersion(%q) failed", tt.from)
+		}
+		if got := v.Next(tt.bump, tt.pre).String(); got != tt.want {
+			t.Errorf("%s.Next(%s, %q) = %s, want %s", tt.from, tt.bump, tt.pre, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,329 @@
+package remoteurl
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"net/url"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// Scheme represents a Git transport
+type Scheme string
+
+const (
+	SSH   Scheme = "ssh"
+	Git   Scheme = "git"
+	HTTP  Scheme = "http"
+	HTTPS Scheme = "https"
+	FTP   Scheme = "ftp"
+	FTPS  Scheme = "ftps"
+	File  Scheme = "file"
+)
+
+// URL represents a parsed Git remote URL
+type URL struct {
+	Raw    string
+	Scheme Scheme
+	User   string
+	Host   string
+	Port   int
+	Path   string // Repository path; absolute only for local remotes
+	Owner  string // Everything before the last path segment
+	Repo   string // Last path segment without .git suffix
+	SCP    bool   // Written in scp-like form (user@host:path)
+}
+
+// Rule represents a url.<base>.insteadOf rewrite
+type Rule struct {
+	Base      string
+	InsteadOf string
+	Push      bool // pushInsteadOf
+}
+
+// Parse parses any URL form accepted by git
+func Parse(raw string) (*URL, error) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return nil, fmt.Errorf("empty remote URL")
+	}
+
+	if i := strings.Index(raw, "://"); i > 0 {
+		return parseStandard(raw, raw[:i])
+	}
+
+	if isSCPLike(raw) {
+		return parseSCP(raw)
+	}
+
+	// Anything else is a local path
+	u := &URL{Raw: raw, Scheme: File, Path: filepath.ToSlash(raw)}
+	u.splitPath()
+	return u, nil
+}
+
+// parseStandard parses scheme://[user@]host[:port]/path URLs
+func parseStandard(raw, scheme string) (*URL, error) {
+	scheme = strings.ToLower(scheme)
+
+	// git+ssh:// and ssh+git:// are legacy aliases for ssh://
+	if scheme == "git+ssh" || scheme == "ssh+git" {
+		scheme = string(SSH)
+	}
+
+	switch Scheme(scheme) {
+	case SSH, Git, HTTP, HTTPS, FTP, FTPS, File:
+	default:
+		return nil, fmt.Errorf("unsupported transport %q in %s", scheme, raw)
+	}
+
+	u := &URL{Raw: raw, Scheme: Scheme(scheme)}
+
+	if u.Scheme == File {
+		u.Path = raw[len(scheme)+3:]
+		u.splitPath()
+		return u, nil
+	}
+
+	// ssh://host:~user/repo is valid for git but not for net/url
+	rest := raw[strings.Index(raw, "://")+3:]
+	parsed, err := url.Parse(scheme + "://" + strings.Replace(rest, ":~", "/~", 1))
+	if err != nil {
+		return nil, fmt.Errorf("invalid remote URL %s: %w", raw, err)
+	}
+
+	if parsed.User != nil {
+		u.User = parsed.User.Username()
+	}
+	u.Host = parsed.Hostname()
+	if p := parsed.Port(); p != "" {
+		port, err := strconv.Atoi(p)
+		if err != nil {
+			return nil, fmt.Errorf("invalid port %q in %s", p, raw)
+		}
+		u.Port = port
+	}
+	if u.Host == "" {
+		return nil, fmt.Errorf("missing host in %s", raw)
+	}
+
+	u.Path = strings.TrimPrefix(parsed.Path, "/")
+	u.splitPath()
+	return u, nil
+}
+
+// isSCPLike reports whether raw uses the [user@]host:path form.
+// Like git, a colon after the first slash means a local path.
+func isSCPLike(raw string) bool {
+	colon := scpColon(raw)
+	if colon <= 0 {
+		return false
+	}
+	if slash := strings.Index(raw, "/"); slash >= 0 && slash < colon {
+		return false
+	}
+	// Windows drive letters (C:\repo) are local paths
+	if colon == 1 && len(raw) > 2 && (raw[2] == '\\' || raw[2] == '/') {
+		return false
+	}
+	return true
+}
+
+// scpColon returns the index of the colon separating host and path in
+// scp-like URLs. A bracketed host, "[user@host:port]" or "[::1]", may
+// contain colons itself.
+func scpColon(raw string) int {
+	if open := strings.Index(raw, "["); open >= 0 && open < strings.Index(raw, ":") {
+		if end := strings.Index(raw, "]:"); end > open {
+			return end + 1
+		}
+	}
+	return strings.Index(raw, ":")
+}
+
+// parseSCP parses [user@]host:path URLs, including [user@host:port]:path
+// and user@[host]:path
+func parseSCP(raw string) (*URL, error) {
+	colon := scpColon(raw)
+	hostPart, pathPart := raw[:colon], raw[colon+1:]
+	bracketed := strings.Contains(hostPart, "[")
+
+	u := &URL{Raw: raw, Scheme: SSH, SCP: true}
+	if at := strings.LastIndex(hostPart, "@"); at >= 0 {
+		u.User = strings.TrimPrefix(hostPart[:at], "[")
+		hostPart = hostPart[at+1:]
+	}
+	u.Host = strings.Trim(hostPart, "[]")
+
+	// One colon in brackets separates the port; more make an IPv6 address
+	if bracketed && strings.Count(u.Host, ":") == 1 {
+		host, p, _ := strings.Cut(u.Host, ":")
+		port, err := strconv.Atoi(p)
+		if err != nil {
+			return nil, fmt.Errorf("invalid port %q in %s", p, raw)
+		}
+		u.Host, u.Port = host, port
+	}
+	if u.Host == "" {
+		return nil, fmt.Errorf("missing host in %s", raw)
+	}
+
+	u.Path = strings.TrimPrefix(pathPart, "/")
+	u.splitPath()
+	return u, nil
+}
+
+// splitPath fills Owner and Repo from Path
+func (u *URL) splitPath() {
+	p := strings.TrimSuffix(u.Path, "/")
+	u.Repo = strings.TrimSuffix(path.Base(p), ".git")
+	if dir := path.Dir(p); dir != "." && dir != "/" {
+		u.Owner = strings.TrimPrefix(dir, "/")
+	}
+}
+
+// IsLocal reports whether the URL points at the local filesystem
+func (u *URL) IsLocal() bool {
+	return u.Scheme == File
+}
+
+// HostPort returns host or host:port when a non-default port is set
+func (u *URL) HostPort() string {
+	if u.Port == 0 {
+		return u.Host
+	}
+	return fmt.Sprintf("%s:%d", u.Host, u.Port)
+}
+
+// FullName returns owner/repo as used by forges
+func (u *URL) FullName() string {
+	if u.Owner == "" {
+		return u.Repo
+	}
+	return u.Owner + "/" + u.Repo
+}
+
+// String returns the canonical URL form
+func (u *URL) String() string {
+	if u.Scheme == File {
+		if strings.HasPrefix(u.Raw, "file://") {
+			return "file://" + u.Path
+		}
+		return u.Path
+	}
+
+	var b strings.Builder
+	if u.SCP {
+		if u.User != "" {
+			b.WriteString(u.User + "@")
+		}
+		switch {
+		case u.Port != 0:
+			return fmt.Sprintf("[%s%s:%d]:%s", b.String(), u.Host, u.Port, u.Path)
+		case strings.Contains(u.Host, ":"):
+			b.WriteString("[" + u.Host + "]:" + u.Path)
+		default:
+			b.WriteString(u.Host + ":" + u.Path)
+		}
+		return b.String()
+	}
+
+	b.WriteString(string(u.Scheme) + "://")
+	if u.User != "" {
+		b.WriteString(u.User + "@")
+	}
+	b.WriteString(u.HostPort())
+	b.WriteString("/" + u.Path)
+	return b.String()
+}
+
+// WebURL returns the https browse URL for forge-hosted remotes
+func (u *URL) WebURL() string {
+	if u.IsLocal() {
+		return ""
+	}
+	return fmt.Sprintf("https://%s/%s", u.Host, strings.TrimSuffix(u.Path, ".git"))
+}
+
+// Rewrite applies insteadOf rules the way git does: the longest
+// matching prefix wins. Push rewrites pushInsteadOf rules first.
+func Rewrite(raw string, rules []Rule, push bool) string {
+	if push {
+		if base, ok := longestMatch(raw, rules, true); ok {
+			return base
+		}
+	}
+	if base, ok := longestMatch(raw, rules, false); ok {
+		return base
+	}
+	return raw
+}
+
+func longestMatch(raw string, rules []Rule, push bool) (string, bool) {
+	best := -1
+	var result string
+	for _, r := range rules {
+		if r.Push != push || r.InsteadOf == "" {
+			continue
+		}
+		if strings.HasPrefix(raw, r.InsteadOf) && len(r.InsteadOf) > best {
+			best = len(r.InsteadOf)
+			result = r.Base + raw[len(r.InsteadOf):]
+		}
+	}
+	return result, best >= 0
+}
+
+// LoadRules reads url.*.insteadOf and url.*.pushInsteadOf from git config.
+// An empty repoPath resolves configuration from the current directory.
+func LoadRules(repoPath string) ([]Rule, error) {
+	cmd := exec.Command("git", "config", "--get-regexp", `^url\..*\.(insteadof|pushinsteadof)$`)
+	cmd.Dir = repoPath
+	var out bytes.Buffer
+	cmd.Stdout = &out
+	if err := cmd.Run(); err != nil {
+		// Exit code 1 means no matching keys
+		if exitErr, ok := err.(*exec.ExitError); ok && exitErr.ExitCode() == 1 {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var rules []Rule
+	scanner := bufio.NewScanner(&out)
+	for scanner.Scan() {
+		key, value, ok := strings.Cut(scanner.Text(), " ")
+		if !ok {
+			continue
+		}
+
+		lower := strings.ToLower(key)
+		rule := Rule{InsteadOf: value}
+		switch {
+		case strings.HasSuffix(lower, ".pushinsteadof"):
+			rule.Base = key[len("url.") : len(key)-len(".pushinsteadof")]
+			rule.Push = true
+		case strings.HasSuffix(lower, ".insteadof"):
+			rule.Base = key[len("url.") : len(key)-len(".insteadof")]
+		default:
+			continue
+		}
+		rules = append(rules, rule)
+	}
+
+	return rules, nil
+}
+
+// Resolve rewrites raw with the insteadOf rules visible from repoPath
+// and parses the result
+func Resolve(repoPath, raw string, push bool) (*URL, error) {
+	rules, err := LoadRules(repoPath)
+	if err != nil {
+		return nil, err
+	}
+	return Parse(Rewrite(raw, rules, push))
+}
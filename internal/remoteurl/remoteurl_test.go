@@ -0,0 +1,135 @@
+package remoteurl
+
+import (
+	"os/exec"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		raw    string
+		scheme Scheme
+		user   string
+		host   string
+		port   int
+		path   string
+		owner  string
+		repo   string
+		scp    bool
+	}{
+		{"https://github.com/owner/repo.git", HTTPS, "", "github.com", 0, "owner/repo.git", "owner", "repo", false},
+		{"https://user@example.com:8443/group/sub/repo", HTTPS, "user", "example.com", 8443, "group/sub/repo", "group/sub", "repo", false},
+		{"ssh://git@example.com:2222/owner/repo.git", SSH, "git", "example.com", 2222, "owner/repo.git", "owner", "repo", false},
+		{"git+ssh://git@example.com/owner/repo.git", SSH, "git", "example.com", 0, "owner/repo.git", "owner", "repo", false},
+		{"ssh://example.com:~user/repo.git", SSH, "", "example.com", 0, "~user/repo.git", "~user", "repo", false},
+		{"git://example.com/repo.git", Git, "", "example.com", 0, "repo.git", "", "repo", false},
+		{"git@github.com:owner/repo.git", SSH, "git", "github.com", 0, "owner/repo.git", "owner", "repo", true},
+		{"example.com:repo", SSH, "", "example.com", 0, "repo", "", "repo", true},
+		{"[git@example.com:2222]:owner/repo.git", SSH, "git", "example.com", 2222, "owner/repo.git", "owner", "repo", true},
+		{"[example.com:2222]:repo.git", SSH, "", "example.com", 2222, "repo.git", "", "repo", true},
+		{"git@[::1]:owner/repo.git", SSH, "git", "::1", 0, "owner/repo.git", "owner", "repo", true},
+		{"[::1]:repo.git", SSH, "", "::1", 0, "repo.git", "", "repo", true},
+		{"/srv/git/repo.git", File, "", "", 0, "/srv/git/repo.git", "srv/git", "repo", false},
+		{"./a:b/repo", File, "", "", 0, "./a:b/repo", "a:b", "repo", false},
+		{"file:///srv/git/repo.git", File, "", "", 0, "/srv/git/repo.git", "srv/git", "repo", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.raw, func(t *testing.T) {
+			u, err := Parse(tt.raw)
+			if err != nil {
+				t.Fatalf("Parse(%q): %v", tt.raw, err)
+			}
+			if u.Scheme != tt.scheme || u.User != tt.user || u.Host != tt.host || u.Port != tt.port {
+				t.Errorf("Parse(%q) = scheme %q user %q host %q port %d, want %q %q %q %d",
+					tt.raw, u.Scheme, u.User, u.Host, u.Port, tt.scheme, tt.user, tt.host, tt.port)
+			}
+			if u.Path != tt.path || u.Owner != tt.owner || u.Repo != tt.repo || u.SCP != tt.scp {
+				t.Errorf("Parse(%q) = path %q owner %q repo %q scp %v, want %q %q %q %v",
+					tt.raw, u.Path, u.Owner, u.Repo, u.SCP, tt.path, tt.owner, tt.repo, tt.scp)
+			}
+			if got := u.String(); got != tt.raw && !(tt.scheme == SSH && !tt.scp) {
+				t.Errorf("Parse(%q).String() = %q", tt.raw, got)
+			}
+		})
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	for _, raw := range []string{
+		"",
+		"svn://example.com/repo",
+		"https:///repo",
+		"[example.com:port]:repo",
+	} {
+		if u, err := Parse(raw); err == nil {
+			t.Errorf("Parse(%q) = %+v, want error", raw, u)
+		}
+	}
+}
+
+func TestRewrite(t *testing.T) {
+	rules := []Rule{
+		{Base: "https://github.com/", InsteadOf: "gh:"},
+		{Base: "https://github.com/org/", InsteadOf: "gh:org/"},
+		{Base: "git@github.com:", InsteadOf: "https://github.com/", Push: true},
+	}
+
+	tests := []struct {
+		raw  string
+		push bool
+		want string
+	}{
+		{"gh:owner/repo", false, "https://github.com/owner/repo"},
+		{"gh:org/repo", false, "https://github.com/org/repo"},
+		{"https://github.com/owner/repo", false, "https://github.com/owner/repo"},
+		{"https://github.com/owner/repo", true, "git@github.com:owner/repo"},
+		{"gh:owner/repo", true, "https://github.com/owner/repo"},
+		{"git@example.com:repo", false, "git@example.com:repo"},
+	}
+
+	for _, tt := range tests {
+		if got := Rewrite(tt.raw, rules, tt.push); got != tt.want {
+			t.Errorf("Rewrite(%q, push=%v) = %q, want %q", tt.raw, tt.push, got, tt.want)
+		}
+	}
+}
+
+func TestResolve(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+
+	dir := t.TempDir()
+	for _, args := range [][]string{
+		{"init", "-q"},
+		{"config", "url.git@example.com:.insteadOf", "ex:"},
+		{"config", "url.ssh://push.example.com/.pushInsteadOf", "ex:"},
+	} {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v: %s", args, err, out)
+		}
+	}
+
+	tests := []struct {
+		raw  string
+		push bool
+		want string
+	}{
+		{"ex:owner/repo.git", false, "git@example.com:owner/repo.git"},
+		{"ex:owner/repo.git", true, "ssh://push.example.com/owner/repo.git"},
+		{"https://github.com/owner/repo.git", false, "https://github.com/owner/repo.git"},
+	}
+
+	for _, tt := range tests {
+		u, err := Resolve(dir, tt.raw, tt.push)
+		if err != nil {
+			t.Fatalf("Resolve(%q): %v", tt.raw, err)
+		}
+		if got := u.String(); got != tt.want {
+			t.Errorf("Resolve(%q, push=%v) = %q, want %q", tt.raw, tt.push, got, tt.want)
+		}
+	}
+}
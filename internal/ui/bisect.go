@@ -0,0 +1,255 @@
+package ui
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/gitflow/tui/internal/git"
+)
+
+// cmdBisect shows the bisect actions
+func cmdBisect(m *Model) tea.Cmd {
+	return func() tea.Msg {
+		m.showBisectMenu()
+		return nil
+	}
+}
+
+// cmdBisectReset ends the bisect session
+func cmdBisectReset(m *Model) tea.Cmd {
+	return m.bisectReset()
+}
+
+// showBisectMenu shows the actions for the bisect session, or how to
+// start one
+func (m *Model) showBisectMenu() {
+	if m.bisect == nil {
+		m.openMenu("Bisect: find the commit that introduced a bug", []menuItem{
+			{"s", "Start (then mark a bad and a good commit in the Graph tab)", func(m *Model) tea.Cmd {
+				m.selectTab(m.tabIndex(ViewGraph))
+				return m.bisectCmd(func() (string, error) {
+					return "Bisecting: mark a bad commit with x and a good one with o", m.git.BisectStart()
+				})
+			}},
+		})
+		return
+	}
+
+	var items []menuItem
+	if m.bisect.Culprit != "" {
+		items = append(items, menuItem{"d", "Show first bad commit", func(m *Model) tea.Cmd {
+			m.showCulprit()
+			return nil
+		}})
+	} else {
+		mark := func(term string) func(m *Model) tea.Cmd {
+			return func(m *Model) tea.Cmd {
+				return m.bisectMark(term, "")
+			}
+		}
+		items = append(items,
+			menuItem{"o", "Mark checked-out commit good", mark(git.BisectGood)},
+			menuItem{"x", "Mark checked-out commit bad", mark(git.BisectBad)},
+			menuItem{"s", "Skip checked-out commit", mark(git.BisectSkip)},
+		)
+		if m.bisect.Bad != "" && len(m.bisect.Good) > 0 {
+			items = append(items, menuItem{"r", "Run a test command...", func(m *Model) tea.Cmd {
+				m.prompt("bisect-run", "Command; exit 0 good, 125 skip, other bad...", m.bisectCommand, func(command string) {
+					command = strings.TrimSpace(command)
+					if command == "" {
+						return
+					}
+					m.bisectCommand = command
+					m.inputCmd = m.bisectRun(command)
+				})
+				return nil
+			}})
+		}
+	}
+	items = append(items,
+		menuItem{"l", "Show bisect log", func(m *Model) tea.Cmd {
+			log, err := m.git.BisectLog()
+			if err != nil {
+				m.errorMsg = err.Error()
+				return nil
+			}
+			m.outputTitle = "Bisect log"
+			m.outputContent = log
+			m.currentView = ViewOutput
+			return nil
+		}},
+		menuItem{"R", "Reset and return to the original branch", func(m *Model) tea.Cmd {
+			return m.bisectReset()
+		}},
+	)
+
+	m.openMenu("Bisect: "+m.bisectStatus(), items)
+}
+
+// bisectStatus summarizes the bisect session
+func (m *Model) bisectStatus() string {
+	b := m.bisect
+	switch {
+	case b.Culprit != "":
+		return "first bad commit is " + m.bisectCulprit
+	case b.Bad == "" && len(b.Good) == 0:
+		return "mark a bad and a good commit"
+	case b.Bad == "":
+		return "mark a bad commit"
+	case len(b.Good) == 0:
+		return "mark a good commit"
+	}
+	commits, steps := b.Left()
+	if commits == 0 {
+		return "only skipped commits left to test"
+	}
+	return fmt.Sprintf("%d commit(s) left to test, roughly %d step(s)", commits, steps)
+}
+
+// commitSummary returns a commit's short hash and subject
+func (m *Model) commitSummary(hash string) string {
+	out, err := m.git.Execute("show", "--no-patch", "--format=%h %s", hash, "--")
+	if err != nil {
+		return hash[:min(len(hash), 7)]
+	}
+	return strings.TrimSpace(out)
+}
+
+// handleBisectKey marks the commit selected in the Graph tab. Without a
+// bisect session it asks before starting one
+func (m *Model) handleBisectKey(term string) tea.Cmd {
+	if m.selectedCommit >= len(m.commits) {
+		return nil
+	}
+	commit := m.commits[m.selectedCommit]
+	if m.bisect != nil {
+		return m.bisectMark(term, commit.Hash)
+	}
+	if term == git.BisectSkip {
+		m.errorMsg = "Not bisecting; mark a bad and a good commit first"
+		return nil
+	}
+	m.confirm(fmt.Sprintf("Start bisecting with %s as %s?", commit.ShortHash, term), func() {
+		m.inputCmd = m.bisectCmd(func() (string, error) {
+			if err := m.git.BisectStart(); err != nil {
+				return "", err
+			}
+			return m.git.BisectMark(term, commit.Hash)
+		})
+	})
+	return nil
+}
+
+// bisectMark marks rev, or the checked-out commit when rev is empty, and
+// moves on to the next commit to test
+func (m *Model) bisectMark(term, rev string) tea.Cmd {
+	return m.bisectCmd(func() (string, error) {
+		return m.git.BisectMark(term, rev)
+	})
+}
+
+// bisectMsg reports a bisect step that ran in the background
+type bisectMsg struct {
+	out string
+	err error
+}
+
+// bisectCmd runs a bisect step, which may check out another commit, in the
+// background
+func (m *Model) bisectCmd(step func() (string, error)) tea.Cmd {
+	return func() tea.Msg {
+		out, err := step()
+		return bisectMsg{out: out, err: err}
+	}
+}
+
+// bisectDone reloads the bisect session after a step and shows the first
+// bad commit once it is found
+func (m *Model) bisectDone(msg bisectMsg) {
+	m.reloadBisect()
+	if msg.err != nil {
+		m.errorMsg = msg.err.Error()
+		return
+	}
+	m.successMsg = msg.out
+	if m.bisect != nil && m.bisect.Culprit != "" {
+		m.showCulprit()
+	}
+}
+
+// bisectRun lets git test the remaining commits with command, streaming
+// its output, and shows the first bad commit when it is found
+func (m *Model) bisectRun(command string) tea.Cmd {
+	return m.stream("Bisect run: "+command, func(w io.Writer) error {
+		return m.git.BisectRun(w, command)
+	}, func(m *Model, err error) {
+		m.reloadBisect()
+		switch {
+		case err != nil:
+			m.errorMsg = "Bisect run failed: " + err.Error()
+		case m.bisect != nil && m.bisect.Culprit != "":
+			m.showCulprit()
+		default:
+			m.successMsg = "Bisect run finished: " + m.bisectStatus()
+		}
+	})
+}
+
+// showCulprit shows the first bad commit
+func (m *Model) showCulprit() {
+	culprit := m.bisect.Culprit
+	m.successMsg = "First bad commit: " + m.bisectCulprit + " · run bisect-reset to finish"
+	m.showDiff(func() (string, error) { return m.git.ShowCommit(culprit) })
+}
+
+// bisectReset ends the bisect session
+func (m *Model) bisectReset() tea.Cmd {
+	return m.bisectCmd(func() (string, error) {
+		return "Bisect finished", m.git.BisectReset()
+	})
+}
+
+// loadBisect loads the bisect session, looking up the first bad commit's
+// summary once when it is found so rendering the status needs no git
+func (m *Model) loadBisect() error {
+	b, err := m.git.GetBisect()
+	if err != nil {
+		return err
+	}
+	switch {
+	case b == nil || b.Culprit == "":
+		m.bisectCulprit = ""
+	case m.bisect == nil || m.bisect.Culprit != b.Culprit || m.bisectCulprit == "":
+		m.bisectCulprit = m.commitSummary(b.Culprit)
+	}
+	m.bisect = b
+	return nil
+}
+
+// reloadBisect reloads the bisect session and the Graph tab, selecting the
+// commit checked out for testing
+func (m *Model) reloadBisect() {
+	if err := m.loadBisect(); err != nil {
+		m.errorMsg = err.Error()
+		return
+	}
+	b := m.bisect
+
+	commits, err := m.git.FilterCommits(m.graphFilter(m.logFilter))
+	if err != nil {
+		m.errorMsg = err.Error()
+		return
+	}
+	m.commits = commits
+	m.selectedCommit = min(m.selectedCommit, max(0, len(commits)-1))
+	if b == nil {
+		return
+	}
+	for i, c := range commits {
+		if c.Hash == b.Current {
+			m.selectedCommit = i
+		}
+	}
+}
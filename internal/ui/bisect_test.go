@@ -0,0 +1,19 @@
+package ui
+
+import (
+	"testing"
+
+	"github.com/gitflow/tui/internal/git"
+)
+
+func TestBisectStatusCulprit(t *testing.T) {
+	// The summary is looked up when the culprit is found, not per render
+	m := &Model{
+		git:           git.New(t.TempDir()),
+		bisect:        &git.Bisect{Bad: "abc", Remaining: []string{"abc"}, Culprit: "abc"},
+		bisectCulprit: "abc1234 Break the parser",
+	}
+	if got, want := m.bisectStatus(), "first bad commit is abc1234 Break the parser"; got != want {
+		t.Errorf("bisectStatus() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,384 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/gitflow/tui/internal/git"
+)
+
+// blameView is a blamed file; earlier holds the newer revisions it was
+// re-blamed from, so back can return to them
+type blameView struct {
+	rev      string
+	path     string
+	lines    []git.BlameLine
+	selected int
+	earlier  []blameView
+}
+
+// fileHistory is the list of commits that changed a file
+type fileHistory struct {
+	path      string
+	revisions []git.FileRevision
+	selected  int
+}
+
+// cmdBlame asks for a file and blames it
+func cmdBlame(m *Model) tea.Cmd {
+	return func() tea.Msg {
+		m.prompt("blame", "File to blame...", m.selectedPath(), func(path string) {
+			if path != "" {
+				m.openBlame("", path)
+			}
+		})
+		return nil
+	}
+}
+
+// cmdFileHistory asks for a file and lists the commits that changed it
+func cmdFileHistory(m *Model) tea.Cmd {
+	return func() tea.Msg {
+		m.prompt("file-history", "File to show history of...", m.selectedPath(), func(path string) {
+			if path != "" {
+				m.openFileHistory(path)
+			}
+		})
+		return nil
+	}
+}
+
+// selectedPath returns the first changed file, a starting point for
+// prompts asking for a path
+func (m *Model) selectedPath() string {
+	if m.status == nil {
+		return ""
+	}
+	for _, files := range [][]git.FileStatus{m.status.Staged, m.status.Unstaged} {
+		if len(files) > 0 {
+			return files[0].Path
+		}
+	}
+	return ""
+}
+
+// openBlame blames path at rev, or the working tree when rev is empty
+func (m *Model) openBlame(rev, path string) bool {
+	lines, err := m.git.Blame(rev, path)
+	if err != nil {
+		m.errorMsg = err.Error()
+		return false
+	}
+	if len(lines) == 0 {
+		m.errorMsg = path + " is empty"
+		return false
+	}
+	m.blame = &blameView{rev: rev, path: path, lines: lines}
+	m.browser = ViewBlame
+	m.currentView = ViewBlame
+	return true
+}
+
+// openFileHistory lists the commits that changed path
+func (m *Model) openFileHistory(path string) {
+	revisions, err := m.git.FileHistory(path)
+	if err != nil {
+		m.errorMsg = err.Error()
+		return
+	}
+	if len(revisions) == 0 {
+		m.errorMsg = "No commits touch " + path
+		return
+	}
+	m.history = &fileHistory{path: path, revisions: revisions}
+	m.browser = ViewFileHistory
+	m.currentView = ViewFileHistory
+}
+
+// handleBlameKeys handles blame view actions
+func (m *Model) handleBlameKeys(action string) (tea.Model, tea.Cmd) {
+	b := m.blame
+	switch action {
+	case ActionUp:
+		if b.selected > 0 {
+			b.selected--
+		}
+	case ActionDown:
+		if b.selected < len(b.lines)-1 {
+			b.selected++
+		}
+	case ActionTop:
+		b.selected = 0
+	case ActionBottom:
+		b.selected = len(b.lines) - 1
+	case ActionSelect:
+		m.showBlameMenu()
+	}
+	return m, nil
+}
+
+// showBlameMenu shows the actions for the selected line's commit
+func (m *Model) showBlameMenu() {
+	b := m.blame
+	line := b.lines[b.selected]
+	if strings.Trim(line.Hash, "0") == "" {
+		m.successMsg = "Not committed yet"
+		return
+	}
+
+	items := []menuItem{
+		{"c", "Show commit", func(m *Model) tea.Cmd {
+			m.showDiff(func() (string, error) { return m.git.ShowCommit(line.Hash) })
+			return nil
+		}},
+	}
+	if line.Previous != "" {
+		items = append(items, menuItem{"p", "Blame parent " + line.Previous[:7], func(m *Model) tea.Cmd {
+			m.reblame(line.Previous, line.PreviousPath, line.Line)
+			return nil
+		}})
+	}
+	items = append(items, menuItem{"h", "File history", func(m *Model) tea.Cmd {
+		m.openFileHistory(line.Path)
+		return nil
+	}})
+
+	m.openMenu(fmt.Sprintf("%s %s", line.ShortHash, line.Summary), items)
+}
+
+// reblame blames path at rev, keeping the current blame to return to,
+// and selects the line nearest to line
+func (m *Model) reblame(rev, path string, line int) {
+	current := *m.blame
+	if !m.openBlame(rev, path) {
+		return
+	}
+	m.blame.earlier = append(current.earlier, current)
+	m.blame.earlier[len(m.blame.earlier)-1].earlier = nil
+	m.blame.selected = min(max(line-1, 0), len(m.blame.lines)-1)
+}
+
+// closeBlame returns to the newer revision the blame came from, or
+// leaves the blame view
+func (m *Model) closeBlame() {
+	b := m.blame
+	if n := len(b.earlier); n > 0 {
+		previous := b.earlier[n-1]
+		previous.earlier = b.earlier[:n-1]
+		m.blame = &previous
+		return
+	}
+	m.blame = nil
+	m.closeBrowser()
+}
+
+// closeFileHistory leaves the file history view
+func (m *Model) closeFileHistory() {
+	m.history = nil
+	m.closeBrowser()
+}
+
+// closeBrowser returns to another browser that is still open, or to the
+// active tab
+func (m *Model) closeBrowser() {
+	switch {
+	case m.history != nil:
+		m.browser = ViewFileHistory
+	case m.blame != nil:
+		m.browser = ViewBlame
+	case m.reflog != nil:
+		m.browser = ViewReflog
+	default:
+		m.browser = 0
+	}
+	m.currentView = m.tabs[m.activeTab].View
+	m.resumeBrowser()
+}
+
+// resumeBrowser returns to the blame, file history or reflog view that
+// was open before a menu or diff replaced it
+func (m *Model) resumeBrowser() {
+	if m.browser != 0 && m.composer == nil {
+		m.currentView = m.browser
+	}
+}
+
+// handleFileHistoryKeys handles file history actions
+func (m *Model) handleFileHistoryKeys(action string) (tea.Model, tea.Cmd) {
+	h := m.history
+	switch action {
+	case ActionUp:
+		if h.selected > 0 {
+			h.selected--
+		}
+	case ActionDown:
+		if h.selected < len(h.revisions)-1 {
+			h.selected++
+		}
+	case ActionTop:
+		h.selected = 0
+	case ActionBottom:
+		h.selected = len(h.revisions) - 1
+	case ActionSelect:
+		m.showFileHistoryMenu()
+	}
+	return m, nil
+}
+
+// showFileHistoryMenu shows the actions for the selected revision
+func (m *Model) showFileHistoryMenu() {
+	rev := m.history.revisions[m.history.selected]
+	paths := []string{rev.Path}
+	if rev.OldPath != "" {
+		paths = append(paths, rev.OldPath)
+	}
+
+	items := []menuItem{
+		{"d", "Show changes to " + rev.Path, func(m *Model) tea.Cmd {
+			m.showDiff(func() (string, error) { return m.git.ShowFile(rev.Commit.Hash, paths...) })
+			return nil
+		}},
+		{"c", "Show commit", func(m *Model) tea.Cmd {
+			m.showDiff(func() (string, error) { return m.git.ShowCommit(rev.Commit.Hash) })
+			return nil
+		}},
+	}
+	if rev.Status != "D" {
+		items = append(items, menuItem{"b", "Blame at this revision", func(m *Model) tea.Cmd {
+			m.openBlame(rev.Commit.Hash, rev.Path)
+			return nil
+		}})
+	}
+
+	m.openMenu(fmt.Sprintf("%s %s", rev.Commit.ShortHash, rev.Commit.Message), items)
+}
+
+// showDiff shows the output of show in the diff view
+func (m *Model) showDiff(show func() (string, error)) {
+	diff, err := show()
+	if err != nil {
+		m.errorMsg = err.Error()
+		return
+	}
+	m.diffContent = diff
+	m.currentView = ViewDiff
+}
+
+// ageColor returns the color for a change made at t, from the muted color
+// for the oldest change to the highlight color for the newest
+func (m *Model) ageColor(t, oldest, newest time.Time) lipgloss.Color {
+	colors := m.config.Theme.Colors
+	scale := []string{colors.Muted, colors.Tertiary, colors.Secondary, colors.Accent, colors.Highlight}
+	span := newest.Sub(oldest)
+	if span <= 0 {
+		return lipgloss.Color(scale[len(scale)-1])
+	}
+	i := int(float64(t.Sub(oldest)) / float64(span) * float64(len(scale)-1))
+	return lipgloss.Color(scale[min(max(i, 0), len(scale)-1)])
+}
+
+// visibleRange returns the window of n rows to show around selected
+func (m *Model) visibleRange(selected, n int) (int, int) {
+	rows := max(m.height-12, 5)
+	start := min(max(selected-rows/2, 0), max(n-rows, 0))
+	return start, min(start+rows, n)
+}
+
+// renderBlame renders the blame view
+func (m *Model) renderBlame() string {
+	colors := m.config.Theme.Colors
+	style := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color(colors.Border)).
+		Padding(1)
+
+	titleStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(colors.Primary)).Bold(true)
+	mutedStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(colors.Muted))
+	selectedStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(colors.Highlight)).Bold(true)
+
+	b := m.blame
+	title := b.path
+	if b.rev != "" {
+		title += " @ " + b.rev[:min(len(b.rev), 7)]
+	}
+
+	var oldest, newest time.Time
+	for _, l := range b.lines {
+		if l.Date.IsZero() {
+			continue
+		}
+		if oldest.IsZero() || l.Date.Before(oldest) {
+			oldest = l.Date
+		}
+		if l.Date.After(newest) {
+			newest = l.Date
+		}
+	}
+
+	lines := []string{
+		titleStyle.Render(title),
+		mutedStyle.Render("enter commit actions · esc back"),
+		"",
+	}
+	start, end := m.visibleRange(b.selected, len(b.lines))
+	for i := start; i < end; i++ {
+		l := b.lines[i]
+		date := ""
+		if !l.Date.IsZero() {
+			date = l.Date.Format("2006-01-02")
+		}
+		gutter := fmt.Sprintf("%-7s %-14.14s %-10s", l.ShortHash, l.Author, date)
+		text := fmt.Sprintf("%5d │ %s", i+1, l.Text)
+
+		if i == b.selected {
+			lines = append(lines, selectedStyle.Render("▸ "+gutter+" "+text))
+			continue
+		}
+		ageStyle := lipgloss.NewStyle().Foreground(m.ageColor(l.Date, oldest, newest))
+		lines = append(lines, "  "+ageStyle.Render(gutter)+" "+text)
+	}
+
+	return style.Render(strings.Join(lines, "\n"))
+}
+
+// renderFileHistory renders the file history view
+func (m *Model) renderFileHistory() string {
+	colors := m.config.Theme.Colors
+	style := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color(colors.Border)).
+		Padding(1)
+
+	titleStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(colors.Primary)).Bold(true)
+	mutedStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(colors.Muted))
+	hashStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(colors.Accent))
+	renameStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(colors.Warning))
+	selectedStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(colors.Highlight)).Bold(true)
+
+	h := m.history
+	lines := []string{
+		titleStyle.Render("History of " + h.path),
+		mutedStyle.Render("enter show changes · esc back"),
+		"",
+	}
+	start, end := m.visibleRange(h.selected, len(h.revisions))
+	for i := start; i < end; i++ {
+		rev := h.revisions[i]
+		c := rev.Commit
+		if i == h.selected {
+			lines = append(lines, selectedStyle.Render(fmt.Sprintf("▸ %s %s %s %s",
+				c.ShortHash, c.Date.Format("2006-01-02"), c.Author, c.Message)))
+		} else {
+			lines = append(lines, fmt.Sprintf("  %s %s %s %s", hashStyle.Render(c.ShortHash),
+				mutedStyle.Render(c.Date.Format("2006-01-02")), c.Author, c.Message))
+		}
+		if rev.OldPath != "" {
+			lines = append(lines, "    "+renameStyle.Render(fmt.Sprintf("renamed %s → %s", rev.OldPath, rev.Path)))
+		}
+	}
+
+	return style.Render(strings.Join(lines, "\n"))
+}
@@ -0,0 +1,334 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/gitflow/tui/internal/git"
+	"github.com/gitflow/tui/internal/plugin"
+)
+
+// showBranchMenu shows the actions for a branch
+func (m *Model) showBranchMenu(branch git.Branch) {
+	if branch.IsRemote {
+		m.showRemoteBranchMenu(branch)
+		return
+	}
+
+	name := branch.Name
+	upstream := branch.Upstream
+
+	items := []menuItem{
+		{"c", "Checkout", func(m *Model) tea.Cmd { return m.checkoutBranch(name) }},
+		{"n", "New branch from " + name, func(m *Model) tea.Cmd { return m.createBranch(name) }},
+		{"r", "Rename", func(m *Model) tea.Cmd { return m.renameBranch(name) }},
+		{"d", "Delete", func(m *Model) tea.Cmd { return m.deleteBranch(name) }},
+	}
+	if upstream != "" {
+		items = append(items,
+			menuItem{"D", "Delete remote branch " + upstream, func(m *Model) tea.Cmd { return m.deleteRemoteBranch(upstream) }},
+			menuItem{"U", "Unset upstream " + upstream, func(m *Model) tea.Cmd { return m.unsetUpstream(name) }},
+		)
+	}
+	items = append(items,
+		menuItem{"u", "Set upstream", func(m *Model) tea.Cmd { return m.setUpstream(name, upstream) }},
+		menuItem{"v", "Compare with...", func(m *Model) tea.Cmd { return m.compareBranch(name) }},
+		menuItem{"x", "Delete branches merged into default branch", cmdDeleteMerged},
+	)
+
+	title := "Branch " + name
+	if upstream != "" {
+		title += " → " + upstream
+	}
+	m.openMenu(title, items)
+}
+
+// showRemoteBranchMenu shows the actions for a remote-tracking branch
+func (m *Model) showRemoteBranchMenu(branch git.Branch) {
+	name := branch.Name
+	m.openMenu("Remote branch "+name, []menuItem{
+		{"c", "Checkout as tracking branch", func(m *Model) tea.Cmd { return m.checkoutTracking(name) }},
+		{"n", "New branch from " + name, func(m *Model) tea.Cmd { return m.createBranch(name) }},
+		{"D", "Delete on " + branch.Remote, func(m *Model) tea.Cmd { return m.deleteRemoteBranch(name) }},
+		{"v", "Compare with...", func(m *Model) tea.Cmd { return m.compareBranch(name) }},
+	})
+}
+
+// checkoutTracking checks out a remote-tracking branch as a local
+// branch, switching to the local branch if it already tracks it
+func (m *Model) checkoutTracking(remoteBranch string) tea.Cmd {
+	for _, b := range m.branches {
+		if b.Upstream == remoteBranch {
+			return m.checkoutBranch(b.Name)
+		}
+	}
+
+	return func() tea.Msg {
+		if err := m.git.CheckoutTracking(remoteBranch); err != nil {
+			m.errorMsg = err.Error()
+			return nil
+		}
+		_, local, _ := strings.Cut(remoteBranch, "/")
+		m.emit(plugin.EventBranchSwitched, map[string]interface{}{"branch": local, "created": true})
+		m.successMsg = fmt.Sprintf("Created %s tracking %s", local, remoteBranch)
+		return refreshMsg{}
+	}
+}
+
+// checkoutBranch switches to a local branch
+func (m *Model) checkoutBranch(name string) tea.Cmd {
+	return func() tea.Msg {
+		if err := m.git.Checkout(name, false); err != nil {
+			m.errorMsg = err.Error()
+			return nil
+		}
+		m.emit(plugin.EventBranchSwitched, map[string]interface{}{"branch": name, "created": false})
+		m.successMsg = "Switched to " + name
+		return refreshMsg{}
+	}
+}
+
+// createBranch asks for a name and a start point, defaulting to from
+func (m *Model) createBranch(from string) tea.Cmd {
+	return func() tea.Msg {
+		m.prompt("branch-create", "New branch name...", "", func(name string) {
+			if name == "" {
+				return
+			}
+			m.prompt("branch-start", "Start point (branch, tag or commit)...", from, func(start string) {
+				if err := m.git.CreateBranch(name, strings.TrimSpace(start)); err != nil {
+					m.errorMsg = err.Error()
+					return
+				}
+				m.successMsg = fmt.Sprintf("Created %s at %s", name, start)
+				m.inputCmd = m.loadData()
+			})
+		})
+		return nil
+	}
+}
+
+// cmdBranchFromCommit creates a branch at the commit selected in the graph
+func cmdBranchFromCommit(m *Model) tea.Cmd {
+	if m.selectedCommit >= len(m.commits) {
+		return func() tea.Msg {
+			m.errorMsg = "No commit selected"
+			return nil
+		}
+	}
+	commit := m.commits[m.selectedCommit]
+	return func() tea.Msg {
+		m.prompt("branch-create", "New branch at "+commit.ShortHash+"...", "", func(name string) {
+			if name == "" {
+				return
+			}
+			if err := m.git.CreateBranch(name, commit.Hash); err != nil {
+				m.errorMsg = err.Error()
+				return
+			}
+			m.successMsg = fmt.Sprintf("Created %s at %s", name, commit.ShortHash)
+			m.inputCmd = m.loadData()
+		})
+		return nil
+	}
+}
+
+// renameBranch asks for a new branch name
+func (m *Model) renameBranch(name string) tea.Cmd {
+	return func() tea.Msg {
+		m.prompt("branch-rename", "New name for "+name+"...", name, func(newName string) {
+			if newName == "" || newName == name {
+				return
+			}
+			if err := m.git.RenameBranch(name, newName); err != nil {
+				m.errorMsg = err.Error()
+				return
+			}
+			m.successMsg = fmt.Sprintf("Renamed %s to %s", name, newName)
+			m.inputCmd = m.loadData()
+		})
+		return nil
+	}
+}
+
+// deleteBranch deletes a branch after confirmation, warning about
+// commits not merged into its upstream or the default branch
+func (m *Model) deleteBranch(name string) tea.Cmd {
+	return func() tea.Msg {
+		if name == m.currentBranch {
+			m.errorMsg = "Cannot delete the current branch"
+			return nil
+		}
+
+		into, err := m.git.GetUpstream(name)
+		if err != nil {
+			into, _ = m.git.DefaultBranch(m.config.DefaultBranch)
+		}
+
+		question := "Delete branch " + name + "?"
+		if into != "" {
+			unmerged, err := m.git.UnmergedCommits(name, into)
+			if err != nil {
+				m.errorMsg = err.Error()
+				return nil
+			}
+			if len(unmerged) > 0 {
+				question = fmt.Sprintf("%s has %d commit(s) not merged into %s. Delete anyway?", name, len(unmerged), into)
+			}
+		}
+
+		m.confirm(question, func() {
+			// Unmerged work was confirmed above, so force the delete
+			if err := m.git.DeleteBranch(name, true); err != nil {
+				m.errorMsg = err.Error()
+				return
+			}
+			m.successMsg = "Deleted " + name
+			m.inputCmd = m.loadData()
+		})
+		return nil
+	}
+}
+
+// deleteRemoteBranch deletes upstream ("remote/branch") on its remote
+func (m *Model) deleteRemoteBranch(upstream string) tea.Cmd {
+	return func() tea.Msg {
+		remote, branch, ok := strings.Cut(upstream, "/")
+		if !ok {
+			m.errorMsg = upstream + " is not a remote branch"
+			return nil
+		}
+
+		m.confirm("Delete "+branch+" on "+remote+"?", func() {
+			if err := m.git.DeleteRemoteBranch(remote, branch); err != nil {
+				m.errorMsg = err.Error()
+				return
+			}
+			m.successMsg = "Deleted " + upstream
+			m.inputCmd = m.loadData()
+		})
+		return nil
+	}
+}
+
+// setUpstream asks for the upstream of a branch
+func (m *Model) setUpstream(name, current string) tea.Cmd {
+	return func() tea.Msg {
+		initial := current
+		if initial == "" {
+			initial = m.commandContext().Remote + "/" + name
+		}
+
+		m.prompt("branch-upstream", "Upstream for "+name+"...", initial, func(upstream string) {
+			if upstream == "" {
+				return
+			}
+			if err := m.git.SetUpstream(name, upstream); err != nil {
+				m.errorMsg = err.Error()
+				return
+			}
+			m.successMsg = fmt.Sprintf("%s now tracks %s", name, upstream)
+			m.inputCmd = m.loadData()
+		})
+		return nil
+	}
+}
+
+// unsetUpstream removes the upstream of a branch
+func (m *Model) unsetUpstream(name string) tea.Cmd {
+	return func() tea.Msg {
+		if err := m.git.UnsetUpstream(name); err != nil {
+			m.errorMsg = err.Error()
+			return nil
+		}
+		m.successMsg = "Removed upstream of " + name
+		return refreshMsg{}
+	}
+}
+
+// compareBranch asks for a base branch and shows how name differs from it
+func (m *Model) compareBranch(name string) tea.Cmd {
+	return func() tea.Msg {
+		initial := m.currentBranch
+		if initial == name {
+			initial, _ = m.git.DefaultBranch(m.config.DefaultBranch)
+		}
+
+		m.prompt("branch-compare", "Compare "+name+" with...", initial, func(base string) {
+			if base == "" {
+				return
+			}
+			cmp, err := m.git.CompareBranches(base, name)
+			if err != nil {
+				m.errorMsg = err.Error()
+				return
+			}
+			m.outputTitle = base + "..." + name
+			m.outputContent = formatComparison(cmp)
+			m.currentView = ViewOutput
+		})
+		return nil
+	}
+}
+
+// formatComparison renders a branch comparison for the output panel
+func formatComparison(cmp *git.BranchComparison) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s is %d ahead and %d behind %s\n", cmp.Head, len(cmp.Ahead), len(cmp.Behind), cmp.Base)
+
+	section := func(title string, commits []git.Commit) {
+		if len(commits) == 0 {
+			return
+		}
+		fmt.Fprintf(&b, "\n%s:\n", title)
+		for _, c := range commits {
+			fmt.Fprintf(&b, "  %s %s (%s)\n", c.ShortHash, c.Message, c.Author)
+		}
+	}
+	section("Ahead (only on "+cmp.Head+")", cmp.Ahead)
+	section("Behind (only on "+cmp.Base+")", cmp.Behind)
+
+	if cmp.Diff != "" {
+		b.WriteString("\n" + cmp.Diff)
+	}
+	return b.String()
+}
+
+// cmdDeleteMerged deletes the local branches merged into the default branch
+func cmdDeleteMerged(m *Model) tea.Cmd {
+	return func() tea.Msg {
+		base, err := m.git.DefaultBranch(m.config.DefaultBranch)
+		if err != nil {
+			m.errorMsg = err.Error()
+			return nil
+		}
+
+		merged, err := m.git.MergedBranches(base)
+		if err != nil {
+			m.errorMsg = err.Error()
+			return nil
+		}
+		if len(merged) == 0 {
+			m.successMsg = "No branches merged into " + base
+			return nil
+		}
+
+		question := fmt.Sprintf("Delete %d branch(es) merged into %s: %s?", len(merged), base, strings.Join(merged, ", "))
+		m.confirm(question, func() {
+			var failed []string
+			for _, name := range merged {
+				if err := m.git.DeleteBranch(name, false); err != nil {
+					failed = append(failed, name)
+				}
+			}
+			if len(failed) > 0 {
+				m.errorMsg = "Could not delete " + strings.Join(failed, ", ")
+			} else {
+				m.successMsg = fmt.Sprintf("Deleted %d merged branch(es)", len(merged))
+			}
+			m.inputCmd = m.loadData()
+		})
+		return nil
+	}
+}
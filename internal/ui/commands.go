@@ -2,9 +2,12 @@ package ui
 
 import (
 	"fmt"
+	"io"
 	"strings"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/gitflow/tui/internal/git"
+	"github.com/gitflow/tui/internal/plugin"
 )
 
 // Command represents a UI command
@@ -12,7 +15,7 @@ type Command struct {
 	Name        string
 	Description string
 	Action      func(*Model) tea.Cmd
-	Key         string
+	Key         string // Default binding; see Keymap for the effective one
 }
 
 // AvailableCommands returns all available commands
@@ -24,6 +27,11 @@ func AvailableCommands() []Command {
 			Key:         "c",
 			Action:      cmdCommit,
 		},
+		{
+			Name:        "commit-amend",
+			Description: "Amend the last commit",
+			Action:      cmdCommitAmend,
+		},
 		{
 			Name:        "push",
 			Description: "Push to remote",
@@ -90,92 +98,226 @@ func AvailableCommands() []Command {
 			Key:         "C",
 			Action:      cmdCherryPick,
 		},
+		{
+			Name:        "commit-signed",
+			Description: "Create a signed commit",
+			Key:         "G",
+			Action:      cmdCommitSigned,
+		},
+		{
+			Name:        "tag-signed",
+			Description: "Create signed tag",
+			Key:         "T",
+			Action:      cmdTagSigned,
+		},
+		{
+			Name:        "signing-key",
+			Description: "Configure signing format and key",
+			Key:         "K",
+			Action:      cmdSigningKey,
+		},
+		{
+			Name:        "signing-auto",
+			Description: "Toggle signing commits and tags by default",
+			Action:      cmdSigningAuto,
+		},
+		{
+			Name:        "allowed-signer",
+			Description: "Add SSH allowed signer",
+			Key:         "A",
+			Action:      cmdAllowedSigner,
+		},
+		{
+			Name:        "theme",
+			Description: "Pick a theme",
+			Key:         "ctrl+t",
+			Action:      cmdTheme,
+		},
+		{
+			Name:        "branch-from-commit",
+			Description: "Create branch at selected commit",
+			Key:         "B",
+			Action:      cmdBranchFromCommit,
+		},
+		{
+			Name:        "delete-merged",
+			Description: "Delete branches merged into the default branch",
+			Action:      cmdDeleteMerged,
+		},
+		{
+			Name:        "remote-add",
+			Description: "Add a remote",
+			Action:      cmdRemoteAdd,
+		},
+		{
+			Name:        "remote-test",
+			Description: "Test connectivity to all remotes",
+			Action:      cmdRemoteTest,
+		},
+		{
+			Name:        "stash-files",
+			Description: "Stash selected files",
+			Action:      cmdStashFiles,
+		},
+		{
+			Name:        "stash-hunks",
+			Description: "Stash selected hunks",
+			Action:      cmdStashHunks,
+		},
+		{
+			Name:        "release",
+			Description: "Tag the next semantic version",
+			Action:      cmdRelease,
+		},
+		{
+			Name:        "changelog",
+			Description: "Preview the changelog of a range",
+			Action:      cmdChangelog,
+		},
+		{
+			Name:        "hooks",
+			Description: "Manage git hooks",
+			Action:      cmdHooks,
+		},
+		{
+			Name:        "blame",
+			Description: "Blame a file",
+			Action:      cmdBlame,
+		},
+		{
+			Name:        "file-history",
+			Description: "Show the commits that changed a file",
+			Action:      cmdFileHistory,
+		},
+		{
+			Name:        "reflog",
+			Description: "Browse the reflog to recover commits",
+			Action:      cmdReflog,
+		},
+		{
+			Name:        "dangling-commits",
+			Description: "Find unreachable commits",
+			Action:      cmdDanglingCommits,
+		},
+		{
+			Name:        "worktrees",
+			Description: "Manage and switch between worktrees",
+			Action:      cmdWorktrees,
+		},
+		{
+			Name:        "submodules",
+			Description: "List, update and enter submodules",
+			Action:      cmdSubmodules,
+		},
+		{
+			Name:        "bisect",
+			Description: "Find the commit that introduced a bug",
+			Action:      cmdBisect,
+		},
+		{
+			Name:        "bisect-reset",
+			Description: "End the bisect session",
+			Action:      cmdBisectReset,
+		},
+		{
+			Name:        "search-history",
+			Description: "Search and filter the commit graph",
+			Key:         "/",
+			Action:      cmdSearchHistory,
+		},
+		{
+			Name:        "palette",
+			Description: "Open command palette",
+			Key:         ":",
+			Action:      cmdPalette,
+		},
 	}
 }
 
-// cmdCommit handles commit command
+// cmdCommit opens the commit composer
 func cmdCommit(m *Model) tea.Cmd {
 	return func() tea.Msg {
-		m.inputMode = "commit"
-		m.input.Placeholder = "Enter commit message..."
-		m.input.SetValue("")
-		m.input.Focus()
-		m.currentView = ViewInput
-		m.inputCallback = func(value string) {
-			if value != "" {
-				err := m.git.Commit(value, false)
-				if err != nil {
-					m.errorMsg = err.Error()
-				} else {
-					m.successMsg = "Committed: " + value
-					m.loadData()
-				}
-			}
-		}
+		m.openComposer("", git.CommitOptions{})
 		return nil
 	}
 }
 
-// cmdPush handles push command
-func cmdPush(m *Model) tea.Cmd {
+// cmdCommitSigned opens the commit composer for a signed commit
+func cmdCommitSigned(m *Model) tea.Cmd {
+	return func() tea.Msg {
+		m.openComposer("", git.CommitOptions{Sign: true})
+		return nil
+	}
+}
+
+// cmdCommitAmend opens the commit composer with the previous message
+func cmdCommitAmend(m *Model) tea.Cmd {
 	return func() tea.Msg {
-		// Get current branch
-		branch, err := m.git.GetCurrentBranch()
+		message, err := m.git.LastCommitMessage()
 		if err != nil {
 			m.errorMsg = err.Error()
 			return nil
 		}
+		m.openComposer(message, git.CommitOptions{Amend: true})
+		return nil
+	}
+}
 
-		// Find origin remote
-		var remote string
-		for _, r := range m.remotes {
-			if r.Name == "origin" {
-				remote = r.Name
-				break
-			}
-		}
-		if remote == "" && len(m.remotes) > 0 {
-			remote = m.remotes[0].Name
-		}
+// cmdPush handles push command. Output, including the pre-push hook's,
+// is streamed to the output panel
+func cmdPush(m *Model) tea.Cmd {
+	branch, err := m.git.GetCurrentBranch()
+	if err != nil {
+		m.errorMsg = err.Error()
+		return nil
+	}
+	remote := m.defaultRemote()
 
-		err = m.git.Push(remote, branch, false)
+	return m.stream(fmt.Sprintf("Pushing to %s/%s", remote, branch), func(w io.Writer) error {
+		return m.git.PushWithOutput(remote, branch, false, w)
+	}, func(m *Model, err error) {
+		event := map[string]interface{}{"remote": remote, "branch": branch}
 		if err != nil {
-			m.errorMsg = err.Error()
+			event["error"] = err.Error()
+		}
+		m.emit(plugin.EventPushFinished, event)
+		if err != nil {
+			m.errorMsg = "Push failed: " + err.Error()
 		} else {
 			m.successMsg = fmt.Sprintf("Pushed to %s/%s", remote, branch)
 		}
-		return nil
-	}
+	})
 }
 
-// cmdPull handles pull command
+// cmdPull handles pull command, streaming the output when merge hooks
+// would run
 func cmdPull(m *Model) tea.Cmd {
-	return func() tea.Msg {
-		branch, err := m.git.GetCurrentBranch()
-		if err != nil {
-			m.errorMsg = err.Error()
-			return nil
-		}
-
-		var remote string
-		for _, r := range m.remotes {
-			if r.Name == "origin" {
-				remote = r.Name
-				break
-			}
-		}
-		if remote == "" && len(m.remotes) > 0 {
-			remote = m.remotes[0].Name
-		}
+	branch, err := m.git.GetCurrentBranch()
+	if err != nil {
+		m.errorMsg = err.Error()
+		return nil
+	}
+	remote := m.defaultRemote()
 
-		err = m.git.Pull(remote, branch, false)
+	pulled := func(m *Model, err error) {
 		if err != nil {
 			m.errorMsg = err.Error()
 		} else {
 			m.successMsg = fmt.Sprintf("Pulled from %s/%s", remote, branch)
-			m.loadData()
 		}
-		return nil
+	}
+	if m.git.HasEnabledHook(git.MergeHooks...) {
+		return m.stream(fmt.Sprintf("Pulling from %s/%s", remote, branch), func(w io.Writer) error {
+			return m.git.PullWithOutput(remote, branch, false, w)
+		}, pulled)
+	}
+	return func() tea.Msg {
+		err := m.git.Pull(remote, branch, false)
+		pulled(m, err)
+		if err != nil {
+			return nil
+		}
+		return refreshMsg{}
 	}
 }
 
@@ -199,7 +341,7 @@ func cmdFetch(m *Model) tea.Cmd {
 			} else {
 				m.successMsg = "Fetched all remotes"
 			}
-			m.loadData()
+			return refreshMsg{}
 		}
 		return nil
 	}
@@ -228,12 +370,13 @@ func cmdCheckout(m *Model) tea.Cmd {
 				if err != nil {
 					m.errorMsg = err.Error()
 				} else {
+					m.emit(plugin.EventBranchSwitched, map[string]interface{}{"branch": value, "created": !exists})
 					if exists {
 						m.successMsg = "Switched to " + value
 					} else {
 						m.successMsg = "Created and switched to " + value
 					}
-					m.loadData()
+					m.inputCmd = m.loadData()
 				}
 			}
 		}
@@ -241,41 +384,51 @@ func cmdCheckout(m *Model) tea.Cmd {
 	}
 }
 
-// cmdMerge handles merge command
+// cmdMerge handles merge command, streaming the output when merge hooks
+// would run
 func cmdMerge(m *Model) tea.Cmd {
-	return func() tea.Msg {
-		if m.selectedBranch >= len(m.branches) {
-			m.errorMsg = "No branch selected"
-			return nil
-		}
+	branch, ok := m.selectedBranchInfo()
+	if !ok {
+		m.errorMsg = "No branch selected"
+		return nil
+	}
 
-		branch := m.branches[m.selectedBranch]
-		err := m.git.Merge(branch.Name, false)
+	merged := func(m *Model, err error) {
 		if err != nil {
 			m.errorMsg = err.Error()
 		} else {
 			m.successMsg = "Merged " + branch.Name
-			m.loadData()
 		}
-		return nil
+	}
+	if m.git.HasEnabledHook(git.MergeHooks...) {
+		return m.stream("Merging "+branch.Name, func(w io.Writer) error {
+			return m.git.MergeWithOutput(branch.Name, false, w)
+		}, merged)
+	}
+	return func() tea.Msg {
+		err := m.git.Merge(branch.Name, false)
+		merged(m, err)
+		if err != nil {
+			return nil
+		}
+		return refreshMsg{}
 	}
 }
 
 // cmdRebase handles rebase command
 func cmdRebase(m *Model) tea.Cmd {
 	return func() tea.Msg {
-		if m.selectedBranch >= len(m.branches) {
+		branch, ok := m.selectedBranchInfo()
+		if !ok {
 			m.errorMsg = "No branch selected"
 			return nil
 		}
-
-		branch := m.branches[m.selectedBranch]
 		err := m.git.Rebase(branch.Name, false)
 		if err != nil {
 			m.errorMsg = err.Error()
 		} else {
 			m.successMsg = "Rebased onto " + branch.Name
-			m.loadData()
+			return refreshMsg{}
 		}
 		return nil
 	}
@@ -295,7 +448,7 @@ func cmdStash(m *Model) tea.Cmd {
 				m.errorMsg = err.Error()
 			} else {
 				m.successMsg = "Changes stashed"
-				m.loadData()
+				m.inputCmd = m.loadData()
 			}
 		}
 		return nil
@@ -316,7 +469,7 @@ func cmdStashPop(m *Model) tea.Cmd {
 			m.errorMsg = err.Error()
 		} else {
 			m.successMsg = "Popped stash@{" + fmt.Sprintf("%d", stash.Index) + "}"
-			m.loadData()
+			return refreshMsg{}
 		}
 		return nil
 	}
@@ -337,7 +490,7 @@ func cmdTag(m *Model) tea.Cmd {
 					m.errorMsg = err.Error()
 				} else {
 					m.successMsg = "Created tag " + value
-					m.loadData()
+					m.inputCmd = m.loadData()
 				}
 			}
 		}
@@ -345,6 +498,133 @@ func cmdTag(m *Model) tea.Cmd {
 	}
 }
 
+// cmdTagSigned handles signed tag command
+func cmdTagSigned(m *Model) tea.Cmd {
+	return func() tea.Msg {
+		m.inputMode = "tag-signed"
+		m.input.Placeholder = "Enter tag name and message (name: message)..."
+		m.input.SetValue("")
+		m.input.Focus()
+		m.currentView = ViewInput
+		m.inputCallback = func(value string) {
+			name, message, _ := strings.Cut(value, ":")
+			name = strings.TrimSpace(name)
+			if name == "" {
+				return
+			}
+
+			err := m.git.CreateSignedTag(name, strings.TrimSpace(message), "")
+			if err != nil {
+				m.errorMsg = err.Error()
+			} else {
+				m.successMsg = "Created signed tag " + name
+				m.inputCmd = m.loadData()
+			}
+		}
+		return nil
+	}
+}
+
+// cmdSigningKey handles signing configuration command
+func cmdSigningKey(m *Model) tea.Cmd {
+	return func() tea.Msg {
+		effective, err := m.git.GetSigningConfig()
+		if err != nil {
+			m.errorMsg = err.Error()
+			return nil
+		}
+		local, err := m.git.GetScopedSigningConfig(false)
+		if err != nil {
+			m.errorMsg = err.Error()
+			return nil
+		}
+
+		m.inputMode = "signing-key"
+		m.input.Placeholder = "Enter format and key (openpgp|ssh|x509 KEY)..."
+		m.input.SetValue(strings.TrimSpace(string(effective.Format) + " " + effective.Key))
+		m.input.Focus()
+		m.currentView = ViewInput
+		m.inputCallback = func(value string) {
+			format, key, _ := strings.Cut(strings.TrimSpace(value), " ")
+			if format == "" {
+				return
+			}
+
+			// Only write what was changed, leaving inherited settings alone
+			cfg := *local
+			if f := git.SigningFormat(strings.ToLower(format)); f != effective.Format {
+				cfg.Format = f
+			}
+			if k := strings.TrimSpace(key); k != effective.Key {
+				cfg.Key = k
+			}
+
+			if err := m.git.SetSigningConfig(cfg, false); err != nil {
+				m.errorMsg = err.Error()
+			} else {
+				m.successMsg = fmt.Sprintf("Signing with %s key %s", git.SigningFormat(strings.ToLower(format)), strings.TrimSpace(key))
+			}
+		}
+		return nil
+	}
+}
+
+// cmdSigningAuto toggles signing commits and tags by default in the
+// repository
+func cmdSigningAuto(m *Model) tea.Cmd {
+	return func() tea.Msg {
+		effective, err := m.git.GetSigningConfig()
+		if err != nil {
+			m.errorMsg = err.Error()
+			return nil
+		}
+		local, err := m.git.GetScopedSigningConfig(false)
+		if err != nil {
+			m.errorMsg = err.Error()
+			return nil
+		}
+
+		cfg := *local
+		cfg.SignCommits = !effective.SignCommits
+		cfg.SignTags = cfg.SignCommits
+		if err := m.git.SetSigningConfig(cfg, false); err != nil {
+			m.errorMsg = err.Error()
+		} else if cfg.SignCommits {
+			m.successMsg = "Signing commits and tags by default"
+		} else {
+			m.successMsg = "Not signing commits and tags by default"
+		}
+		return nil
+	}
+}
+
+// cmdAllowedSigner handles adding an SSH allowed signer
+func cmdAllowedSigner(m *Model) tea.Cmd {
+	return func() tea.Msg {
+		m.inputMode = "allowed-signer"
+		m.input.Placeholder = "Enter principal and public key (email ssh-ed25519 AAAA...)..."
+		m.input.SetValue("")
+		m.input.Focus()
+		m.currentView = ViewInput
+		m.inputCallback = func(value string) {
+			principal, key, _ := strings.Cut(strings.TrimSpace(value), " ")
+			if principal == "" || key == "" {
+				m.errorMsg = "Expected: principal keytype key"
+				return
+			}
+
+			signer := git.AllowedSigner{Principals: principal, Key: strings.TrimSpace(key)}
+			if err := m.git.AddAllowedSigner(signer); err != nil {
+				m.errorMsg = err.Error()
+			} else {
+				m.successMsg = "Added allowed signer " + principal
+				m.inputCmd = m.loadData()
+			}
+		}
+		return nil
+	}
+}
+
 // cmdReset handles reset command
 func cmdReset(m *Model) tea.Cmd {
 	return func() tea.Msg {
@@ -373,7 +653,7 @@ func cmdReset(m *Model) tea.Cmd {
 				m.errorMsg = err.Error()
 			} else {
 				m.successMsg = fmt.Sprintf("Reset (%s) to %s", mode, commit.ShortHash)
-				m.loadData()
+				m.inputCmd = m.loadData()
 			}
 		}
 		return nil
@@ -394,15 +674,22 @@ func cmdCherryPick(m *Model) tea.Cmd {
 			m.errorMsg = err.Error()
 		} else {
 			m.successMsg = "Cherry-picked " + commit.ShortHash
-			m.loadData()
+			return refreshMsg{}
 		}
 		return nil
 	}
 }
 
+// commands returns the built-in commands followed by the custom commands
+// from the config and the commands registered by plugins
+func (m *Model) commands() []Command {
+	commands := append(AvailableCommands(), customCommands(m.config.CustomCommands)...)
+	return append(commands, pluginCommands(m.plugins)...)
+}
+
 // ExecuteCommand executes a command by name
 func (m *Model) ExecuteCommand(name string) tea.Cmd {
-	for _, cmd := range AvailableCommands() {
+	for _, cmd := range m.commands() {
 		if cmd.Name == name {
 			return cmd.Action(m)
 		}
@@ -416,8 +703,13 @@ func GetCommandHelp() string {
 	lines = append(lines, "Available Commands:")
 	lines = append(lines, "")
 
+	keys, _ := NewKeymap(nil, AvailableCommands())
 	for _, cmd := range AvailableCommands() {
-		lines = append(lines, fmt.Sprintf("  %s - %s", cmd.Key, cmd.Description))
+		bound := keys.KeysFor(ViewDashboard, cmd.Name)
+		if len(bound) == 0 {
+			continue
+		}
+		lines = append(lines, fmt.Sprintf("  %s - %s", strings.Join(bound, "/"), cmd.Description))
 	}
 
 	return strings.Join(lines, "\n")
@@ -0,0 +1,468 @@
+package ui
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/gitflow/tui/internal/git"
+	"github.com/gitflow/tui/internal/lint"
+	"github.com/gitflow/tui/internal/plugin"
+	"github.com/gitflow/tui/internal/release"
+)
+
+// composer edits a commit message: a subject line and a body in
+// m.textArea
+type composer struct {
+	subject  textinput.Model
+	body     bool // Body has focus
+	opts     git.CommitOptions
+	rules    []lint.Rule
+	problems []lint.Violation
+}
+
+// openComposer opens the commit composer with message prefilled
+func (m *Model) openComposer(message string, opts git.CommitOptions) {
+	subject := textinput.New()
+	subject.Placeholder = "Subject"
+	subject.Prompt = ""
+	subject.CharLimit = 0
+	subject.Width = max(m.width-12, 40)
+
+	summary, body, _ := strings.Cut(message, "\n")
+	subject.SetValue(strings.TrimSpace(summary))
+	subject.Focus()
+
+	m.textArea.Reset()
+	m.textArea.Placeholder = "Body (optional)"
+	m.textArea.SetWidth(max(m.width-8, 40))
+	m.textArea.SetHeight(8)
+	m.textArea.CharLimit = 0
+	m.textArea.SetValue(strings.TrimSpace(body))
+	m.textArea.Blur()
+
+	if m.config.Commit.SignOff {
+		opts.SignOff = true
+	}
+
+	// Rules are loaded on every open so edits to the repository's
+	// commitlint config apply right away
+	rules := lint.FromCommitConfig(m.config.Commit)
+	if cfg, err := lint.Load(m.repoPath, m.config.Commit); err != nil {
+		m.errorMsg = err.Error()
+	} else {
+		rules = cfg.Rules
+	}
+	m.composer = &composer{subject: subject, opts: opts, rules: rules}
+	m.validateComposer()
+	m.currentView = ViewCommit
+}
+
+// resumeComposer returns to the composer after a menu or prompt. While
+// its commit is streaming the composer stays closed, so it cannot be
+// submitted twice
+func (m *Model) resumeComposer() {
+	if m.composer != nil && m.streamDone == nil {
+		m.validateComposer()
+		m.currentView = ViewCommit
+	}
+}
+
+// closeComposer discards the composer
+func (m *Model) closeComposer() {
+	m.composer = nil
+	m.textArea.Blur()
+	m.currentView = m.tabs[m.activeTab].View
+}
+
+// composerMessage joins the subject and body into a commit message
+func (m *Model) composerMessage() string {
+	subject := strings.TrimSpace(m.composer.subject.Value())
+	body := strings.TrimSpace(m.textArea.Value())
+	if body == "" {
+		return subject
+	}
+	return subject + "\n\n" + body
+}
+
+// handleComposerKeys handles keys while the composer is open; all other
+// keys go to the focused field
+func (m *Model) handleComposerKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	c := m.composer
+	switch msg.String() {
+	case "esc":
+		m.closeComposer()
+		return m, nil
+	case "tab", "shift+tab":
+		m.focusComposer(!c.body)
+		return m, nil
+	case "enter":
+		if !c.body {
+			m.focusComposer(true)
+			return m, nil
+		}
+	case "ctrl+s":
+		return m, m.submitComposer()
+	case "ctrl+t":
+		m.showTypeMenu()
+		return m, nil
+	case "ctrl+o":
+		m.showScopeMenu()
+		return m, nil
+	case "ctrl+r":
+		m.showTrailerMenu()
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	if c.body {
+		m.textArea, cmd = m.textArea.Update(msg)
+	} else {
+		c.subject, cmd = c.subject.Update(msg)
+	}
+	m.validateComposer()
+	return m, cmd
+}
+
+// focusComposer focuses the body or the subject
+func (m *Model) focusComposer(body bool) {
+	m.composer.body = body
+	if body {
+		m.composer.subject.Blur()
+		m.textArea.Focus()
+	} else {
+		m.textArea.Blur()
+		m.composer.subject.Focus()
+	}
+}
+
+// submitComposer commits the composed message if it passes the lint
+// rules. When commit hooks are installed their output streams to the
+// output panel; after a failed commit esc returns to the composer.
+func (m *Model) submitComposer() tea.Cmd {
+	m.validateComposer()
+	if lint.HasErrors(m.composer.problems) {
+		m.errorMsg = "Commit message breaks lint rules"
+		return nil
+	}
+
+	opts := m.composer.opts
+	message := m.composerMessage()
+	if m.git.HasEnabledHook(git.CommitHooks...) {
+		return m.stream("Committing", func(w io.Writer) error {
+			return m.git.CommitWithOutput(message, opts, w)
+		}, func(m *Model, err error) {
+			if err != nil {
+				m.errorMsg = "Commit failed (esc to edit the message): " + err.Error()
+				return
+			}
+			m.committed(message, opts)
+		})
+	}
+
+	if err := m.git.CommitWithOptions(message, opts); err != nil {
+		m.errorMsg = err.Error()
+		return nil
+	}
+	m.committed(message, opts)
+	m.currentView = m.tabs[m.activeTab].View
+	return m.loadData()
+}
+
+// committed discards the composer after a successful commit
+func (m *Model) committed(message string, opts git.CommitOptions) {
+	m.composer = nil
+	m.textArea.Blur()
+
+	subject, _, _ := strings.Cut(message, "\n")
+	m.errorMsg = ""
+	m.successMsg = "Committed: " + subject
+	event := map[string]interface{}{"message": message}
+	if opts.Sign {
+		m.successMsg = "Committed (signed): " + subject
+		event["signed"] = true
+	}
+	if opts.Amend {
+		event["amend"] = true
+	}
+	m.emit(plugin.EventCommitCreated, event)
+}
+
+// validateComposer lints the message as it would be committed
+func (m *Model) validateComposer() {
+	m.composer.problems = lint.Lint(m.composerMessage(), m.composer.rules)
+}
+
+// composerEnum returns the values the named enum rule allows, or fallback
+// when there is no such rule
+func (m *Model) composerEnum(name string, fallback []string) []string {
+	for _, r := range m.composer.rules {
+		if r.Name == name && r.Level != lint.LevelOff && !r.Never {
+			if values := r.Values(); len(values) > 0 {
+				return values
+			}
+		}
+	}
+	return fallback
+}
+
+// setConventional rewrites the subject header with change applied to its
+// parsed parts; a plain subject becomes the description
+func (m *Model) setConventional(change func(c *release.Conventional)) {
+	subject := strings.TrimSpace(m.composer.subject.Value())
+	conv, ok := release.ParseConventional(subject, "")
+	if !ok {
+		conv = release.Conventional{Description: subject}
+	}
+	change(&conv)
+
+	header := conv.Type
+	if conv.Scope != "" {
+		header += "(" + conv.Scope + ")"
+	}
+	if conv.Breaking {
+		header += "!"
+	}
+	if header != "" {
+		header += ": "
+	}
+	m.composer.subject.SetValue(header + conv.Description)
+	m.composer.subject.CursorEnd()
+}
+
+// showTypeMenu offers the configured commit types
+func (m *Model) showTypeMenu() {
+	var items []menuItem
+	used := map[string]bool{"!": true}
+	for _, t := range m.composerEnum("type-enum", m.config.Commit.Types) {
+		typ := t
+		items = append(items, menuItem{mnemonic(typ, used), typ, func(m *Model) tea.Cmd {
+			m.setConventional(func(c *release.Conventional) { c.Type = typ })
+			m.resumeComposer()
+			return nil
+		}})
+	}
+	items = append(items, menuItem{"!", "Toggle breaking change", func(m *Model) tea.Cmd {
+		m.setConventional(func(c *release.Conventional) { c.Breaking = !c.Breaking })
+		m.resumeComposer()
+		return nil
+	}})
+	m.openMenu("Commit type", items)
+}
+
+// showScopeMenu offers the configured scopes and a free-form scope
+func (m *Model) showScopeMenu() {
+	setScope := func(scope string) {
+		m.setConventional(func(c *release.Conventional) {
+			c.Scope = scope
+			if c.Type == "" && scope != "" {
+				c.Type = "feat"
+			}
+		})
+		m.resumeComposer()
+	}
+
+	var items []menuItem
+	used := map[string]bool{"+": true, "-": true}
+	for _, s := range m.composerEnum("scope-enum", m.config.Commit.Scopes) {
+		scope := s
+		items = append(items, menuItem{mnemonic(scope, used), scope, func(m *Model) tea.Cmd {
+			setScope(scope)
+			return nil
+		}})
+	}
+	items = append(items,
+		menuItem{"+", "Other scope...", func(m *Model) tea.Cmd {
+			m.prompt("commit-scope", "Scope...", "", func(scope string) { setScope(strings.TrimSpace(scope)) })
+			return nil
+		}},
+		menuItem{"-", "No scope", func(m *Model) tea.Cmd {
+			setScope("")
+			return nil
+		}},
+	)
+	m.openMenu("Commit scope", items)
+}
+
+// showTrailerMenu offers trailers to append to the body
+func (m *Model) showTrailerMenu() {
+	m.openMenu("Add trailer", []menuItem{
+		{"c", "Co-authored-by...", func(m *Model) tea.Cmd {
+			m.showCoAuthorMenu()
+			return nil
+		}},
+		{"s", "Signed-off-by", func(m *Model) tea.Cmd {
+			ident, err := m.git.Identity()
+			if err != nil {
+				m.errorMsg = err.Error()
+			} else {
+				m.addTrailer("Signed-off-by", ident)
+			}
+			m.resumeComposer()
+			return nil
+		}},
+		{"r", "Refs...", func(m *Model) tea.Cmd {
+			m.prompt("commit-refs", "Issue or PR, e.g. #42...", "", func(ref string) {
+				if ref = strings.TrimSpace(ref); ref != "" {
+					m.addTrailer("Refs", ref)
+				}
+				m.resumeComposer()
+			})
+			return nil
+		}},
+	})
+}
+
+// showCoAuthorMenu offers recent authors as co-authors
+func (m *Model) showCoAuthorMenu() {
+	self, _ := m.git.Identity()
+	addCoAuthor := func(ident string) {
+		if ident = strings.TrimSpace(ident); ident != "" {
+			m.addTrailer("Co-authored-by", ident)
+		}
+		m.resumeComposer()
+	}
+
+	var items []menuItem
+	seen := map[string]bool{self: true}
+	for _, c := range m.commits {
+		ident := fmt.Sprintf("%s <%s>", c.Author, c.Email)
+		if seen[ident] || len(items) == 9 {
+			continue
+		}
+		seen[ident] = true
+		items = append(items, menuItem{fmt.Sprint(len(items) + 1), ident, func(m *Model) tea.Cmd {
+			addCoAuthor(ident)
+			return nil
+		}})
+	}
+	items = append(items, menuItem{"o", "Other...", func(m *Model) tea.Cmd {
+		m.prompt("commit-coauthor", "Name <email>...", "", addCoAuthor)
+		return nil
+	}})
+	m.openMenu("Co-authored-by", items)
+}
+
+// addTrailer appends "key: value" to the trailer block of the body
+func (m *Model) addTrailer(key, value string) {
+	body := strings.TrimRight(m.textArea.Value(), "\n ")
+	trailer := key + ": " + value
+
+	paragraphs := strings.Split(body, "\n\n")
+	switch {
+	case body == "":
+		body = trailer
+	case isTrailerBlock(paragraphs[len(paragraphs)-1]):
+		body += "\n" + trailer
+	default:
+		body += "\n\n" + trailer
+	}
+	m.textArea.SetValue(body)
+}
+
+// isTrailerBlock reports whether every line of a paragraph is a trailer
+func isTrailerBlock(paragraph string) bool {
+	for _, line := range strings.Split(paragraph, "\n") {
+		key, _, ok := strings.Cut(line, ": ")
+		if !ok || key == "" || strings.Contains(key, " ") {
+			return false
+		}
+	}
+	return true
+}
+
+// mnemonic picks an unused menu key from the letters of label, falling
+// back to digits
+func mnemonic(label string, used map[string]bool) string {
+	for _, r := range strings.ToLower(label) {
+		if k := string(r); r >= 'a' && r <= 'z' && !used[k] {
+			used[k] = true
+			return k
+		}
+	}
+	for d := 1; d <= 9; d++ {
+		if k := fmt.Sprint(d); !used[k] {
+			used[k] = true
+			return k
+		}
+	}
+	return ""
+}
+
+// renderComposer renders the commit composer
+func (m *Model) renderComposer() string {
+	colors := m.config.Theme.Colors
+	style := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color(colors.Accent)).
+		Padding(1)
+
+	titleStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(colors.Primary)).Bold(true)
+	mutedStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(colors.Muted))
+	okStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(colors.Success))
+	errStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(colors.Error))
+
+	c := m.composer
+	title := "Commit"
+	if c.opts.Amend {
+		title = "Amend commit"
+	}
+	var flags []string
+	if c.opts.Sign {
+		flags = append(flags, "signed")
+	}
+	if c.opts.SignOff {
+		flags = append(flags, "signed off")
+	}
+	if len(flags) > 0 {
+		title += " (" + strings.Join(flags, ", ") + ")"
+	}
+
+	length := utf8.RuneCountInString(c.subject.Value())
+	limit := m.config.Commit.SubjectLimit
+	counter := fmt.Sprint(length)
+	if limit > 0 {
+		counter = fmt.Sprintf("%d/%d", length, limit)
+		if length > limit {
+			counter = errStyle.Render(counter)
+		}
+	}
+
+	lines := []string{
+		titleStyle.Render(title),
+		"",
+		mutedStyle.Render("Subject ") + counter,
+		c.subject.View(),
+	}
+
+	// The ruler fills up to the subject limit and turns red past it
+	if limit > 0 {
+		filled := min(length, limit)
+		ruler := okStyle.Render(strings.Repeat("━", filled)) + mutedStyle.Render(strings.Repeat("─", limit-filled))
+		if length > limit {
+			ruler += errStyle.Render(strings.Repeat("━", min(length-limit, max(m.width-limit-12, 0))))
+		}
+		lines = append(lines, ruler)
+	}
+
+	lines = append(lines, "", mutedStyle.Render("Body"), m.textArea.View(), "")
+	warnStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(colors.Warning))
+	for _, p := range c.problems {
+		if p.Level == lint.LevelWarning {
+			lines = append(lines, warnStyle.Render(p.String()))
+		} else {
+			lines = append(lines, errStyle.Render(p.String()))
+		}
+	}
+	if !lint.HasErrors(c.problems) {
+		lines = append(lines, okStyle.Render("✓ ready to commit"))
+	}
+	lines = append(lines, "", mutedStyle.Render(
+		"tab switch field · ctrl+s commit · ctrl+t type · ctrl+o scope · ctrl+r trailer · esc cancel"))
+
+	return style.Render(strings.Join(lines, "\n"))
+}
@@ -0,0 +1,92 @@
+package ui
+
+import (
+	"testing"
+
+	"github.com/charmbracelet/bubbles/textarea"
+	"github.com/charmbracelet/bubbles/textinput"
+	"github.com/gitflow/tui/internal/release"
+)
+
+func TestIsTrailerBlock(t *testing.T) {
+	tests := []struct {
+		paragraph string
+		want      bool
+	}{
+		{"Signed-off-by: Ann <ann@example.com>", true},
+		{"Refs: #12\nCo-authored-by: Bob <bob@example.com>", true},
+		{"BREAKING-CHANGE: drops Go 1.20", true},
+		{"Fixes the crash: it was a nil map", false},
+		{"Refs: #12\nplain text", false},
+		{": no key", false},
+		{"", false},
+	}
+	for _, tt := range tests {
+		if got := isTrailerBlock(tt.paragraph); got != tt.want {
+			t.Errorf("isTrailerBlock(%q) = %v, want %v", tt.paragraph, got, tt.want)
+		}
+	}
+}
+
+func TestAddTrailer(t *testing.T) {
+	tests := []struct {
+		body string
+		want string
+	}{
+		{"", "Refs: #7"},
+		{"Explain the change.", "Explain the change.\n\nRefs: #7"},
+		{"Explain the change.\n\n", "Explain the change.\n\nRefs: #7"},
+		{"Explain.\n\nSigned-off-by: Ann <ann@example.com>", "Explain.\n\nSigned-off-by: Ann <ann@example.com>\nRefs: #7"},
+		{"Signed-off-by: Ann <ann@example.com>", "Signed-off-by: Ann <ann@example.com>\nRefs: #7"},
+		{"Why it broke: a nil map", "Why it broke: a nil map\n\nRefs: #7"},
+	}
+	for _, tt := range tests {
+		m := &Model{textArea: textarea.New()}
+		m.textArea.CharLimit = 0
+		m.textArea.SetValue(tt.body)
+		m.addTrailer("Refs", "#7")
+		if got := m.textArea.Value(); got != tt.want {
+			t.Errorf("addTrailer() on %q = %q, want %q", tt.body, got, tt.want)
+		}
+	}
+}
+
+func TestSetConventional(t *testing.T) {
+	tests := []struct {
+		subject string
+		change  func(c *release.Conventional)
+		want    string
+	}{
+		{"add login", func(c *release.Conventional) { c.Type = "feat" }, "feat: add login"},
+		{"feat: add login", func(c *release.Conventional) { c.Type = "fix" }, "fix: add login"},
+		{"feat: add login", func(c *release.Conventional) { c.Scope = "auth" }, "feat(auth): add login"},
+		{"feat(auth): add login", func(c *release.Conventional) { c.Breaking = !c.Breaking }, "feat(auth)!: add login"},
+		{"feat(auth)!: add login", func(c *release.Conventional) { c.Breaking = !c.Breaking }, "feat(auth): add login"},
+		{"feat(auth): add login", func(c *release.Conventional) { c.Scope = "" }, "feat: add login"},
+		{"", func(c *release.Conventional) { c.Type = "docs" }, "docs: "},
+	}
+	for _, tt := range tests {
+		subject := textinput.New()
+		subject.SetValue(tt.subject)
+		m := &Model{composer: &composer{subject: subject}}
+		m.setConventional(tt.change)
+		if got := m.composer.subject.Value(); got != tt.want {
+			t.Errorf("setConventional() on %q = %q, want %q", tt.subject, got, tt.want)
+		}
+	}
+}
+
+func TestResumeComposerWhileStreaming(t *testing.T) {
+	m := &Model{composer: &composer{subject: textinput.New()}, textArea: textarea.New(), currentView: ViewOutput}
+	m.streamDone = func(*Model, error) {}
+	m.resumeComposer()
+	if m.currentView != ViewOutput {
+		t.Errorf("currentView = %v while the commit streams, want the output panel", m.currentView)
+	}
+
+	m.streamDone = nil
+	m.resumeComposer()
+	if m.currentView != ViewCommit {
+		t.Errorf("currentView = %v after the commit failed, want the composer", m.currentView)
+	}
+}
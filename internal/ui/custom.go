@@ -0,0 +1,191 @@
+package ui
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+	"text/template"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/gitflow/tui/internal/config"
+	"github.com/gitflow/tui/internal/git"
+)
+
+// commandContext is the data custom command templates are rendered with
+type commandContext struct {
+	Commit        git.Commit
+	Branch        string // Selected branch in the Branches tab
+	CurrentBranch string
+	File          string // Selected file in the Status tab
+	Remote        string
+	RepoPath      string
+	Input         map[string]string // Prompt answers by name
+}
+
+// templateFuncs are available to custom command templates
+var templateFuncs = template.FuncMap{
+	"q": shellQuote,
+}
+
+// shellQuote quotes s as a single shell word
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// customCommands converts configured custom commands to UI commands
+func customCommands(custom []config.CustomCommand) []Command {
+	var commands []Command
+	for _, cc := range custom {
+		cc := cc
+		description := cc.Description
+		if description == "" {
+			description = strings.Join(cc.Steps, " && ")
+		}
+		commands = append(commands, Command{
+			Name:        cc.Name,
+			Description: description,
+			Key:         cc.Key,
+			Action: func(m *Model) tea.Cmd {
+				return cmdCustom(m, cc)
+			},
+		})
+	}
+	return commands
+}
+
+// cmdCustom runs a custom command: it asks for each prompt in turn, then
+// for confirmation, then runs the steps
+func cmdCustom(m *Model, cc config.CustomCommand) tea.Cmd {
+	return m.promptCustom(cc, m.commandContext(), 0)
+}
+
+// promptCustom asks for prompt i, continuing with the next one on enter
+func (m *Model) promptCustom(cc config.CustomCommand, ctx commandContext, i int) tea.Cmd {
+	if i >= len(cc.Prompts) {
+		return m.confirmCustom(cc, ctx)
+	}
+
+	p := cc.Prompts[i]
+	label := p.Label
+	if label == "" {
+		label = p.Name
+	}
+
+	m.prompt("custom", label+"...", p.Default, func(value string) {
+		ctx.Input[p.Name] = value
+		m.inputCmd = m.promptCustom(cc, ctx, i+1)
+	})
+	return nil
+}
+
+// confirmCustom asks the configured confirmation question, if any
+func (m *Model) confirmCustom(cc config.CustomCommand, ctx commandContext) tea.Cmd {
+	if cc.Confirm == "" {
+		return m.runCustom(cc, ctx)
+	}
+
+	question, err := renderStep(cc.Confirm, ctx)
+	if err != nil {
+		m.errorMsg = err.Error()
+		return nil
+	}
+
+	m.confirm(question, func() {
+		m.inputCmd = m.runCustom(cc, ctx)
+	})
+	return nil
+}
+
+// runCustom renders the steps and runs them in order in the background,
+// stopping at the first failure. Output streams to the output panel,
+// which stays open for "panel" output or when a step fails.
+func (m *Model) runCustom(cc config.CustomCommand, ctx commandContext) tea.Cmd {
+	var commands []string
+	for _, step := range cc.Steps {
+		command, err := renderStep(step, ctx)
+		if err != nil {
+			m.errorMsg = err.Error()
+			return nil
+		}
+		commands = append(commands, command)
+	}
+
+	var output strings.Builder
+	return m.stream(cc.Name, func(w io.Writer) error {
+		w = io.MultiWriter(w, &output)
+		for _, command := range commands {
+			fmt.Fprintf(w, "$ %s\n", command)
+			if err := m.git.ShellTo(w, command); err != nil {
+				return fmt.Errorf("%s: %w", command, err)
+			}
+		}
+		return nil
+	}, func(m *Model, err error) {
+		if err != nil {
+			m.errorMsg = err.Error()
+			return
+		}
+		if cc.Output != "panel" {
+			m.currentView = m.tabs[m.activeTab].View
+		}
+		if cc.Output == "status" {
+			m.successMsg = lastLine(output.String())
+		} else {
+			m.successMsg = cc.Name + " finished"
+		}
+	})
+}
+
+// renderStep renders a custom command template
+func renderStep(step string, ctx commandContext) (string, error) {
+	tmpl, err := template.New("step").Funcs(templateFuncs).Option("missingkey=zero").Parse(step)
+	if err != nil {
+		return "", err
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, ctx); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// lastLine returns the last non-empty line of s
+func lastLine(s string) string {
+	lines := strings.Split(strings.TrimSpace(s), "\n")
+	return lines[len(lines)-1]
+}
+
+// commandContext captures the current selection for custom commands
+func (m *Model) commandContext() commandContext {
+	ctx := commandContext{
+		CurrentBranch: m.currentBranch,
+		RepoPath:      m.repoPath,
+		Input:         make(map[string]string),
+	}
+
+	if m.selectedCommit < len(m.commits) {
+		ctx.Commit = m.commits[m.selectedCommit]
+	}
+	if branch, ok := m.selectedBranchInfo(); ok {
+		ctx.Branch = branch.Name
+	}
+	if items := m.fileList.Items(); m.selectedFile < len(items) {
+		if f, ok := items[m.selectedFile].(fileItem); ok {
+			ctx.File = f.path
+		}
+	}
+
+	for _, r := range m.remotes {
+		if r.Name == "origin" {
+			ctx.Remote = r.Name
+			break
+		}
+	}
+	if ctx.Remote == "" && len(m.remotes) > 0 {
+		ctx.Remote = m.remotes[0].Name
+	}
+
+	return ctx
+}
@@ -0,0 +1,231 @@
+package ui
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/gitflow/tui/internal/config"
+	"github.com/gitflow/tui/internal/git"
+)
+
+// streamOutputMsg carries output from a command running in the background
+type streamOutputMsg struct {
+	text string
+	ch   <-chan tea.Msg
+}
+
+// streamDoneMsg reports that a streamed command finished
+type streamDoneMsg struct {
+	err error
+}
+
+// streamWriter forwards writes to the UI as streamOutputMsg
+type streamWriter chan tea.Msg
+
+func (w streamWriter) Write(p []byte) (int, error) {
+	w <- streamOutputMsg{text: string(p), ch: w}
+	return len(p), nil
+}
+
+// stream runs fn in the background and shows its output in the output
+// panel as it arrives. done runs when fn returns.
+func (m *Model) stream(title string, fn func(w io.Writer) error, done func(m *Model, err error)) tea.Cmd {
+	ch := make(chan tea.Msg)
+	m.outputTitle = title
+	m.outputContent = ""
+	m.currentView = ViewOutput
+	m.streamDone = done
+
+	go func() {
+		err := fn(streamWriter(ch))
+		ch <- streamDoneMsg{err: err}
+		close(ch)
+	}()
+	return waitStream(ch)
+}
+
+// waitStream waits for the next message of a stream
+func waitStream(ch <-chan tea.Msg) tea.Cmd {
+	return func() tea.Msg {
+		msg, ok := <-ch
+		if !ok {
+			return nil
+		}
+		return msg
+	}
+}
+
+// handleStream appends streamed output or finishes the stream
+func (m *Model) handleStream(msg tea.Msg) tea.Cmd {
+	switch msg := msg.(type) {
+	case streamOutputMsg:
+		m.outputContent += msg.text
+		return waitStream(msg.ch)
+	case streamDoneMsg:
+		done := m.streamDone
+		m.streamDone = nil
+		if done != nil {
+			done(m, msg.err)
+		}
+		return m.loadData()
+	}
+	return nil
+}
+
+// cmdHooks shows the installed hooks
+func cmdHooks(m *Model) tea.Cmd {
+	return func() tea.Msg {
+		m.showHooksMenu()
+		return nil
+	}
+}
+
+// showHooksMenu lists the hooks and ways to add them
+func (m *Model) showHooksMenu() {
+	hooks, err := m.git.GetHooks()
+	if err != nil {
+		m.errorMsg = err.Error()
+		return
+	}
+	dir, _ := m.git.HooksDir()
+
+	var items []menuItem
+	for i, h := range hooks {
+		hook := h
+		key := ""
+		if i < 9 {
+			key = fmt.Sprint(i + 1)
+		}
+		items = append(items, menuItem{key, hookLabel(hook), func(m *Model) tea.Cmd {
+			m.showHookMenu(hook)
+			return nil
+		}})
+	}
+	items = append(items, menuItem{"n", "New hook...", cmdNewHook})
+
+	hookCfg, err := config.LoadHooks(m.repoPath)
+	if err != nil {
+		m.errorMsg = err.Error()
+	} else if hookCfg != nil {
+		items = append(items, menuItem{"i", "Install hooks from " + hookCfg.Path, func(m *Model) tea.Cmd {
+			return m.installHooks(hookCfg)
+		}})
+	}
+
+	m.openMenu("Hooks in "+dir, items)
+}
+
+// hookLabel describes a hook and its state
+func hookLabel(h git.Hook) string {
+	var state []string
+	switch {
+	case !h.Enabled:
+		state = append(state, "disabled")
+	case !h.Executable:
+		state = append(state, "not executable")
+	default:
+		state = append(state, "enabled")
+	}
+	if h.Managed {
+		state = append(state, "managed")
+	}
+	return fmt.Sprintf("%s (%s)", h.Name, strings.Join(state, ", "))
+}
+
+// showHookMenu shows the actions for a hook
+func (m *Model) showHookMenu(h git.Hook) {
+	items := []menuItem{
+		{"o", "Open in editor", func(m *Model) tea.Cmd { return m.editFile(h.Path) }},
+	}
+	if h.Enabled && h.Executable {
+		items = append(items,
+			menuItem{"d", "Disable", func(m *Model) tea.Cmd { return m.setHookEnabled(h.Name, false) }},
+			menuItem{"r", "Run now", func(m *Model) tea.Cmd { return m.runHook(h.Name) }},
+		)
+	} else {
+		items = append(items, menuItem{"e", "Enable", func(m *Model) tea.Cmd { return m.setHookEnabled(h.Name, true) }})
+	}
+	m.openMenu(hookLabel(h), items)
+}
+
+// setHookEnabled enables or disables a hook
+func (m *Model) setHookEnabled(name string, enabled bool) tea.Cmd {
+	return func() tea.Msg {
+		if err := m.git.SetHookEnabled(name, enabled); err != nil {
+			m.errorMsg = err.Error()
+			return nil
+		}
+		if enabled {
+			m.successMsg = "Enabled " + name
+		} else {
+			m.successMsg = "Disabled " + name
+		}
+		return nil
+	}
+}
+
+// runHook runs a hook with its output streamed to the output panel
+func (m *Model) runHook(name string) tea.Cmd {
+	return m.stream("Hook "+name, func(w io.Writer) error {
+		return m.git.RunHook(name, w)
+	}, func(m *Model, err error) {
+		if err != nil {
+			m.errorMsg = fmt.Sprintf("%s failed: %s", name, err)
+		} else {
+			m.successMsg = name + " passed"
+		}
+	})
+}
+
+// cmdNewHook asks for a hook name and creates an empty executable hook
+func cmdNewHook(m *Model) tea.Cmd {
+	return func() tea.Msg {
+		m.prompt("hook-new", "Hook name, e.g. pre-commit...", "", func(name string) {
+			path, err := m.git.CreateHook(strings.TrimSpace(name))
+			if err != nil {
+				m.errorMsg = err.Error()
+				return
+			}
+			m.successMsg = "Created " + path
+		})
+		return nil
+	}
+}
+
+// installHooks installs the project's declarative hooks
+func (m *Model) installHooks(cfg *config.HookConfig) tea.Cmd {
+	return func() tea.Msg {
+		m.confirm(fmt.Sprintf("Install %d hook(s) from %s?", len(cfg.Hooks), cfg.Path), func() {
+			names, err := m.git.InstallHooks(cfg.Hooks, cfg.Path)
+			if err != nil {
+				m.errorMsg = err.Error()
+				return
+			}
+			m.successMsg = "Installed " + strings.Join(names, ", ")
+		})
+		return nil
+	}
+}
+
+// editFile opens path in the configured editor, suspending the UI
+func (m *Model) editFile(path string) tea.Cmd {
+	editor := m.config.Editor
+	if editor == "" {
+		editor = os.Getenv("VISUAL")
+	}
+	if editor == "" {
+		editor = "vi"
+	}
+	args := append(strings.Fields(editor), path)
+	cmd := exec.Command(args[0], args[1:]...)
+	return tea.ExecProcess(cmd, func(err error) tea.Msg {
+		if err != nil {
+			return errMsg{err: err}
+		}
+		return nil
+	})
+}
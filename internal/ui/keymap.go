@@ -0,0 +1,428 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+)
+
+// KeymapGlobal is the scope of bindings active in every view
+const KeymapGlobal = "global"
+
+// Navigation and UI actions; git commands use their Command.Name
+const (
+	ActionUp          = "up"
+	ActionDown        = "down"
+	ActionLeft        = "left"
+	ActionRight       = "right"
+	ActionHelp        = "help"
+	ActionQuit        = "quit"
+	ActionNextTab     = "next-tab"
+	ActionPrevTab     = "prev-tab"
+	ActionSelect      = "select"
+	ActionBack        = "back"
+	ActionRefresh     = "refresh"
+	ActionToggleStage = "toggle-stage"
+	ActionTop         = "top"
+	ActionBottom      = "bottom"
+	ActionBisectGood  = "bisect-good"
+	ActionBisectBad   = "bisect-bad"
+	ActionBisectSkip  = "bisect-skip"
+)
+
+// Binding binds a key sequence to an action
+type Binding struct {
+	Action string
+	Keys   []string // Chord, e.g. ["g", "g"]
+	Help   string
+}
+
+// Sequence returns the chord as written in config ("g g")
+func (b Binding) Sequence() string {
+	return strings.Join(b.Keys, " ")
+}
+
+// Keymap holds the effective per-view key bindings
+type Keymap struct {
+	scopes  map[string][]Binding
+	actions map[string]string // Action -> help
+	pending []string
+}
+
+// keymapScopes maps views to their keymap scope names
+var keymapScopes = map[ViewState]string{
+	ViewDashboard:   "dashboard",
+	ViewGraph:       "graph",
+	ViewBranches:    "branches",
+	ViewStatus:      "status",
+	ViewStash:       "stash",
+	ViewRemote:      "remotes",
+	ViewTags:        "tags",
+	ViewDiff:        "diff",
+	ViewHelp:        "help",
+	ViewThemes:      "themes",
+	ViewOutput:      "output",
+	ViewMenu:        "menu",
+	ViewPicker:      "picker",
+	ViewBlame:       "blame",
+	ViewFileHistory: "file-history",
+	ViewReflog:      "reflog",
+}
+
+// defaultBindings returns the built-in bindings per scope, with the
+// default keys of commands bound globally
+func defaultBindings(commands []Command) map[string][]Binding {
+	global := []Binding{
+		{ActionUp, []string{"up"}, "up"},
+		{ActionUp, []string{"k"}, "up"},
+		{ActionDown, []string{"down"}, "down"},
+		{ActionDown, []string{"j"}, "down"},
+		{ActionLeft, []string{"left"}, "left"},
+		{ActionLeft, []string{"h"}, "left"},
+		{ActionRight, []string{"right"}, "right"},
+		{ActionRight, []string{"l"}, "right"},
+		{ActionHelp, []string{"?"}, "help"},
+		{ActionQuit, []string{"q"}, "quit"},
+		{ActionQuit, []string{"ctrl+c"}, "quit"},
+		{ActionNextTab, []string{"tab"}, "next tab"},
+		{ActionPrevTab, []string{"shift+tab"}, "prev tab"},
+		{ActionSelect, []string{"enter"}, "select"},
+		{ActionBack, []string{"esc"}, "back"},
+		{ActionRefresh, []string{"r"}, "refresh"},
+	}
+
+	// Git commands keep their historical single-key shortcuts
+	for _, cmd := range commands {
+		if cmd.Key != "" {
+			global = append(global, Binding{cmd.Name, []string{cmd.Key}, cmd.Description})
+		}
+	}
+
+	global = append(global, Binding{"palette", []string{"ctrl+p"}, "Open command palette"})
+
+	return map[string][]Binding{
+		KeymapGlobal: global,
+		"graph": {
+			{ActionTop, []string{"g", "g"}, "first commit"},
+			{ActionBottom, []string{"g", "e"}, "last commit"},
+			{ActionBisectGood, []string{"o"}, "bisect: mark good"},
+			{ActionBisectBad, []string{"x"}, "bisect: mark bad"},
+			{ActionBisectSkip, []string{"s"}, "bisect: skip"},
+		},
+		"status": {
+			{ActionToggleStage, []string{" "}, "stage/unstage"},
+		},
+		"picker": {
+			{ActionToggleStage, []string{" "}, "toggle item"},
+		},
+		"blame": {
+			{ActionTop, []string{"g", "g"}, "first line"},
+			{ActionBottom, []string{"g", "e"}, "last line"},
+		},
+		"file-history": {
+			{ActionTop, []string{"g", "g"}, "newest commit"},
+			{ActionBottom, []string{"g", "e"}, "oldest commit"},
+		},
+		"reflog": {
+			{ActionTop, []string{"g", "g"}, "newest entry"},
+			{ActionBottom, []string{"g", "e"}, "oldest entry"},
+		},
+	}
+}
+
+// knownActions returns all actions that may be bound, with their help
+func knownActions(commands []Command) map[string]string {
+	actions := make(map[string]string)
+	for _, bindings := range defaultBindings(commands) {
+		for _, b := range bindings {
+			actions[b.Action] = b.Help
+		}
+	}
+	// Commands without a default key can still be bound
+	for _, cmd := range commands {
+		if _, ok := actions[cmd.Name]; !ok {
+			actions[cmd.Name] = cmd.Description
+		}
+	}
+	return actions
+}
+
+// NewKeymap builds the effective keymap for commands from the defaults
+// and the configured overrides. Overriding an action replaces all of its
+// default keys in that scope. Problems are returned alongside a usable keymap.
+func NewKeymap(overrides map[string]map[string][]string, commands []Command) (*Keymap, []error) {
+	actions := knownActions(commands)
+	km := &Keymap{scopes: defaultBindings(commands), actions: actions}
+
+	validScopes := map[string]bool{KeymapGlobal: true}
+	for _, scope := range keymapScopes {
+		validScopes[scope] = true
+	}
+
+	var errs []error
+	for _, scope := range sortedKeys(overrides) {
+		if !validScopes[scope] {
+			errs = append(errs, fmt.Errorf("keybindings.%s: unknown view", scope))
+			continue
+		}
+
+		for _, action := range sortedKeys(overrides[scope]) {
+			help, ok := actions[action]
+			if !ok {
+				errs = append(errs, fmt.Errorf("keybindings.%s.%s: unknown action", scope, action))
+				continue
+			}
+
+			// Drop the defaults for this action in this scope
+			var kept []Binding
+			for _, b := range km.scopes[scope] {
+				if b.Action != action {
+					kept = append(kept, b)
+				}
+			}
+			for _, seq := range overrides[scope][action] {
+				keys := parseSequence(seq)
+				if len(keys) == 0 {
+					continue
+				}
+				kept = append(kept, Binding{Action: action, Keys: keys, Help: help})
+			}
+			km.scopes[scope] = kept
+		}
+	}
+
+	errs = append(errs, km.Conflicts()...)
+	return km, errs
+}
+
+// parseSequence splits "g g" into a chord; "space" names the space key
+func parseSequence(seq string) []string {
+	var keys []string
+	for _, k := range strings.Fields(seq) {
+		if k == "space" {
+			k = " "
+		}
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+// displayKey formats a key for help output
+func displayKey(k string) string {
+	if k == " " {
+		return "space"
+	}
+	return k
+}
+
+// Conflicts reports sequences bound to several actions and chords
+// shadowed by a shorter binding, per view (including global bindings)
+func (km *Keymap) Conflicts() []error {
+	var errs []error
+	seen := make(map[string]bool)
+
+	scopes := []string{KeymapGlobal}
+	for _, scope := range keymapScopes {
+		scopes = append(scopes, scope)
+	}
+	sort.Strings(scopes[1:])
+
+	for _, scope := range scopes {
+		bindings := km.effective(scope)
+		for i, a := range bindings {
+			for _, b := range bindings[i+1:] {
+				var msg string
+				switch {
+				case a.Sequence() == b.Sequence() && a.Action != b.Action:
+					msg = fmt.Sprintf("%q is bound to both %s and %s", displayKey(a.Sequence()), a.Action, b.Action)
+				case isPrefix(a.Keys, b.Keys):
+					msg = fmt.Sprintf("%q (%s) shadows chord %q (%s)", displayKey(a.Sequence()), a.Action, b.Sequence(), b.Action)
+				case isPrefix(b.Keys, a.Keys):
+					msg = fmt.Sprintf("%q (%s) shadows chord %q (%s)", displayKey(b.Sequence()), b.Action, a.Sequence(), a.Action)
+				default:
+					continue
+				}
+				if !seen[msg] {
+					seen[msg] = true
+					errs = append(errs, fmt.Errorf("keybindings.%s: %s", scope, msg))
+				}
+			}
+		}
+	}
+
+	return errs
+}
+
+// isPrefix reports whether a is a strict prefix of b
+func isPrefix(a, b []string) bool {
+	if len(a) >= len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// effective returns the bindings active in scope. View bindings take
+// precedence over global bindings with the same sequence.
+func (km *Keymap) effective(scope string) []Binding {
+	bindings := append([]Binding{}, km.scopes[scope]...)
+	if scope == KeymapGlobal {
+		return bindings
+	}
+
+	taken := make(map[string]bool)
+	for _, b := range bindings {
+		taken[b.Sequence()] = true
+	}
+	for _, b := range km.scopes[KeymapGlobal] {
+		if !taken[b.Sequence()] {
+			bindings = append(bindings, b)
+		}
+	}
+	return bindings
+}
+
+// Resolve feeds a key press into the keymap. It returns the bound action,
+// or pending=true while a chord is incomplete.
+func (km *Keymap) Resolve(view ViewState, k string) (action string, pending bool) {
+	bindings := km.effective(keymapScopes[view])
+	km.pending = append(km.pending, k)
+
+	for {
+		prefix := false
+		for _, b := range bindings {
+			if equalKeys(b.Keys, km.pending) {
+				km.pending = nil
+				return b.Action, false
+			}
+			if isPrefix(km.pending, b.Keys) {
+				prefix = true
+			}
+		}
+		if prefix {
+			return "", true
+		}
+
+		// An abandoned chord: retry with only the latest key
+		if len(km.pending) > 1 {
+			km.pending = km.pending[len(km.pending)-1:]
+			continue
+		}
+		km.pending = nil
+		return "", false
+	}
+}
+
+// Pending returns the keys of an incomplete chord
+func (km *Keymap) Pending() string {
+	return strings.Join(km.pending, " ")
+}
+
+func equalKeys(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// KeysFor returns the display keys bound to action in view
+func (km *Keymap) KeysFor(view ViewState, action string) []string {
+	var keys []string
+	for _, b := range km.effective(keymapScopes[view]) {
+		if b.Action == action {
+			keys = append(keys, displayKey(b.Sequence()))
+		}
+	}
+	return keys
+}
+
+// Binding returns a bubbles key binding for action, for the help bubble
+func (km *Keymap) Binding(view ViewState, action string) key.Binding {
+	keys := km.KeysFor(view, action)
+	if len(keys) == 0 {
+		return key.NewBinding(key.WithDisabled())
+	}
+	return key.NewBinding(
+		key.WithKeys(keys...),
+		key.WithHelp(strings.Join(keys, "/"), km.actions[action]),
+	)
+}
+
+// ShortHelp returns keybindings to be shown in the mini help view.
+func (km *Keymap) ShortHelp() []key.Binding {
+	return []key.Binding{
+		km.Binding(ViewDashboard, ActionHelp),
+		km.Binding(ViewDashboard, ActionQuit),
+	}
+}
+
+// FullHelp returns keybindings for the expanded help view.
+func (km *Keymap) FullHelp() [][]key.Binding {
+	b := func(action string) key.Binding { return km.Binding(ViewDashboard, action) }
+	return [][]key.Binding{
+		{b(ActionUp), b(ActionDown), b(ActionLeft), b(ActionRight)},
+		{b(ActionSelect), b(ActionNextTab), b(ActionPrevTab)},
+		{b(ActionRefresh), b(ActionBack), b(ActionHelp), b(ActionQuit)},
+	}
+}
+
+// HelpSections returns help lines grouped by scope, global first
+func (km *Keymap) HelpSections() []string {
+	var sections []string
+
+	scopes := []string{KeymapGlobal}
+	var views []string
+	for scope := range km.scopes {
+		if scope != KeymapGlobal {
+			views = append(views, scope)
+		}
+	}
+	sort.Strings(views)
+	scopes = append(scopes, views...)
+
+	for _, scope := range scopes {
+		// Group keys per action, preserving definition order
+		var order []string
+		keys := make(map[string][]string)
+		help := make(map[string]string)
+		for _, b := range km.scopes[scope] {
+			if _, ok := keys[b.Action]; !ok {
+				order = append(order, b.Action)
+			}
+			keys[b.Action] = append(keys[b.Action], displayKey(b.Sequence()))
+			help[b.Action] = b.Help
+		}
+		if len(order) == 0 {
+			continue
+		}
+
+		title := strings.ToUpper(scope[:1]) + scope[1:]
+		lines := []string{title + ":"}
+		for _, action := range order {
+			lines = append(lines, fmt.Sprintf("  %-12s %s", strings.Join(keys[action], "/"), help[action]))
+		}
+		sections = append(sections, strings.Join(lines, "\n"))
+	}
+
+	return sections
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
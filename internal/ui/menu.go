@@ -0,0 +1,118 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// menuItem is an action in a menu, run by its key or by selecting it
+type menuItem struct {
+	Key   string
+	Label string
+	Run   func(*Model) tea.Cmd
+}
+
+// menu is a list of actions for the selected object
+type menu struct {
+	title    string
+	items    []menuItem
+	selected int
+}
+
+// openMenu shows a menu
+func (m *Model) openMenu(title string, items []menuItem) {
+	m.menu = &menu{title: title, items: items}
+	m.currentView = ViewMenu
+}
+
+// closeMenu returns to the active tab, or to the composer or browser
+// (blame, file history, reflog) the menu was opened from
+func (m *Model) closeMenu() {
+	m.menu = nil
+	m.currentView = m.tabs[m.activeTab].View
+	m.resumeComposer()
+	m.resumeBrowser()
+}
+
+// handleMenuKeys handles keys while a menu is open. Item keys take
+// precedence over the keymap so menus can use any letter.
+func (m *Model) handleMenuKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	for _, item := range m.menu.items {
+		if item.Key == msg.String() {
+			m.closeMenu()
+			return m, item.Run(m)
+		}
+	}
+
+	action, _ := m.keys.Resolve(ViewMenu, msg.String())
+	switch action {
+	case ActionUp:
+		if m.menu.selected > 0 {
+			m.menu.selected--
+		}
+	case ActionDown:
+		if m.menu.selected < len(m.menu.items)-1 {
+			m.menu.selected++
+		}
+	case ActionSelect:
+		item := m.menu.items[m.menu.selected]
+		m.closeMenu()
+		return m, item.Run(m)
+	case ActionBack:
+		m.closeMenu()
+	case ActionQuit:
+		m.plugins.Close()
+		return m, tea.Quit
+	}
+	return m, nil
+}
+
+// renderMenu renders the open menu
+func (m *Model) renderMenu() string {
+	colors := m.config.Theme.Colors
+	style := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color(colors.Accent)).
+		Padding(1)
+
+	titleStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(colors.Primary)).Bold(true)
+	keyStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(colors.Tertiary))
+	selectedStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(colors.Highlight)).Bold(true)
+	normalStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(colors.Foreground))
+
+	lines := []string{titleStyle.Render(m.menu.title), ""}
+	for i, item := range m.menu.items {
+		cursor, label := "  ", normalStyle.Render(item.Label)
+		if i == m.menu.selected {
+			cursor, label = "▸ ", selectedStyle.Render(item.Label)
+		}
+		lines = append(lines, fmt.Sprintf("%s%s %s", cursor, keyStyle.Render(fmt.Sprintf("%-3s", item.Key)), label))
+	}
+
+	return style.Render(strings.Join(lines, "\n"))
+}
+
+// prompt asks for a value in the input view and passes it to fn
+func (m *Model) prompt(mode, placeholder, initial string, fn func(string)) {
+	m.inputMode = mode
+	m.input.Placeholder = placeholder
+	m.input.SetValue(initial)
+	m.input.Focus()
+	m.currentView = ViewInput
+	m.inputCallback = fn
+}
+
+// confirm asks a yes/no question and calls fn on yes
+func (m *Model) confirm(question string, fn func()) {
+	m.prompt("confirm", question+" (y/n)", "", func(value string) {
+		switch strings.ToLower(strings.TrimSpace(value)) {
+		case "y", "yes":
+			fn()
+		default:
+			m.successMsg = "Cancelled"
+		}
+	})
+}
@@ -6,7 +6,6 @@ import (
 	"time"
 
 	"github.com/charmbracelet/bubbles/help"
-	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/list"
 	"github.com/charmbracelet/bubbles/textarea"
 	"github.com/charmbracelet/bubbles/textinput"
@@ -15,6 +14,7 @@ import (
 	"github.com/charmbracelet/lipgloss"
 	"github.com/gitflow/tui/internal/config"
 	"github.com/gitflow/tui/internal/git"
+	"github.com/gitflow/tui/internal/plugin"
 	"github.com/gitflow/tui/pkg/graph"
 )
 
@@ -35,8 +35,21 @@ const (
 	ViewHelp
 	ViewConfirm
 	ViewInput
+	ViewThemes
+	ViewPalette
+	ViewOutput
+	ViewPlugin
+	ViewMenu
+	ViewPicker
+	ViewBlame
+	ViewFileHistory
+	ViewReflog
 )
 
+// Version is shown on the splash screen; the entrypoint sets it from
+// the build information
+var Version = "dev"
+
 // Splash screen banner
 const asciiBanner = `
 ╔══════════════════════════════════════════════════════════════════╗
@@ -98,17 +111,19 @@ type Model struct {
 	showSplash bool
 
 	// Data
-	commits       []git.Commit
-	branches      []git.Branch
-	status        *git.Status
-	remotes       []git.Remote
-	stashes       []git.Stash
-	tags          []git.Tag
-	currentBranch string
+	commits        []git.Commit
+	branches       []git.Branch
+	remoteBranches []git.Branch
+	remoteConfigs  []git.RemoteConfig
+	status         *git.Status
+	remotes        []git.Remote
+	stashes        []git.Stash
+	tags           []git.Tag
+	currentBranch  string
 
 	// UI Components
 	help       help.Model
-	keys       keyMap
+	keys       *Keymap
 	viewport   viewport.Model
 	list       list.Model
 	input      textinput.Model
@@ -123,6 +138,7 @@ type Model struct {
 	// Input state
 	inputMode       string
 	inputCallback   func(string)
+	inputCmd        tea.Cmd // Started by inputCallback, e.g. a stream
 	confirmCallback func(bool)
 
 	// Selection
@@ -130,6 +146,12 @@ type Model struct {
 	selectedBranch int
 	selectedFile   int
 	selectedStash  int
+	selectedRemote int
+	selectedTag    int
+
+	// Tags on the remote last compared with, by name
+	remoteTags     map[string]string
+	remoteTagsFrom string
 
 	// Diff view
 	diffContent string
@@ -137,88 +159,47 @@ type Model struct {
 
 	// Graph
 	graphRenderer *graph.Graph
-}
-
-// keyMap defines keyboard shortcuts
-type keyMap struct {
-	Up       key.Binding
-	Down     key.Binding
-	Left     key.Binding
-	Right    key.Binding
-	Help     key.Binding
-	Quit     key.Binding
-	Tab      key.Binding
-	ShiftTab key.Binding
-	Enter    key.Binding
-	Esc      key.Binding
-	Space    key.Binding
-	Refresh  key.Binding
-}
-
-// Default key bindings
-var defaultKeys = keyMap{
-	Up: key.NewBinding(
-		key.WithKeys("up", "k"),
-		key.WithHelp("↑/k", "up"),
-	),
-	Down: key.NewBinding(
-		key.WithKeys("down", "j"),
-		key.WithHelp("↓/j", "down"),
-	),
-	Left: key.NewBinding(
-		key.WithKeys("left", "h"),
-		key.WithHelp("←/h", "left"),
-	),
-	Right: key.NewBinding(
-		key.WithKeys("right", "l"),
-		key.WithHelp("→/l", "right"),
-	),
-	Help: key.NewBinding(
-		key.WithKeys("?"),
-		key.WithHelp("?", "help"),
-	),
-	Quit: key.NewBinding(
-		key.WithKeys("q", "ctrl+c"),
-		key.WithHelp("q", "quit"),
-	),
-	Tab: key.NewBinding(
-		key.WithKeys("tab"),
-		key.WithHelp("tab", "next tab"),
-	),
-	ShiftTab: key.NewBinding(
-		key.WithKeys("shift+tab"),
-		key.WithHelp("shift+tab", "prev tab"),
-	),
-	Enter: key.NewBinding(
-		key.WithKeys("enter"),
-		key.WithHelp("enter", "select"),
-	),
-	Esc: key.NewBinding(
-		key.WithKeys("esc"),
-		key.WithHelp("esc", "back"),
-	),
-	Space: key.NewBinding(
-		key.WithKeys(" "),
-		key.WithHelp("space", "stage/unstage"),
-	),
-	Refresh: key.NewBinding(
-		key.WithKeys("r"),
-		key.WithHelp("r", "refresh"),
-	),
-}
-
-// ShortHelp returns keybindings to be shown in the mini help view.
-func (k keyMap) ShortHelp() []key.Binding {
-	return []key.Binding{k.Help, k.Quit}
-}
-
-// FullHelp returns keybindings for the expanded help view.
-func (k keyMap) FullHelp() [][]key.Binding {
-	return [][]key.Binding{
-		{k.Up, k.Down, k.Left, k.Right},
-		{k.Enter, k.Space, k.Tab, k.ShiftTab},
-		{k.Refresh, k.Esc, k.Help, k.Quit},
-	}
+	logFilter     git.LogFilter
+
+	// Bisect session, nil when not bisecting, the short hash and subject
+	// of its first bad commit once found, and the last test command
+	bisect        *git.Bisect
+	bisectCulprit string
+	bisectCommand string
+
+	// Theme picker
+	themes        []config.Theme
+	selectedTheme int
+	themeBefore   config.Theme
+
+	// Command palette, nil when closed
+	palette *palette
+
+	// Command output panel
+	outputTitle   string
+	outputContent string
+
+	// Action menu and multi-select picker, nil when closed
+	menu   *menu
+	picker *picker
+
+	// Commit composer, nil when closed
+	composer *composer
+
+	// Blame, file history and reflog browsers, nil when closed; browser
+	// is the one in front
+	blame   *blameView
+	history *fileHistory
+	reflog  *reflogView
+	browser ViewState
+
+	// Called when the command streaming to the output panel finishes
+	streamDone func(m *Model, err error)
+
+	// Plugins and the tabs they add
+	plugins       *plugin.Manager
+	tabs          []Tab
+	pluginContent map[string]string
 }
 
 // New creates a new UI model
@@ -271,7 +252,7 @@ func New(cfg *config.Config) *Model {
 	tagList.Title = "Tags"
 	tagList.SetShowHelp(false)
 
-	return &Model{
+	m := &Model{
 		config:      cfg,
 		repo:        repoPath,
 		git:         g,
@@ -280,7 +261,6 @@ func New(cfg *config.Config) *Model {
 		showSplash:  true,
 		splashTick:  0,
 		help:        h,
-		keys:        defaultKeys,
 		input:       input,
 		textArea:    ta,
 		commitList:  commitList,
@@ -289,7 +269,18 @@ func New(cfg *config.Config) *Model {
 		stashList:   stashList,
 		remoteList:  remoteList,
 		tagList:     tagList,
+		plugins:     &plugin.Manager{},
+		tabs:        append([]Tab{}, Tabs...),
 	}
+
+	// Plugins start in Init; until then their key bindings are unknown,
+	// so problems are reported once they have loaded
+	m.keys, _ = m.bindKeys()
+
+	// Resolve "auto" and named themes for this terminal
+	m.applyTheme(cfg.Theme)
+
+	return m
 }
 
 // splashTickMsg is sent for splash screen animation
@@ -307,6 +298,7 @@ func (m *Model) Init() tea.Cmd {
 		m.loadData(),
 		tea.EnterAltScreen,
 		splashTick(),
+		m.loadPlugins(),
 	)
 }
 
@@ -315,8 +307,11 @@ func (m *Model) loadData() tea.Cmd {
 	return func() tea.Msg {
 		var err error
 
-		// Load commits
-		m.commits, err = m.git.GetCommits(50)
+		// Load commits, and the bisect session they are marked with
+		if err := m.loadBisect(); err != nil {
+			return errMsg{err: err}
+		}
+		m.commits, err = m.git.FilterCommits(m.graphFilter(m.logFilter))
 		if err != nil {
 			return errMsg{err: err}
 		}
@@ -326,25 +321,35 @@ func (m *Model) loadData() tea.Cmd {
 		if err != nil {
 			return errMsg{err: err}
 		}
-
-		// Load status
-		m.status, err = m.git.GetStatus()
+		m.remoteBranches, err = m.git.GetRemoteBranches()
 		if err != nil {
 			return errMsg{err: err}
 		}
 
+		// Load status; a bare repository has no working tree
+		if m.repo.IsBare {
+			m.status = &git.Status{}
+		} else if m.status, err = m.git.GetStatus(); err != nil {
+			return errMsg{err: err}
+		}
+
 		// Load remotes
 		m.remotes, err = m.git.GetRemotes()
 		if err != nil {
 			return errMsg{err: err}
 		}
-
-		// Load stashes
-		m.stashes, err = m.git.GetStash()
+		m.remoteConfigs, err = m.git.GetRemoteConfigs()
 		if err != nil {
 			return errMsg{err: err}
 		}
 
+		// Load stashes; stash needs a working tree too
+		if m.repo.IsBare {
+			m.stashes = nil
+		} else if m.stashes, err = m.git.GetStash(); err != nil {
+			return errMsg{err: err}
+		}
+
 		// Load tags
 		m.tags, err = m.git.GetTags()
 		if err != nil {
@@ -354,6 +359,11 @@ func (m *Model) loadData() tea.Cmd {
 		// Get current branch
 		m.currentBranch, _ = m.git.GetCurrentBranch()
 
+		// Plugin tabs may show repository data too
+		if m.currentView == ViewPlugin {
+			m.refreshPluginTab()
+		}
+
 		return dataLoadedMsg{}
 	}
 }
@@ -426,9 +436,28 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case dataLoadedMsg:
 		m.loading = false
 		m.updateLists()
+		return m, m.verifyVisibleCommits()
 
 	case refreshMsg:
 		return m, m.loadData()
+
+	case pluginsLoadedMsg:
+		m.addPlugins(msg)
+
+	case signatureMsg:
+		m.setSignature(msg.commit)
+
+	case signaturesMsg:
+		m.setSignatures(msg.statuses)
+
+	case palettePreviewMsg:
+		m.setPalettePreview(msg)
+
+	case bisectMsg:
+		m.bisectDone(msg)
+
+	case streamOutputMsg, streamDoneMsg:
+		return m, m.handleStream(msg)
 	}
 
 	return m, nil
@@ -440,11 +469,10 @@ func (m *Model) handleMouse(msg tea.MouseMsg) (tea.Model, tea.Cmd) {
 	case tea.MouseLeft:
 		// Check if clicked on a tab
 		if msg.Y == 1 {
-			tabWidth := m.width / len(Tabs)
+			tabWidth := m.width / len(m.tabs)
 			clickedTab := msg.X / tabWidth
-			if clickedTab < len(Tabs) {
-				m.activeTab = clickedTab
-				m.currentView = Tabs[clickedTab].View
+			if clickedTab < len(m.tabs) {
+				return m, m.selectTab(clickedTab)
 			}
 		}
 	}
@@ -460,123 +488,175 @@ func (m *Model) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		return m, nil
 	}
 
-	switch {
-	case key.Matches(msg, m.keys.Quit):
+	// The composer and text input consume all keys except their own
+	// enter/esc handling
+	if m.currentView == ViewCommit && m.composer != nil {
+		if msg.Type == tea.KeyCtrlC {
+			m.plugins.Close()
+			return m, tea.Quit
+		}
+		return m.handleComposerKeys(msg)
+	}
+	if m.currentView == ViewInput {
+		if msg.Type == tea.KeyCtrlC {
+			m.plugins.Close()
+			return m, tea.Quit
+		}
+		return m.handleInputKeys(msg)
+	}
+
+	if m.currentView == ViewPalette && m.palette != nil {
+		return m.handlePaletteKeys(msg)
+	}
+
+	if m.currentView == ViewMenu && m.menu != nil {
+		return m.handleMenuKeys(msg)
+	}
+
+	action, pending := m.keys.Resolve(m.currentView, msg.String())
+	if pending || action == "" {
+		return m, nil
+	}
+
+	// Pickers only take navigation, so letters cannot start commands
+	if m.currentView == ViewPicker && m.picker != nil && action != ActionQuit {
+		return m.handlePickerKeys(action)
+	}
+
+	switch action {
+	case ActionQuit:
+		m.plugins.Close()
 		return m, tea.Quit
 
-	case key.Matches(msg, m.keys.Help):
+	case ActionHelp:
 		if m.currentView == ViewHelp {
 			m.currentView = ViewDashboard
 		} else {
 			m.currentView = ViewHelp
 		}
 
-	case key.Matches(msg, m.keys.Tab):
-		m.activeTab = (m.activeTab + 1) % len(Tabs)
-		m.currentView = Tabs[m.activeTab].View
+	case ActionNextTab:
+		return m, m.selectTab((m.activeTab + 1) % len(m.tabs))
 
-	case key.Matches(msg, m.keys.ShiftTab):
-		m.activeTab = (m.activeTab - 1 + len(Tabs)) % len(Tabs)
-		m.currentView = Tabs[m.activeTab].View
+	case ActionPrevTab:
+		return m, m.selectTab((m.activeTab - 1 + len(m.tabs)) % len(m.tabs))
 
-	case key.Matches(msg, m.keys.Refresh):
+	case ActionRefresh:
 		return m, m.loadData()
 
-	case key.Matches(msg, m.keys.Esc):
-		if m.currentView == ViewInput || m.currentView == ViewConfirm {
-			m.currentView = ViewDashboard
+	case ActionBack:
+		if m.currentView == ViewThemes {
+			m.cancelThemePicker()
+		}
+		switch m.currentView {
+		case ViewConfirm, ViewDiff, ViewHelp, ViewOutput:
+			m.currentView = m.tabs[m.activeTab].View
+			// Back to the composer after a failed commit, or to the
+			// browser the diff was opened from
+			m.resumeComposer()
+			m.resumeBrowser()
+		case ViewBlame:
+			m.closeBlame()
+		case ViewFileHistory:
+			m.closeFileHistory()
+		case ViewReflog:
+			m.closeReflog()
+		case ViewGraph:
+			if !m.logFilter.Empty() {
+				m.setLogFilter(git.LogFilter{})
+			}
 		}
-
-	// Git command shortcuts
-	case msg.String() == "c":
-		return m, cmdCommit(m)
-	case msg.String() == "p":
-		return m, cmdPush(m)
-	case msg.String() == "P":
-		return m, cmdPull(m)
-	case msg.String() == "f":
-		return m, cmdFetch(m)
-	case msg.String() == "b":
-		return m, cmdCheckout(m)
-	case msg.String() == "m":
-		return m, cmdMerge(m)
-	case msg.String() == "R":
-		return m, cmdRebase(m)
-	case msg.String() == "S":
-		return m, cmdStash(m)
-	case msg.String() == "O":
-		return m, cmdStashPop(m)
-	case msg.String() == "t":
-		return m, cmdTag(m)
-	case msg.String() == "X":
-		return m, cmdReset(m)
-	case msg.String() == "C":
-		return m, cmdCherryPick(m)
 
 	default:
-		// View-specific key handling
+		// Git commands
+		if cmd := m.ExecuteCommand(action); cmd != nil {
+			return m, cmd
+		}
+
+		// View-specific actions
 		switch m.currentView {
 		case ViewGraph:
-			return m.handleGraphKeys(msg)
+			return m.handleGraphKeys(action)
 		case ViewBranches:
-			return m.handleBranchKeys(msg)
+			return m.handleBranchKeys(action)
 		case ViewStatus:
-			return m.handleStatusKeys(msg)
-		case ViewInput:
-			return m.handleInputKeys(msg)
+			return m.handleStatusKeys(action)
+		case ViewStash:
+			return m.handleStashKeys(action)
+		case ViewRemote:
+			return m.handleRemoteKeys(action)
+		case ViewTags:
+			return m.handleTagKeys(action)
+		case ViewThemes:
+			return m.handleThemeKeys(action)
+		case ViewBlame:
+			return m.handleBlameKeys(action)
+		case ViewFileHistory:
+			return m.handleFileHistoryKeys(action)
+		case ViewReflog:
+			return m.handleReflogKeys(action)
 		}
 	}
 
 	return m, nil
 }
 
-// handleGraphKeys handles graph view keys
-func (m *Model) handleGraphKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
-	switch {
-	case key.Matches(msg, m.keys.Up):
+// handleGraphKeys handles graph view actions
+func (m *Model) handleGraphKeys(action string) (tea.Model, tea.Cmd) {
+	switch action {
+	case ActionUp:
 		if m.selectedCommit > 0 {
 			m.selectedCommit--
 		}
-	case key.Matches(msg, m.keys.Down):
+	case ActionDown:
 		if m.selectedCommit < len(m.commits)-1 {
 			m.selectedCommit++
 		}
-	case key.Matches(msg, m.keys.Enter):
+	case ActionTop:
+		m.selectedCommit = 0
+	case ActionBottom:
+		m.selectedCommit = max(0, len(m.commits)-1)
+	case ActionSelect:
 		if m.selectedCommit < len(m.commits) {
 			commit := m.commits[m.selectedCommit]
-			m.showCommitDetails(commit)
+			return m, m.showCommitDetails(commit)
 		}
+	case ActionBisectGood:
+		return m, m.handleBisectKey(git.BisectGood)
+	case ActionBisectBad:
+		return m, m.handleBisectKey(git.BisectBad)
+	case ActionBisectSkip:
+		return m, m.handleBisectKey(git.BisectSkip)
 	}
 	return m, nil
 }
 
-// handleBranchKeys handles branch view keys
-func (m *Model) handleBranchKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
-	switch {
-	case key.Matches(msg, m.keys.Up):
+// handleBranchKeys handles branch view actions
+func (m *Model) handleBranchKeys(action string) (tea.Model, tea.Cmd) {
+	switch action {
+	case ActionUp:
 		if m.selectedBranch > 0 {
 			m.selectedBranch--
 		}
-	case key.Matches(msg, m.keys.Down):
-		if m.selectedBranch < len(m.branches)-1 {
+	case ActionDown:
+		if m.selectedBranch < len(m.branches)+len(m.remoteBranches)-1 {
 			m.selectedBranch++
 		}
-	case key.Matches(msg, m.keys.Enter):
-		if m.selectedBranch < len(m.branches) {
-			branch := m.branches[m.selectedBranch]
+	case ActionSelect:
+		if branch, ok := m.selectedBranchInfo(); ok {
 			m.showBranchMenu(branch)
 		}
 	}
 	return m, nil
 }
 
-// handleStatusKeys handles status view keys
-func (m *Model) handleStatusKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
-	switch {
-	case key.Matches(msg, m.keys.Space):
+// handleStatusKeys handles status view actions
+func (m *Model) handleStatusKeys(action string) (tea.Model, tea.Cmd) {
+	switch action {
+	case ActionToggleStage:
 		// Stage/unstage file
 		m.toggleStage()
-	case key.Matches(msg, m.keys.Enter):
+	case ActionSelect:
 		// Show diff
 		m.showFileDiff()
 	}
@@ -588,12 +668,22 @@ func (m *Model) handleInputKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	switch msg.Type {
 	case tea.KeyEnter:
 		if m.inputCallback != nil {
-			m.inputCallback(m.input.Value())
+			// The callback may open a follow-up prompt
+			value := m.input.Value()
 			m.input.SetValue("")
 			m.currentView = ViewDashboard
+			m.inputCallback(value)
+			if m.currentView == ViewDashboard {
+				m.resumeBrowser()
+			}
+			cmd := m.inputCmd
+			m.inputCmd = nil
+			return m, cmd
 		}
 	case tea.KeyEsc:
 		m.currentView = ViewDashboard
+		m.resumeComposer()
+		m.resumeBrowser()
 	default:
 		var cmd tea.Cmd
 		m.input, cmd = m.input.Update(msg)
@@ -725,7 +815,7 @@ func (m *Model) renderSplash() string {
 		bannerStyle.Render(asciiBanner),
 		"",
 		loadingStyle.Render(loading),
-		versionStyle.Render("GitFlow TUI v1.0.0 - Press any key to skip"),
+		versionStyle.Render("GitFlow TUI "+Version+" - Press any key to skip"),
 	)
 
 	// Center the whole thing on screen
@@ -753,7 +843,7 @@ func (m *Model) renderBanner() string {
 // renderTabs renders the tab bar
 func (m *Model) renderTabs() string {
 	var tabs []string
-	for i, tab := range Tabs {
+	for i, tab := range m.tabs {
 		style := lipgloss.NewStyle().
 			Padding(0, 2).
 			Border(lipgloss.RoundedBorder())
@@ -796,6 +886,26 @@ func (m *Model) renderContent() string {
 		return m.renderInput()
 	case ViewDiff:
 		return m.renderDiff()
+	case ViewThemes:
+		return m.renderThemes()
+	case ViewPalette:
+		return m.renderPalette()
+	case ViewOutput:
+		return m.renderOutput()
+	case ViewPlugin:
+		return m.renderPluginTab()
+	case ViewMenu:
+		return m.renderMenu()
+	case ViewPicker:
+		return m.renderPicker()
+	case ViewCommit:
+		return m.renderComposer()
+	case ViewBlame:
+		return m.renderBlame()
+	case ViewFileHistory:
+		return m.renderFileHistory()
+	case ViewReflog:
+		return m.renderReflog()
 	default:
 		return m.renderDashboard()
 	}
@@ -866,7 +976,7 @@ func (m *Model) renderDashboard() string {
 
 // renderGraph renders the commit graph
 func (m *Model) renderGraph() string {
-	if len(m.commits) == 0 {
+	if len(m.commits) == 0 && m.logFilter.Empty() {
 		return "No commits found"
 	}
 
@@ -888,8 +998,24 @@ func (m *Model) renderGraph() string {
 	// Use colorful graph
 	g := graph.NewColored(m.commits, graphStyle, m.config.Theme.Colors)
 	g.SetWidth(m.width - 4)
+	g.SetBisect(m.bisect)
 
-	return style.Render(g.Render())
+	var headers []string
+	filterStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(m.config.Theme.Colors.Accent))
+	if !m.logFilter.Empty() {
+		headers = append(headers, filterStyle.Render(fmt.Sprintf("Filter: %s · %d commit(s) · esc to clear", m.logFilter, len(m.commits))))
+	}
+	if m.bisect != nil {
+		status := "Bisect: " + m.bisectStatus()
+		if m.bisect.Culprit == "" {
+			status += " · o good · x bad · s skip"
+		}
+		headers = append(headers, filterStyle.Render(status))
+	}
+	if len(headers) == 0 {
+		return style.Render(markSelected(g.Render(), m.selectedCommit))
+	}
+	return style.Render(strings.Join(headers, "\n") + "\n\n" + markSelected(g.Render(), m.selectedCommit))
 }
 
 // renderBranches renders the colorful branches view
@@ -899,65 +1025,57 @@ func (m *Model) renderBranches() string {
 		BorderForeground(lipgloss.Color(m.config.Theme.Colors.Border)).
 		Padding(1)
 
-	// Use colorful branch graph renderer
-	g := graph.NewColored(nil, graph.Unicode, m.config.Theme.Colors)
-	return style.Render(g.RenderBranchGraph(m.branches, m.currentBranch))
-}
-
-// renderStatus renders the status view
-func (m *Model) renderStatus() string {
-	style := lipgloss.NewStyle().
-		Border(lipgloss.RoundedBorder()).
-		BorderForeground(lipgloss.Color(m.config.Theme.Colors.Border)).
-		Padding(1)
+	headerStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color(m.config.Theme.Colors.Secondary)).
+		Bold(true)
 
-	// Use colorful status renderer
-	return style.Render(graph.RenderStatusGraph(m.status, m.config.Theme.Colors))
+	// Use colorful branch graph renderer; local branches come first and
+	// selection indexes run across both sections
+	g := graph.NewColored(nil, graph.Unicode, m.config.Theme.Colors)
+	sections := []string{headerStyle.Render("Local")}
+	sections = append(sections, markSelected(g.RenderBranchGraph(m.branches, m.currentBranch), m.selectedBranch))
+	if len(m.remoteBranches) > 0 {
+		sections = append(sections, "", headerStyle.Render("Remote-tracking"))
+		sections = append(sections, markSelected(g.RenderBranchGraph(m.remoteBranches, m.currentBranch), m.selectedBranch-len(m.branches)))
+	}
+	return style.Render(strings.Join(sections, "\n"))
 }
 
-// renderStash renders the stash view
-func (m *Model) renderStash() string {
-	style := lipgloss.NewStyle().
-		Border(lipgloss.RoundedBorder()).
-		BorderForeground(lipgloss.Color(m.config.Theme.Colors.Border)).
-		Padding(1)
-
-	var content strings.Builder
-	for _, s := range m.stashes {
-		content.WriteString(fmt.Sprintf("stash@{%d}: %s\n", s.Index, s.Message))
+// markSelected prefixes line selected of s with a cursor
+func markSelected(s string, selected int) string {
+	lines := strings.Split(s, "\n")
+	for i := range lines {
+		if i == selected {
+			lines[i] = "▸ " + lines[i]
+		} else {
+			lines[i] = "  " + lines[i]
+		}
 	}
-
-	return style.Render(content.String())
+	return strings.Join(lines, "\n")
 }
 
-// renderRemotes renders the remotes view
-func (m *Model) renderRemotes() string {
-	style := lipgloss.NewStyle().
-		Border(lipgloss.RoundedBorder()).
-		BorderForeground(lipgloss.Color(m.config.Theme.Colors.Border)).
-		Padding(1)
-
-	var content strings.Builder
-	for _, r := range m.remotes {
-		content.WriteString(fmt.Sprintf("%s\n  %s (%s)\n\n", r.Name, r.URL, r.Type))
+// selectedBranchInfo returns the branch selected in the Branches tab,
+// local or remote-tracking
+func (m *Model) selectedBranchInfo() (git.Branch, bool) {
+	switch {
+	case m.selectedBranch < len(m.branches):
+		return m.branches[m.selectedBranch], true
+	case m.selectedBranch < len(m.branches)+len(m.remoteBranches):
+		return m.remoteBranches[m.selectedBranch-len(m.branches)], true
+	default:
+		return git.Branch{}, false
 	}
-
-	return style.Render(content.String())
 }
 
-// renderTags renders the tags view
-func (m *Model) renderTags() string {
+// renderStatus renders the status view
+func (m *Model) renderStatus() string {
 	style := lipgloss.NewStyle().
 		Border(lipgloss.RoundedBorder()).
 		BorderForeground(lipgloss.Color(m.config.Theme.Colors.Border)).
 		Padding(1)
 
-	var content strings.Builder
-	for _, t := range m.tags {
-		content.WriteString(fmt.Sprintf("%s\n  %s\n\n", t.Name, t.Message))
-	}
-
-	return style.Render(content.String())
+	// Use colorful status renderer
+	return style.Render(graph.RenderStatusGraph(m.status, m.config.Theme.Colors))
 }
 
 // renderHelpView renders the help view
@@ -967,33 +1085,8 @@ func (m *Model) renderHelpView() string {
 		BorderForeground(lipgloss.Color(m.config.Theme.Colors.Border)).
 		Padding(1)
 
-	help := `
-Keyboard Shortcuts:
-
-Navigation:
-  ↑/k      Move up
-  ↓/j      Move down
-  ←/h      Move left
-  →/l      Move right
-  Tab      Next tab
-  Shift+Tab Previous tab
-
-Actions:
-  Enter    Select/Confirm
-  Space    Stage/Unstage file
-  r        Refresh data
-  ?        Toggle help
-  q        Quit
-
-Git Commands:
-  c        Commit
-  p        Push
-  P        Pull
-  f        Fetch
-  b        Checkout branch
-  m        Merge
-  R        Rebase
-`
+	// Generated from the effective keymap so overrides are reflected
+	help := "Keyboard Shortcuts:\n\n" + strings.Join(m.keys.HelpSections(), "\n\n")
 
 	return style.Render(help)
 }
@@ -1018,6 +1111,28 @@ func (m *Model) renderDiff() string {
 	return style.Render(m.diffContent)
 }
 
+// renderOutput renders the output of a command
+func (m *Model) renderOutput() string {
+	style := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color(m.config.Theme.Colors.Border)).
+		Padding(1)
+
+	titleStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color(m.config.Theme.Colors.Primary)).
+		Bold(true)
+
+	// While output streams in, keep its end in view
+	content := strings.TrimRight(m.outputContent, "\n")
+	if m.streamDone != nil && m.height > 12 {
+		if lines := strings.Split(content, "\n"); len(lines) > m.height-12 {
+			content = strings.Join(lines[len(lines)-(m.height-12):], "\n")
+		}
+	}
+
+	return style.Render(titleStyle.Render(m.outputTitle) + "\n\n" + content)
+}
+
 // renderStatusBar renders the status bar
 func (m *Model) renderStatusBar() string {
 	style := lipgloss.NewStyle().
@@ -1046,24 +1161,30 @@ func (m *Model) renderHelp() string {
 
 // renderCommandFooter renders nano-style command footer
 func (m *Model) renderCommandFooter() string {
-	// Define commands to show based on current view
-	var commands []struct {
+	type footerItem struct {
 		key  string
 		desc string
 	}
 
-	// Common commands always shown
-	commands = []struct {
-		key  string
-		desc string
-	}{
-		{"c", "commit"},
-		{"p", "push"},
-		{"P", "pull"},
-		{"b", "checkout"},
-		{"S", "stash"},
-		{"?", "help"},
-		{"q", "quit"},
+	// Common actions always shown, with their configured keys
+	var commands []footerItem
+	for _, action := range []footerItem{
+		{"commit", "commit"},
+		{"push", "push"},
+		{"pull", "pull"},
+		{"checkout", "checkout"},
+		{"stash", "stash"},
+		{ActionHelp, "help"},
+		{ActionQuit, "quit"},
+	} {
+		if keys := m.keys.KeysFor(m.currentView, action.key); len(keys) > 0 {
+			commands = append(commands, footerItem{keys[0], action.desc})
+		}
+	}
+
+	// Show an incomplete chord so the user knows more keys are expected
+	if pending := m.keys.Pending(); pending != "" {
+		commands = append(commands, footerItem{pending, "…"})
 	}
 
 	// Build footer items
@@ -1098,14 +1219,85 @@ func (m *Model) renderCommandFooter() string {
 }
 
 // Helper methods
-func (m *Model) showCommitDetails(commit git.Commit) {
+func (m *Model) showCommitDetails(commit git.Commit) tea.Cmd {
 	// Show commit details in a modal or new view
 	m.successMsg = fmt.Sprintf("Selected: %s - %s", commit.ShortHash, commit.Message)
+
+	// Verify the signature on demand; git log skips it as it is slow
+	g := m.git
+	return func() tea.Msg {
+		if err := g.VerifySignature(&commit); err != nil {
+			return errMsg{err}
+		}
+		return signatureMsg{commit}
+	}
 }
 
-func (m *Model) showBranchMenu(branch git.Branch) {
-	// Show branch actions menu
-	m.successMsg = fmt.Sprintf("Branch: %s", branch.Name)
+// verifyVisibleCommits verifies the signatures of the commits around the
+// selection in one background call, for the Graph tab badges
+func (m *Model) verifyVisibleCommits() tea.Cmd {
+	start, end := m.visibleRange(m.selectedCommit, len(m.commits))
+	if start >= end {
+		return nil
+	}
+	var hashes []string
+	for _, c := range m.commits[start:end] {
+		hashes = append(hashes, c.Hash)
+	}
+
+	g := m.git
+	return func() tea.Msg {
+		statuses, err := g.VerifySignatures(hashes)
+		if err != nil {
+			return errMsg{err}
+		}
+		return signaturesMsg{statuses}
+	}
+}
+
+// signaturesMsg carries the verified signature status of several commits
+type signaturesMsg struct {
+	statuses map[string]git.SignatureStatus
+}
+
+// setSignatures records verified signatures in the Graph tab, keeping the
+// signer details of commits verified one by one
+func (m *Model) setSignatures(statuses map[string]git.SignatureStatus) {
+	for i := range m.commits {
+		if status, ok := statuses[m.commits[i].Hash]; ok && m.commits[i].Signature == "" {
+			m.commits[i].Signature = status
+		}
+	}
+}
+
+// signatureMsg carries a commit whose signature was verified
+type signatureMsg struct {
+	commit git.Commit
+}
+
+// setSignature records a verified signature in the Graph tab and shows it
+// while the commit is still selected
+func (m *Model) setSignature(commit git.Commit) {
+	for i := range m.commits {
+		if m.commits[i].Hash == commit.Hash {
+			m.commits[i].Signature = commit.Signature
+			m.commits[i].Signer = commit.Signer
+			m.commits[i].SignKey = commit.SignKey
+		}
+	}
+	if !commit.Signature.Signed() || m.selectedCommit >= len(m.commits) ||
+		m.commits[m.selectedCommit].Hash != commit.Hash {
+		return
+	}
+
+	m.successMsg = fmt.Sprintf("Selected: %s - %s [%s", commit.ShortHash, commit.Message, commit.Signature.Description())
+	if commit.Signer != "" {
+		m.successMsg += " by " + commit.Signer
+	}
+	if commit.SignKey != "" {
+		m.successMsg += " key " + commit.SignKey
+	}
+	m.successMsg += "]"
 }
 
 func (m *Model) toggleStage() {
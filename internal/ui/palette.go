@@ -0,0 +1,401 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/gitflow/tui/internal/config"
+	"github.com/gitflow/tui/internal/git"
+	"github.com/sahilm/fuzzy"
+)
+
+// maxPaletteResults limits the number of rows shown in the palette
+const maxPaletteResults = 12
+
+// paletteEntry is a single searchable palette item
+type paletteEntry struct {
+	Kind   string // command, branch, tag, file
+	Title  string
+	Detail string
+	Recent bool
+	Run    func(*Model) tea.Cmd
+}
+
+// searchText is what the fuzzy matcher sees
+func (e paletteEntry) searchText() string {
+	return e.Title + " " + e.Detail
+}
+
+// paletteEntries implements fuzzy.Source
+type paletteEntries []paletteEntry
+
+func (p paletteEntries) String(i int) string { return p[i].searchText() }
+func (p paletteEntries) Len() int            { return len(p) }
+
+// palette holds the command palette state
+type palette struct {
+	input    textinput.Model
+	entries  paletteEntries
+	results  []paletteEntry
+	selected int
+	preview  string
+	previews map[string]string // Loaded previews by entry
+	state    *config.State
+}
+
+// cmdPalette opens the command palette
+func cmdPalette(m *Model) tea.Cmd {
+	return func() tea.Msg {
+		state, err := config.LoadState()
+		if err != nil {
+			m.errorMsg = err.Error()
+			state = &config.State{}
+		}
+
+		input := textinput.New()
+		input.Prompt = ": "
+		input.Placeholder = "Search commands, branches, tags and files..."
+		input.Focus()
+
+		m.palette = &palette{input: input, state: state, previews: make(map[string]string)}
+		m.palette.entries = m.buildPaletteEntries(state.RecentCommands)
+		preview := m.filterPalette()
+		m.currentView = ViewPalette
+		if preview == nil {
+			return nil
+		}
+		return preview()
+	}
+}
+
+// buildPaletteEntries collects all palette items, recent commands first
+func (m *Model) buildPaletteEntries(recent []string) paletteEntries {
+	all := m.commands()
+	commands := make(map[string]Command)
+	for _, cmd := range all {
+		commands[cmd.Name] = cmd
+	}
+
+	var entries paletteEntries
+	commandEntry := func(cmd Command, isRecent bool) paletteEntry {
+		name := cmd.Name
+		return paletteEntry{
+			Kind:   "command",
+			Title:  cmd.Name,
+			Detail: cmd.Description,
+			Recent: isRecent,
+			Run: func(m *Model) tea.Cmd {
+				m.rememberCommand(name)
+				return m.ExecuteCommand(name)
+			},
+		}
+	}
+
+	seen := make(map[string]bool)
+	for _, name := range recent {
+		if cmd, ok := commands[name]; ok && !seen[name] {
+			seen[name] = true
+			entries = append(entries, commandEntry(cmd, true))
+		}
+	}
+	for _, cmd := range all {
+		if !seen[cmd.Name] && cmd.Name != "palette" {
+			entries = append(entries, commandEntry(cmd, false))
+		}
+	}
+
+	for _, b := range m.branches {
+		name := b.Name
+		entries = append(entries, paletteEntry{
+			Kind:   "branch",
+			Title:  name,
+			Detail: "checkout branch",
+			Run: func(m *Model) tea.Cmd {
+				return m.checkoutBranch(name)
+			},
+		})
+	}
+
+	for _, b := range m.remoteBranches {
+		name := b.Name
+		entries = append(entries, paletteEntry{
+			Kind:   "branch",
+			Title:  name,
+			Detail: "checkout as tracking branch",
+			Run: func(m *Model) tea.Cmd {
+				return m.checkoutTracking(name)
+			},
+		})
+	}
+
+	for _, t := range m.tags {
+		name := t.Name
+		entries = append(entries, paletteEntry{
+			Kind:   "tag",
+			Title:  name,
+			Detail: t.Message,
+			Run: func(m *Model) tea.Cmd {
+				m.activeTab = m.tabIndex(ViewTags)
+				m.currentView = ViewTags
+				m.successMsg = "Tag: " + name
+				return nil
+			},
+		})
+	}
+
+	if m.status != nil {
+		var files []string
+		for _, f := range m.status.Staged {
+			files = append(files, f.Path)
+		}
+		for _, f := range m.status.Unstaged {
+			files = append(files, f.Path)
+		}
+		files = append(files, m.status.Untracked...)
+
+		for _, f := range files {
+			path := f
+			entries = append(entries, paletteEntry{
+				Kind:   "file",
+				Title:  path,
+				Detail: "show diff",
+				Run: func(m *Model) tea.Cmd {
+					// Staged and unstaged changes, like the preview
+					m.showDiff(func() (string, error) { return m.git.GetDiffHead("--", path) })
+					return nil
+				},
+			})
+		}
+	}
+
+	return entries
+}
+
+// tabIndex returns the index of the tab showing view
+func (m *Model) tabIndex(view ViewState) int {
+	for i, t := range m.tabs {
+		if t.View == view {
+			return i
+		}
+	}
+	return 0
+}
+
+// rememberCommand records a command in the palette history
+func (m *Model) rememberCommand(name string) {
+	if m.palette == nil || m.palette.state == nil {
+		return
+	}
+	m.palette.state.AddRecentCommand(name)
+	if err := m.palette.state.Save(); err != nil {
+		m.errorMsg = err.Error()
+	}
+}
+
+// filterPalette refreshes results for the current query and returns the
+// command loading the preview of the selected entry
+func (m *Model) filterPalette() tea.Cmd {
+	p := m.palette
+	query := strings.TrimSpace(p.input.Value())
+
+	p.results = p.results[:0]
+	if query == "" {
+		// Without a query show commands, recent ones first
+		for _, e := range p.entries {
+			if e.Kind == "command" {
+				p.results = append(p.results, e)
+			}
+		}
+	} else {
+		for _, match := range fuzzy.FindFrom(query, p.entries) {
+			p.results = append(p.results, p.entries[match.Index])
+		}
+	}
+
+	if len(p.results) > maxPaletteResults {
+		p.results = p.results[:maxPaletteResults]
+	}
+	if p.selected >= len(p.results) {
+		p.selected = max(0, len(p.results)-1)
+	}
+	return m.updatePalettePreview()
+}
+
+// palettePreviewMsg carries a preview loaded in the background
+type palettePreviewMsg struct {
+	key     string
+	preview string
+}
+
+// previewKey identifies the entry a preview belongs to
+func (e paletteEntry) previewKey() string {
+	return e.Kind + ":" + e.Title
+}
+
+// updatePalettePreview shows the preview of the selected entry. Previews
+// that need git are loaded by the returned command, once per entry, so
+// typing and moving through results never waits for git.
+func (m *Model) updatePalettePreview() tea.Cmd {
+	p := m.palette
+	if p.selected >= len(p.results) {
+		p.preview = ""
+		return nil
+	}
+
+	e := p.results[p.selected]
+	if e.Kind == "command" {
+		keys := m.keys.KeysFor(ViewDashboard, e.Title)
+		p.preview = e.Detail
+		if len(keys) > 0 {
+			p.preview += "\nKeys: " + strings.Join(keys, ", ")
+		}
+		return nil
+	}
+
+	key := e.previewKey()
+	if preview, ok := p.previews[key]; ok {
+		p.preview = preview
+		return nil
+	}
+	p.preview = "Loading preview..."
+
+	g := m.git
+	return func() tea.Msg {
+		return palettePreviewMsg{key: key, preview: loadPalettePreview(g, e)}
+	}
+}
+
+// loadPalettePreview runs git for the preview of a branch, tag or file
+func loadPalettePreview(g *git.Git, e paletteEntry) string {
+	switch e.Kind {
+	case "branch":
+		out, err := g.Execute("log", "-5", "--oneline", e.Title, "--")
+		if err != nil {
+			out = err.Error()
+		}
+		return "Recent commits on " + e.Title + ":\n" + strings.TrimSpace(out)
+	case "tag":
+		out, err := g.Execute("show", "--no-patch", "--format=%h %s%n%an, %ar", e.Title, "--")
+		if err != nil {
+			out = err.Error()
+		}
+		return strings.TrimSpace(out)
+	case "file":
+		out, err := g.Execute("diff", "--stat", "HEAD", "--", e.Title)
+		if err != nil || strings.TrimSpace(out) == "" {
+			out = "untracked or unchanged"
+		}
+		return strings.TrimSpace(out)
+	}
+	return ""
+}
+
+// setPalettePreview stores a loaded preview, showing it while its entry
+// is still selected
+func (m *Model) setPalettePreview(msg palettePreviewMsg) {
+	p := m.palette
+	if p == nil {
+		return
+	}
+	p.previews[msg.key] = msg.preview
+	if p.selected < len(p.results) && p.results[p.selected].previewKey() == msg.key {
+		p.preview = msg.preview
+	}
+}
+
+// handlePaletteKeys handles keys while the palette is open
+func (m *Model) handlePaletteKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	p := m.palette
+	switch msg.String() {
+	case "esc":
+		m.palette = nil
+		m.currentView = m.tabs[m.activeTab].View
+		return m, nil
+	case "up", "ctrl+k", "ctrl+p":
+		if p.selected > 0 {
+			p.selected--
+			return m, m.updatePalettePreview()
+		}
+		return m, nil
+	case "down", "ctrl+j", "ctrl+n":
+		if p.selected < len(p.results)-1 {
+			p.selected++
+			return m, m.updatePalettePreview()
+		}
+		return m, nil
+	case "enter":
+		if p.selected >= len(p.results) {
+			return m, nil
+		}
+		entry := p.results[p.selected]
+		m.currentView = m.tabs[m.activeTab].View
+		cmd := entry.Run(m)
+		m.palette = nil
+		return m, cmd
+	}
+
+	var cmd tea.Cmd
+	p.input, cmd = p.input.Update(msg)
+	p.selected = 0
+	return m, tea.Batch(cmd, m.filterPalette())
+}
+
+// renderPalette renders the palette with results and a preview pane
+func (m *Model) renderPalette() string {
+	colors := m.config.Theme.Colors
+	style := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color(colors.Accent)).
+		Padding(0, 1)
+
+	kindStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(colors.Muted))
+	keyStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(colors.Tertiary))
+	selectedStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(colors.Highlight)).Bold(true)
+	normalStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(colors.Foreground))
+
+	lines := []string{m.palette.input.View(), ""}
+	for i, e := range m.palette.results {
+		title := normalStyle.Render(e.Title)
+		cursor := "  "
+		if i == m.palette.selected {
+			title = selectedStyle.Render(e.Title)
+			cursor = "▸ "
+		}
+
+		kind := e.Kind
+		if e.Recent {
+			kind = "recent"
+		}
+
+		line := fmt.Sprintf("%s%s %s", cursor, kindStyle.Render(fmt.Sprintf("%-8s", kind)), title)
+		if e.Kind == "command" {
+			if keys := m.keys.KeysFor(ViewDashboard, e.Title); len(keys) > 0 {
+				line += " " + keyStyle.Render("["+strings.Join(keys, ", ")+"]")
+			}
+		}
+		if e.Detail != "" {
+			line += " " + kindStyle.Render(e.Detail)
+		}
+		lines = append(lines, line)
+	}
+	if len(m.palette.results) == 0 {
+		lines = append(lines, kindStyle.Render("  No matches"))
+	}
+
+	list := strings.Join(lines, "\n")
+	if m.palette.preview == "" {
+		return style.Render(list)
+	}
+
+	previewStyle := lipgloss.NewStyle().
+		Border(lipgloss.NormalBorder(), false, false, false, true).
+		BorderForeground(lipgloss.Color(colors.Border)).
+		Foreground(lipgloss.Color(colors.Muted)).
+		PaddingLeft(1).
+		MarginLeft(2)
+
+	return style.Render(lipgloss.JoinHorizontal(lipgloss.Top, list, previewStyle.Render(m.palette.preview)))
+}
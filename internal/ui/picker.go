@@ -0,0 +1,106 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// pickerItem is a selectable row in a picker
+type pickerItem struct {
+	Label   string
+	Detail  string // Shown below the selected row, e.g. a hunk
+	Checked bool
+}
+
+// picker lets the user select several items, e.g. files or hunks
+type picker struct {
+	title    string
+	items    []pickerItem
+	selected int
+	done     func(m *Model, chosen []int) tea.Cmd
+}
+
+// openPicker shows a picker; done receives the indexes of checked items
+func (m *Model) openPicker(title string, items []pickerItem, done func(m *Model, chosen []int) tea.Cmd) {
+	m.picker = &picker{title: title, items: items, done: done}
+	m.currentView = ViewPicker
+}
+
+// handlePickerKeys handles picker actions: toggle-stage toggles the
+// current item, select confirms and back cancels
+func (m *Model) handlePickerKeys(action string) (tea.Model, tea.Cmd) {
+	p := m.picker
+	switch action {
+	case ActionUp:
+		if p.selected > 0 {
+			p.selected--
+		}
+	case ActionDown:
+		if p.selected < len(p.items)-1 {
+			p.selected++
+		}
+	case ActionToggleStage:
+		if p.selected < len(p.items) {
+			p.items[p.selected].Checked = !p.items[p.selected].Checked
+		}
+	case ActionSelect:
+		var chosen []int
+		for i, item := range p.items {
+			if item.Checked {
+				chosen = append(chosen, i)
+			}
+		}
+		m.picker = nil
+		m.currentView = m.tabs[m.activeTab].View
+		if len(chosen) == 0 {
+			m.successMsg = "Nothing selected"
+			return m, nil
+		}
+		return m, p.done(m, chosen)
+	case ActionBack:
+		m.picker = nil
+		m.currentView = m.tabs[m.activeTab].View
+	}
+	return m, nil
+}
+
+// renderPicker renders the open picker
+func (m *Model) renderPicker() string {
+	colors := m.config.Theme.Colors
+	style := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color(colors.Accent)).
+		Padding(1)
+
+	titleStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(colors.Primary)).Bold(true)
+	selectedStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(colors.Highlight)).Bold(true)
+	mutedStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(colors.Muted))
+
+	toggle := strings.Join(m.keys.KeysFor(ViewPicker, ActionToggleStage), "/")
+	lines := []string{
+		titleStyle.Render(m.picker.title),
+		mutedStyle.Render(fmt.Sprintf("%s toggle · enter confirm · esc cancel", toggle)),
+		"",
+	}
+	for i, item := range m.picker.items {
+		box := "[ ]"
+		if item.Checked {
+			box = "[x]"
+		}
+
+		line := fmt.Sprintf("  %s %s", box, item.Label)
+		if i == m.picker.selected {
+			line = selectedStyle.Render(fmt.Sprintf("▸ %s %s", box, item.Label))
+		}
+		lines = append(lines, line)
+
+		if i == m.picker.selected && item.Detail != "" {
+			lines = append(lines, mutedStyle.Render(item.Detail))
+		}
+	}
+
+	return style.Render(strings.Join(lines, "\n"))
+}
@@ -0,0 +1,176 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/gitflow/tui/internal/config"
+	"github.com/gitflow/tui/internal/git"
+	"github.com/gitflow/tui/internal/plugin"
+)
+
+// pluginsLoadedMsg carries the plugins started in the background and the
+// ones that failed to start
+type pluginsLoadedMsg struct {
+	plugins  *plugin.Manager
+	problems []error
+}
+
+// loadPlugins starts the plugins in the background so slow ones do not
+// hold up startup. Plugins query whichever repository is open.
+func (m *Model) loadPlugins() tea.Cmd {
+	repoPath := m.repoPath
+	return func() tea.Msg {
+		host := plugin.GitHost{Git: func() *git.Git { return m.git }}
+		plugins, problems := plugin.Load(config.PluginsDir(), repoPath, host)
+		return pluginsLoadedMsg{plugins, problems}
+	}
+}
+
+// addPlugins adds the tabs and commands of the loaded plugins and reports
+// problems with them and with the key bindings
+func (m *Model) addPlugins(msg pluginsLoadedMsg) {
+	m.plugins = msg.plugins
+	m.tabs = append(m.tabs, pluginTabs(m.plugins)...)
+
+	keys, keyErrs := m.bindKeys()
+	m.keys = keys
+	problems := append(msg.problems, keyErrs...)
+	if len(problems) > 0 {
+		m.errorMsg = problems[0].Error()
+		if len(problems) > 1 {
+			m.errorMsg += fmt.Sprintf(" (and %d more problems)", len(problems)-1)
+		}
+	}
+}
+
+// bindKeys builds the keymap for all commands from the defaults and the
+// configured overrides
+func (m *Model) bindKeys() (*Keymap, []error) {
+	keys, problems := NewKeymap(m.config.Keybindings, m.commands())
+	for _, cmd := range AvailableCommands() {
+		for _, cc := range m.config.CustomCommands {
+			if cc.Name == cmd.Name {
+				problems = append(problems, fmt.Errorf("custom_commands: %q is a built-in command", cc.Name))
+			}
+		}
+	}
+	return keys, problems
+}
+
+// pluginCommands converts the commands registered by plugins to UI
+// commands named "<plugin>.<command>"
+func pluginCommands(mgr *plugin.Manager) []Command {
+	var commands []Command
+	for _, p := range mgr.Plugins() {
+		for _, info := range p.Manifest.Commands {
+			pluginName, command := p.Name(), info.Name
+			commands = append(commands, Command{
+				Name:        pluginName + "." + command,
+				Description: info.Description,
+				Key:         info.Key,
+				Action: func(m *Model) tea.Cmd {
+					return cmdPlugin(m, pluginName, command)
+				},
+			})
+		}
+	}
+	return commands
+}
+
+// pluginTabs returns the tabs added by plugins. The tab key is
+// "<plugin>/<tab id>".
+func pluginTabs(mgr *plugin.Manager) []Tab {
+	var tabs []Tab
+	for _, p := range mgr.Plugins() {
+		for _, t := range p.Manifest.Tabs {
+			tabs = append(tabs, Tab{t.Title, ViewPlugin, p.Name() + "/" + t.ID, 0})
+		}
+	}
+	return tabs
+}
+
+// cmdPlugin runs a plugin command with the current selection
+func cmdPlugin(m *Model, pluginName, command string) tea.Cmd {
+	return func() tea.Msg {
+		result, err := m.plugins.RunCommand(pluginName, command, m.pluginContext())
+		if err != nil {
+			m.errorMsg = err.Error()
+			return nil
+		}
+
+		if result.Output != "" {
+			m.outputTitle = pluginName + "." + command
+			m.outputContent = result.Output
+			m.currentView = ViewOutput
+		}
+		if result.Message != "" {
+			m.successMsg = result.Message
+		}
+		return refreshMsg{}
+	}
+}
+
+// pluginContext describes the current selection to plugins
+func (m *Model) pluginContext() plugin.Context {
+	ctx := m.commandContext()
+	return plugin.Context{
+		RepoPath:      ctx.RepoPath,
+		CurrentBranch: ctx.CurrentBranch,
+		Commit:        ctx.Commit.Hash,
+		Branch:        ctx.Branch,
+		File:          ctx.File,
+	}
+}
+
+// selectTab switches to tab i, fetching plugin tab content if needed
+func (m *Model) selectTab(i int) tea.Cmd {
+	m.activeTab = i
+	m.currentView = m.tabs[i].View
+	// Switching tabs leaves the blame, file history and reflog views
+	m.blame, m.history, m.reflog, m.browser = nil, nil, nil, 0
+	if m.currentView != ViewPlugin {
+		return nil
+	}
+
+	return func() tea.Msg {
+		m.refreshPluginTab()
+		return nil
+	}
+}
+
+// refreshPluginTab asks the plugin owning the active tab for its content
+func (m *Model) refreshPluginTab() {
+	key := m.tabs[m.activeTab].Key
+	pluginName, id, _ := strings.Cut(key, "/")
+
+	content, err := m.plugins.RenderTab(pluginName, id, m.width, m.height)
+	if err != nil {
+		content = err.Error()
+	}
+	if m.pluginContent == nil {
+		m.pluginContent = make(map[string]string)
+	}
+	m.pluginContent[key] = content
+}
+
+// renderPluginTab renders the last content of the active plugin tab
+func (m *Model) renderPluginTab() string {
+	style := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color(m.config.Theme.Colors.Border)).
+		Padding(1)
+
+	content, ok := m.pluginContent[m.tabs[m.activeTab].Key]
+	if !ok {
+		content = "Loading..."
+	}
+	return style.Render(content)
+}
+
+// emit notifies plugins of a repository event
+func (m *Model) emit(event string, data map[string]interface{}) {
+	m.plugins.Emit(event, data)
+}
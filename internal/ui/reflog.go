@@ -0,0 +1,281 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/gitflow/tui/internal/git"
+)
+
+// reflogDangling is the pseudo ref listing commits no ref reaches
+const reflogDangling = "dangling"
+
+// reflogLimit is the number of reflog entries loaded per ref
+const reflogLimit = 200
+
+// reflogView browses the reflog of HEAD and each branch, and the
+// dangling commits
+type reflogView struct {
+	refs     []string
+	ref      int
+	entries  []git.ReflogEntry
+	selected int
+}
+
+// cmdReflog opens the reflog of HEAD
+func cmdReflog(m *Model) tea.Cmd {
+	return func() tea.Msg {
+		m.openReflog("HEAD")
+		return nil
+	}
+}
+
+// cmdDanglingCommits lists commits no ref reaches
+func cmdDanglingCommits(m *Model) tea.Cmd {
+	return func() tea.Msg {
+		m.openReflog(reflogDangling)
+		return nil
+	}
+}
+
+// openReflog opens the reflog browser on ref
+func (m *Model) openReflog(ref string) {
+	refs := []string{"HEAD"}
+	for _, b := range m.branches {
+		refs = append(refs, b.Name)
+	}
+	refs = append(refs, reflogDangling)
+
+	r := &reflogView{refs: refs}
+	for i, name := range refs {
+		if name == ref {
+			r.ref = i
+		}
+	}
+	if err := m.loadReflog(r); err != nil {
+		m.errorMsg = err.Error()
+		return
+	}
+	m.reflog = r
+	m.browser = ViewReflog
+	m.currentView = ViewReflog
+}
+
+// loadReflog loads the entries of r's current ref
+func (m *Model) loadReflog(r *reflogView) error {
+	ref := r.refs[r.ref]
+	r.selected = 0
+	if ref != reflogDangling {
+		entries, err := m.git.Reflog(ref, reflogLimit)
+		r.entries = entries
+		return err
+	}
+
+	commits, err := m.git.DanglingCommits()
+	if err != nil {
+		return err
+	}
+	r.entries = nil
+	for _, c := range commits {
+		r.entries = append(r.entries, git.ReflogEntry{
+			Hash:      c.Hash,
+			ShortHash: c.ShortHash,
+			Date:      c.Date,
+			Action:    "dangling",
+			Message:   c.Message,
+		})
+	}
+	m.successMsg = fmt.Sprintf("%d dangling commit(s)", len(commits))
+	return nil
+}
+
+// handleReflogKeys handles reflog browser actions; left and right switch
+// between refs
+func (m *Model) handleReflogKeys(action string) (tea.Model, tea.Cmd) {
+	r := m.reflog
+	switch action {
+	case ActionUp:
+		if r.selected > 0 {
+			r.selected--
+		}
+	case ActionDown:
+		if r.selected < len(r.entries)-1 {
+			r.selected++
+		}
+	case ActionTop:
+		r.selected = 0
+	case ActionBottom:
+		r.selected = max(0, len(r.entries)-1)
+	case ActionLeft, ActionRight:
+		step := 1
+		if action == ActionLeft {
+			step = len(r.refs) - 1
+		}
+		r.ref = (r.ref + step) % len(r.refs)
+		if err := m.loadReflog(r); err != nil {
+			m.errorMsg = err.Error()
+			r.entries = nil
+		}
+	case ActionSelect:
+		if r.selected < len(r.entries) {
+			m.showReflogMenu(r.entries[r.selected])
+		}
+	}
+	return m, nil
+}
+
+// closeReflog leaves the reflog browser
+func (m *Model) closeReflog() {
+	m.reflog = nil
+	m.closeBrowser()
+}
+
+// showReflogMenu shows the recovery actions for an entry
+func (m *Model) showReflogMenu(e git.ReflogEntry) {
+	title := e.ShortHash + " " + e.Message
+	if e.Selector != "" {
+		title = e.Selector + ": " + title
+	}
+
+	reset := func(mode string) func(m *Model) tea.Cmd {
+		return func(m *Model) tea.Cmd {
+			run := func() tea.Cmd {
+				if err := m.git.Reset("--"+mode, e.Hash); err != nil {
+					m.errorMsg = err.Error()
+					return nil
+				}
+				m.successMsg = fmt.Sprintf("Reset (%s) %s to %s", mode, m.currentBranch, e.ShortHash)
+				m.reloadReflog()
+				return m.loadData()
+			}
+			if mode == "hard" {
+				m.confirm(fmt.Sprintf("Reset %s to %s and discard all uncommitted changes?", m.currentBranch, e.ShortHash), func() {
+					m.inputCmd = run()
+				})
+				return nil
+			}
+			return run()
+		}
+	}
+
+	m.openMenu(title, []menuItem{
+		{"d", "Show commit", func(m *Model) tea.Cmd {
+			m.showDiff(func() (string, error) { return m.git.ShowCommit(e.Hash) })
+			return nil
+		}},
+		{"c", "Check out (detached HEAD)", func(m *Model) tea.Cmd {
+			if err := m.git.Checkout(e.Hash, false); err != nil {
+				m.errorMsg = err.Error()
+				return nil
+			}
+			m.successMsg = "Checked out " + e.ShortHash
+			m.reloadReflog()
+			return m.loadData()
+		}},
+		{"b", "Create branch here...", func(m *Model) tea.Cmd {
+			m.prompt("branch-create", "New branch name...", "", func(name string) {
+				if name == "" {
+					return
+				}
+				if err := m.git.CreateBranch(name, e.Hash); err != nil {
+					m.errorMsg = err.Error()
+					return
+				}
+				m.successMsg = "Created " + name + " at " + e.ShortHash
+				m.inputCmd = m.loadData()
+			})
+			return nil
+		}},
+		{"s", "Reset current branch here (soft)", reset("soft")},
+		{"m", "Reset current branch here (mixed)", reset("mixed")},
+		{"h", "Reset current branch here (hard)", reset("hard")},
+	})
+}
+
+// reloadReflog reloads the open reflog after it changed
+func (m *Model) reloadReflog() {
+	if m.reflog == nil {
+		return
+	}
+	if err := m.loadReflog(m.reflog); err != nil {
+		m.errorMsg = err.Error()
+	}
+}
+
+// renderReflog renders the reflog browser
+func (m *Model) renderReflog() string {
+	colors := m.config.Theme.Colors
+	style := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color(colors.Border)).
+		Padding(1)
+
+	activeStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color(colors.Background)).
+		Background(lipgloss.Color(colors.Primary)).
+		Bold(true).
+		Padding(0, 1)
+	refStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(colors.Muted)).Padding(0, 1)
+	mutedStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(colors.Muted))
+	hashStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(colors.Tertiary))
+	selectedStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(colors.Highlight)).Bold(true)
+
+	r := m.reflog
+	var refs []string
+	for i, ref := range r.refs {
+		if i == r.ref {
+			refs = append(refs, activeStyle.Render(ref))
+		} else {
+			refs = append(refs, refStyle.Render(ref))
+		}
+	}
+
+	lines := []string{
+		strings.Join(refs, " "),
+		mutedStyle.Render("←/→ switch ref · enter recover · esc back"),
+		"",
+	}
+	if len(r.entries) == 0 {
+		lines = append(lines, mutedStyle.Render("No entries"))
+	}
+
+	start, end := m.visibleRange(r.selected, len(r.entries))
+	for i := start; i < end; i++ {
+		e := r.entries[i]
+		date := e.Date.Format("2006-01-02 15:04")
+		operation := e.Action
+		if e.Description != "" && e.Description != e.Message {
+			operation += ": " + e.Description
+		}
+
+		if i == r.selected {
+			lines = append(lines, selectedStyle.Render(fmt.Sprintf("▸ %-12s %s %s %s %s",
+				e.Selector, date, e.ShortHash, operation, e.Message)))
+			continue
+		}
+		lines = append(lines, fmt.Sprintf("  %-12s %s %s %s %s",
+			e.Selector, mutedStyle.Render(date), hashStyle.Render(e.ShortHash),
+			lipgloss.NewStyle().Foreground(lipgloss.Color(m.reflogActionColor(e.Action))).Render(operation),
+			e.Message))
+	}
+
+	return style.Render(strings.Join(lines, "\n"))
+}
+
+// reflogActionColor returns the color for a reflog operation, making
+// history-rewriting ones stand out
+func (m *Model) reflogActionColor(action string) string {
+	colors := m.config.Theme.Colors
+	switch {
+	case strings.HasPrefix(action, "reset"), action == "dangling":
+		return colors.Warning
+	case strings.HasPrefix(action, "rebase"), strings.HasPrefix(action, "commit (amend)"):
+		return colors.Accent
+	case strings.HasPrefix(action, "checkout"):
+		return colors.Secondary
+	default:
+		return colors.Primary
+	}
+}
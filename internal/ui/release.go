@@ -0,0 +1,149 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/gitflow/tui/internal/release"
+)
+
+// cmdRelease proposes the next release version
+func cmdRelease(m *Model) tea.Cmd {
+	return m.planRelease("")
+}
+
+// planRelease plans a release, a pre-release when pre is set, and shows
+// the release menu
+func (m *Model) planRelease(pre string) tea.Cmd {
+	return func() tea.Msg {
+		plan, err := release.PlanRelease(m.git, pre)
+		if err != nil {
+			m.errorMsg = err.Error()
+			return nil
+		}
+		if len(plan.Commits) == 0 && plan.Previous != "" {
+			m.successMsg = "No commits since " + plan.Previous
+			return nil
+		}
+		m.showReleaseMenu(plan, pre)
+		return nil
+	}
+}
+
+// showReleaseMenu shows the actions for a release plan
+func (m *Model) showReleaseMenu(plan *release.Plan, pre string) {
+	next := plan.Next.String()
+	since := "the first commit"
+	if plan.Since != "" {
+		since = plan.Since
+	}
+	title := fmt.Sprintf("Release %s (%s, %d commit(s) since %s)", next, plan.Bump, len(plan.Commits), since)
+
+	items := []menuItem{
+		{"n", "Preview notes", func(m *Model) tea.Cmd { return m.previewRelease(plan) }},
+		{"t", "Tag " + next, func(m *Model) tea.Cmd { return m.createRelease(plan, "") }},
+	}
+	if remote := m.defaultRemote(); remote != "" {
+		items = append(items, menuItem{"p", "Tag " + next + " and push to " + remote,
+			func(m *Model) tea.Cmd { return m.createRelease(plan, remote) }})
+	}
+	items = append(items,
+		menuItem{"e", "Edit version...", func(m *Model) tea.Cmd { return m.editRelease(plan, pre) }},
+		menuItem{"r", "Pre-release...", func(m *Model) tea.Cmd { return m.preRelease(pre) }},
+	)
+	if pre != "" {
+		items = append(items, menuItem{"s", "Stable release", func(m *Model) tea.Cmd { return m.planRelease("") }})
+	}
+
+	m.openMenu(title, items)
+}
+
+// previewRelease shows the release notes in the output panel
+func (m *Model) previewRelease(plan *release.Plan) tea.Cmd {
+	return func() tea.Msg {
+		m.outputTitle = "Release notes for " + plan.Next.String()
+		m.outputContent = plan.Notes()
+		m.currentView = ViewOutput
+		return nil
+	}
+}
+
+// editRelease asks for the version to release instead of the proposed one
+func (m *Model) editRelease(plan *release.Plan, pre string) tea.Cmd {
+	return func() tea.Msg {
+		m.prompt("release-version", "Version to release...", plan.Next.String(), func(value string) {
+			v, ok := release.ParseVersion(strings.TrimSpace(value))
+			if !ok {
+				m.errorMsg = "Not a semantic version: " + value
+				return
+			}
+			plan.Next = v
+			m.showReleaseMenu(plan, pre)
+		})
+		return nil
+	}
+}
+
+// preRelease asks for the pre-release identifier and plans again
+func (m *Model) preRelease(pre string) tea.Cmd {
+	return func() tea.Msg {
+		if pre == "" {
+			pre = "rc"
+		}
+		m.prompt("release-pre", "Pre-release identifier (alpha, beta, rc)...", pre, func(value string) {
+			value = strings.TrimSpace(value)
+			if value == "" {
+				return
+			}
+			m.inputCmd = m.planRelease(value)
+		})
+		return nil
+	}
+}
+
+// createRelease creates the release tag after confirmation
+func (m *Model) createRelease(plan *release.Plan, remote string) tea.Cmd {
+	return func() tea.Msg {
+		next := plan.Next.String()
+		question := "Create release tag " + next + "?"
+		if remote != "" {
+			question = fmt.Sprintf("Create release tag %s and push it to %s?", next, remote)
+		}
+
+		m.confirm(question, func() {
+			if err := release.Create(m.git, plan, remote); err != nil {
+				m.errorMsg = err.Error()
+				m.inputCmd = m.loadData()
+				return
+			}
+			if remote != "" {
+				m.successMsg = fmt.Sprintf("Released %s to %s", next, remote)
+			} else {
+				m.successMsg = "Tagged " + next
+			}
+			m.inputCmd = m.loadData()
+		})
+		return nil
+	}
+}
+
+// cmdChangelog asks for a range and previews its changelog
+func cmdChangelog(m *Model) tea.Cmd {
+	return func() tea.Msg {
+		from, _ := m.git.LatestTag("HEAD")
+		m.prompt("changelog", "Range to summarize (from..to)...", from+"..HEAD", func(value string) {
+			from, to, _ := strings.Cut(strings.TrimSpace(value), "..")
+			changelog, err := release.Generate(m.git, from, to)
+			if err != nil {
+				m.errorMsg = err.Error()
+				return
+			}
+
+			m.outputTitle = "Changelog " + changelog.From + ".." + changelog.To
+			m.outputContent = changelog.Markdown()
+			m.currentView = ViewOutput
+		})
+		return nil
+	}
+}
@@ -0,0 +1,265 @@
+package ui
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/gitflow/tui/internal/auth"
+	"github.com/gitflow/tui/internal/git"
+)
+
+// handleRemoteKeys handles remotes view actions
+func (m *Model) handleRemoteKeys(action string) (tea.Model, tea.Cmd) {
+	switch action {
+	case ActionUp:
+		if m.selectedRemote > 0 {
+			m.selectedRemote--
+		}
+	case ActionDown:
+		if m.selectedRemote < len(m.remoteConfigs)-1 {
+			m.selectedRemote++
+		}
+	case ActionSelect:
+		m.showRemoteMenu()
+	}
+	return m, nil
+}
+
+// showRemoteMenu shows the actions for the selected remote
+func (m *Model) showRemoteMenu() {
+	items := []menuItem{
+		{"a", "Add remote", cmdRemoteAdd},
+	}
+
+	title := "Remotes"
+	if m.selectedRemote < len(m.remoteConfigs) {
+		r := m.remoteConfigs[m.selectedRemote]
+		name := r.Name
+		title = "Remote " + name
+		items = append(items,
+			menuItem{"r", "Rename", func(m *Model) tea.Cmd { return m.renameRemote(name) }},
+			menuItem{"d", "Remove", func(m *Model) tea.Cmd { return m.removeRemote(name) }},
+			menuItem{"u", "Set fetch URL", func(m *Model) tea.Cmd { return m.setRemoteURL(r, false) }},
+			menuItem{"U", "Set push URL", func(m *Model) tea.Cmd { return m.setRemoteURL(r, true) }},
+			menuItem{"f", "Edit fetch refspecs", func(m *Model) tea.Cmd { return m.setRemoteRefspecs(r, false) }},
+			menuItem{"F", "Edit push refspecs", func(m *Model) tea.Cmd { return m.setRemoteRefspecs(r, true) }},
+			menuItem{"p", "Prune stale tracking branches", func(m *Model) tea.Cmd { return m.pruneRemote(name) }},
+			menuItem{"t", "Test connectivity", func(m *Model) tea.Cmd { return m.testRemotes([]git.RemoteConfig{r}) }},
+		)
+	}
+	if len(m.remoteConfigs) > 0 {
+		items = append(items, menuItem{"T", "Test all remotes", cmdRemoteTest})
+	}
+
+	m.openMenu(title, items)
+}
+
+// cmdRemoteAdd asks for the name and URL of a new remote
+func cmdRemoteAdd(m *Model) tea.Cmd {
+	return func() tea.Msg {
+		m.prompt("remote-add", "Remote name...", "", func(name string) {
+			if name == "" {
+				return
+			}
+			m.prompt("remote-url", "URL for "+name+"...", "", func(url string) {
+				if url == "" {
+					return
+				}
+				if err := m.git.AddRemote(name, url); err != nil {
+					m.errorMsg = err.Error()
+					return
+				}
+				m.successMsg = "Added remote " + name
+				m.inputCmd = m.loadData()
+			})
+		})
+		return nil
+	}
+}
+
+// renameRemote asks for a new remote name
+func (m *Model) renameRemote(name string) tea.Cmd {
+	return func() tea.Msg {
+		m.prompt("remote-rename", "New name for "+name+"...", name, func(newName string) {
+			if newName == "" || newName == name {
+				return
+			}
+			if err := m.git.RenameRemote(name, newName); err != nil {
+				m.errorMsg = err.Error()
+				return
+			}
+			m.successMsg = fmt.Sprintf("Renamed %s to %s", name, newName)
+			m.inputCmd = m.loadData()
+		})
+		return nil
+	}
+}
+
+// removeRemote removes a remote after confirmation
+func (m *Model) removeRemote(name string) tea.Cmd {
+	return func() tea.Msg {
+		m.confirm("Remove remote "+name+" and its tracking branches?", func() {
+			if err := m.git.RemoveRemote(name); err != nil {
+				m.errorMsg = err.Error()
+				return
+			}
+			m.selectedRemote = 0
+			m.successMsg = "Removed remote " + name
+			m.inputCmd = m.loadData()
+		})
+		return nil
+	}
+}
+
+// setRemoteURL asks for the fetch or push URL of a remote
+func (m *Model) setRemoteURL(r git.RemoteConfig, push bool) tea.Cmd {
+	return func() tea.Msg {
+		kind, initial := "Fetch URL", r.FetchURL
+		if push {
+			kind, initial = "Push URL (empty to use the fetch URL)", r.PushURL()
+		}
+
+		m.prompt("remote-url", kind+" for "+r.Name+"...", initial, func(url string) {
+			url = strings.TrimSpace(url)
+			if url == "" && !push {
+				return
+			}
+			if err := m.git.SetRemoteURL(r.Name, url, push); err != nil {
+				m.errorMsg = err.Error()
+				return
+			}
+			m.successMsg = "Updated " + r.Name
+			m.inputCmd = m.loadData()
+		})
+		return nil
+	}
+}
+
+// setRemoteRefspecs asks for the space-separated fetch or push refspecs
+func (m *Model) setRemoteRefspecs(r git.RemoteConfig, push bool) tea.Cmd {
+	return func() tea.Msg {
+		kind, current := "Fetch refspecs", r.FetchRefspecs
+		if push {
+			kind, current = "Push refspecs", r.PushRefspecs
+		}
+
+		m.prompt("remote-refspecs", kind+" for "+r.Name+" (space-separated)...", strings.Join(current, " "), func(value string) {
+			if err := m.git.SetRemoteRefspecs(r.Name, strings.Fields(value), push); err != nil {
+				m.errorMsg = err.Error()
+				return
+			}
+			m.successMsg = "Updated " + r.Name + " refspecs"
+			m.inputCmd = m.loadData()
+		})
+		return nil
+	}
+}
+
+// pruneRemote lists stale remote-tracking branches and prunes them
+// after confirmation
+func (m *Model) pruneRemote(name string) tea.Cmd {
+	return func() tea.Msg {
+		stale, err := m.git.PruneRemote(name, true)
+		if err != nil {
+			m.errorMsg = err.Error()
+			return nil
+		}
+		if len(stale) == 0 {
+			m.successMsg = "No stale branches on " + name
+			return nil
+		}
+
+		m.confirm(fmt.Sprintf("Prune %s?", strings.Join(stale, ", ")), func() {
+			pruned, err := m.git.PruneRemote(name, false)
+			if err != nil {
+				m.errorMsg = err.Error()
+				return
+			}
+			m.successMsg = fmt.Sprintf("Pruned %d branch(es) from %s", len(pruned), name)
+			m.inputCmd = m.loadData()
+		})
+		return nil
+	}
+}
+
+// cmdRemoteTest tests connectivity to every remote
+func cmdRemoteTest(m *Model) tea.Cmd {
+	return m.testRemotes(m.remoteConfigs)
+}
+
+// testRemotes tests the fetch and push URLs of remotes, streaming the
+// results
+func (m *Model) testRemotes(remotes []git.RemoteConfig) tea.Cmd {
+	mgr, err := auth.New()
+	if err != nil {
+		m.errorMsg = err.Error()
+		return nil
+	}
+
+	repoPath := m.repoPath
+	failed := 0
+	return m.stream("Remote connectivity", func(w io.Writer) error {
+		test := func(name, kind, url string) {
+			if err := mgr.TestAuth(repoPath, url); err != nil {
+				failed++
+				fmt.Fprintf(w, "✗ %s (%s) %s\n  %s\n", name, kind, url, strings.TrimSpace(err.Error()))
+			} else {
+				fmt.Fprintf(w, "✓ %s (%s) %s\n", name, kind, url)
+			}
+		}
+		for _, r := range remotes {
+			test(r.Name, "fetch", r.FetchURL)
+			if r.PushURL() != r.FetchURL {
+				test(r.Name, "push", r.PushURL())
+			}
+		}
+		return nil
+	}, func(m *Model, err error) {
+		if failed > 0 {
+			m.errorMsg = fmt.Sprintf("%d connectivity check(s) failed", failed)
+		} else {
+			m.successMsg = "All remotes reachable"
+		}
+	})
+}
+
+// renderRemotes renders the remotes view
+func (m *Model) renderRemotes() string {
+	colors := m.config.Theme.Colors
+	style := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color(colors.Border)).
+		Padding(1)
+
+	nameStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(colors.Primary)).Bold(true)
+	mutedStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(colors.Muted))
+
+	if len(m.remoteConfigs) == 0 {
+		return style.Render(mutedStyle.Render("No remotes (press enter to add one)"))
+	}
+
+	var content strings.Builder
+	for i, r := range m.remoteConfigs {
+		cursor := "  "
+		if i == m.selectedRemote {
+			cursor = "▸ "
+		}
+		content.WriteString(cursor + nameStyle.Render(r.Name) + "\n")
+		content.WriteString(fmt.Sprintf("    fetch %s\n", r.FetchURL))
+		for _, url := range r.PushURLs {
+			content.WriteString(fmt.Sprintf("    push  %s\n", url))
+		}
+		for _, spec := range r.FetchRefspecs {
+			content.WriteString(mutedStyle.Render("    fetch refspec "+spec) + "\n")
+		}
+		for _, spec := range r.PushRefspecs {
+			content.WriteString(mutedStyle.Render("    push refspec  "+spec) + "\n")
+		}
+		content.WriteString("\n")
+	}
+
+	return style.Render(strings.TrimRight(content.String(), "\n"))
+}
@@ -0,0 +1,61 @@
+package ui
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/gitflow/tui/internal/git"
+)
+
+// graphLimit is the number of commits the Graph tab loads
+const graphLimit = 50
+
+// cmdSearchHistory asks for a search query and filters the Graph tab
+// with it; an empty query shows all commits again
+func cmdSearchHistory(m *Model) tea.Cmd {
+	return func() tea.Msg {
+		placeholder := "author:NAME since:DATE path:PATH S:TEXT main..feature --no-merges message regex"
+		m.prompt("search", placeholder, m.logFilter.String(), func(query string) {
+			filter, err := git.ParseLogFilter(query)
+			if err != nil {
+				m.errorMsg = err.Error()
+				return
+			}
+			m.setLogFilter(filter)
+		})
+		return nil
+	}
+}
+
+// setLogFilter reloads the Graph tab's commits with filter and shows it
+func (m *Model) setLogFilter(filter git.LogFilter) {
+	commits, err := m.git.FilterCommits(m.graphFilter(filter))
+	if err != nil {
+		m.errorMsg = err.Error()
+		return
+	}
+
+	m.logFilter = filter
+	m.commits = commits
+	m.selectedCommit = 0
+	m.selectTab(m.tabIndex(ViewGraph))
+
+	switch {
+	case filter.Empty():
+		m.successMsg = "Showing all commits"
+	case len(commits) == 0:
+		m.successMsg = "No commits match " + filter.String()
+	default:
+		m.successMsg = fmt.Sprintf("%d commit(s) match", len(commits))
+	}
+}
+
+// graphFilter returns the filter loading the Graph tab's commits; while
+// bisecting, history starts at the bad commit so the remaining range shows
+func (m *Model) graphFilter(filter git.LogFilter) git.LogFilter {
+	filter.Limit = graphLimit
+	if filter.Range == "" && m.bisect != nil && m.bisect.Bad != "" {
+		filter.Range = m.bisect.Bad
+	}
+	return filter
+}
@@ -0,0 +1,287 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/gitflow/tui/internal/git"
+)
+
+// handleStashKeys handles stash view actions
+func (m *Model) handleStashKeys(action string) (tea.Model, tea.Cmd) {
+	switch action {
+	case ActionUp:
+		if m.selectedStash > 0 {
+			m.selectedStash--
+		}
+	case ActionDown:
+		if m.selectedStash < len(m.stashes)-1 {
+			m.selectedStash++
+		}
+	case ActionSelect:
+		m.showStashMenu()
+	}
+	return m, nil
+}
+
+// showStashMenu shows ways to stash and the actions for the selected entry
+func (m *Model) showStashMenu() {
+	items := []menuItem{
+		{"w", "Stash all changes", func(m *Model) tea.Cmd { return m.stashWith(git.StashOptions{}) }},
+		{"u", "Stash all including untracked", func(m *Model) tea.Cmd { return m.stashWith(git.StashOptions{IncludeUntracked: true}) }},
+		{"i", "Stash all, keeping staged changes (index)", func(m *Model) tea.Cmd { return m.stashWith(git.StashOptions{KeepIndex: true}) }},
+		{"f", "Stash selected files...", cmdStashFiles},
+		{"h", "Stash selected hunks...", cmdStashHunks},
+	}
+
+	title := "Stash"
+	if m.selectedStash < len(m.stashes) {
+		s := m.stashes[m.selectedStash]
+		title = fmt.Sprintf("stash@{%d}: %s", s.Index, s.Message)
+		items = append(items,
+			menuItem{"s", "Show", func(m *Model) tea.Cmd { return m.showStash(s) }},
+			menuItem{"a", "Apply", func(m *Model) tea.Cmd { return m.applyStash(s, false) }},
+			menuItem{"p", "Pop", func(m *Model) tea.Cmd { return m.applyStash(s, true) }},
+			menuItem{"d", "Drop", func(m *Model) tea.Cmd { return m.dropStash(s) }},
+			menuItem{"b", "Branch from stash", func(m *Model) tea.Cmd { return m.branchFromStash(s) }},
+			menuItem{"r", "Rename", func(m *Model) tea.Cmd { return m.renameStash(s) }},
+		)
+	}
+
+	m.openMenu(title, items)
+}
+
+// stashWith asks for an optional message and stashes with opts
+func (m *Model) stashWith(opts git.StashOptions) tea.Cmd {
+	return func() tea.Msg {
+		m.prompt("stash", "Enter stash message (optional)...", "", func(value string) {
+			opts.Message = value
+			if err := m.git.StashPush(opts); err != nil {
+				m.errorMsg = err.Error()
+				return
+			}
+			m.successMsg = "Changes stashed"
+			m.inputCmd = m.loadData()
+		})
+		return nil
+	}
+}
+
+// cmdStashFiles stashes the files picked from the changed files
+func cmdStashFiles(m *Model) tea.Cmd {
+	return func() tea.Msg {
+		if m.status == nil {
+			m.errorMsg = "No changes to stash"
+			return nil
+		}
+
+		type file struct {
+			path      string
+			untracked bool
+		}
+		var files []file
+		seen := make(map[string]bool)
+		for _, f := range append(append([]git.FileStatus{}, m.status.Staged...), m.status.Unstaged...) {
+			if !seen[f.Path] {
+				seen[f.Path] = true
+				files = append(files, file{f.Path, false})
+			}
+		}
+		for _, path := range m.status.Untracked {
+			files = append(files, file{path, true})
+		}
+		if len(files) == 0 {
+			m.errorMsg = "No changes to stash"
+			return nil
+		}
+
+		var items []pickerItem
+		for _, f := range files {
+			label := f.path
+			if f.untracked {
+				label += " (untracked)"
+			}
+			items = append(items, pickerItem{Label: label})
+		}
+
+		m.openPicker("Stash files", items, func(m *Model, chosen []int) tea.Cmd {
+			var opts git.StashOptions
+			for _, i := range chosen {
+				opts.Paths = append(opts.Paths, files[i].path)
+				opts.IncludeUntracked = opts.IncludeUntracked || files[i].untracked
+			}
+			return m.stashWith(opts)
+		})
+		return nil
+	}
+}
+
+// cmdStashHunks stashes the hunks picked from the unstaged changes
+func cmdStashHunks(m *Model) tea.Cmd {
+	return func() tea.Msg {
+		hunks, err := m.git.GetHunks()
+		if err != nil {
+			m.errorMsg = err.Error()
+			return nil
+		}
+		if len(hunks) == 0 {
+			m.errorMsg = "No unstaged hunks to stash"
+			return nil
+		}
+
+		var items []pickerItem
+		for _, h := range hunks {
+			items = append(items, pickerItem{
+				Label:  h.Path + " " + h.Header,
+				Detail: strings.Join(h.Lines, "\n"),
+			})
+		}
+
+		m.openPicker("Stash hunks", items, func(m *Model, chosen []int) tea.Cmd {
+			var selected []git.Hunk
+			for _, i := range chosen {
+				selected = append(selected, hunks[i])
+			}
+			return func() tea.Msg {
+				m.prompt("stash", "Enter stash message (optional)...", "", func(value string) {
+					if err := m.git.StashHunks(value, selected); err != nil {
+						m.errorMsg = err.Error()
+						return
+					}
+					m.successMsg = fmt.Sprintf("Stashed %d hunk(s)", len(selected))
+					m.inputCmd = m.loadData()
+				})
+				return nil
+			}
+		})
+		return nil
+	}
+}
+
+// showStash shows the diff recorded in a stash entry
+func (m *Model) showStash(s git.Stash) tea.Cmd {
+	return func() tea.Msg {
+		diff, err := m.git.StashShow(s.Index)
+		if err != nil {
+			m.errorMsg = err.Error()
+			return nil
+		}
+		m.diffContent = diff
+		m.currentView = ViewDiff
+		return nil
+	}
+}
+
+// applyStash applies a stash entry, dropping it too when pop is set
+func (m *Model) applyStash(s git.Stash, pop bool) tea.Cmd {
+	return func() tea.Msg {
+		var err error
+		if pop {
+			err = m.git.StashPop(s.Index)
+		} else {
+			err = m.git.StashApply(s.Index)
+		}
+		if err != nil {
+			m.errorMsg = err.Error()
+			return nil
+		}
+
+		if pop {
+			m.successMsg = fmt.Sprintf("Popped stash@{%d}", s.Index)
+			m.selectedStash = 0
+		} else {
+			m.successMsg = fmt.Sprintf("Applied stash@{%d}", s.Index)
+		}
+		return refreshMsg{}
+	}
+}
+
+// dropStash drops a stash entry after confirmation
+func (m *Model) dropStash(s git.Stash) tea.Cmd {
+	return func() tea.Msg {
+		m.confirm(fmt.Sprintf("Drop stash@{%d} (%s)?", s.Index, s.Message), func() {
+			if err := m.git.StashDrop(s.Index); err != nil {
+				m.errorMsg = err.Error()
+				return
+			}
+			m.selectedStash = 0
+			m.successMsg = fmt.Sprintf("Dropped stash@{%d}", s.Index)
+			m.inputCmd = m.loadData()
+		})
+		return nil
+	}
+}
+
+// branchFromStash asks for a branch name and runs stash branch
+func (m *Model) branchFromStash(s git.Stash) tea.Cmd {
+	return func() tea.Msg {
+		m.prompt("stash-branch", "New branch for stash...", "", func(name string) {
+			if name == "" {
+				return
+			}
+			if err := m.git.StashBranch(name, s.Index); err != nil {
+				m.errorMsg = err.Error()
+				return
+			}
+			m.selectedStash = 0
+			m.successMsg = "Created " + name + " from stash"
+			m.inputCmd = m.loadData()
+		})
+		return nil
+	}
+}
+
+// renameStash asks for a new stash message
+func (m *Model) renameStash(s git.Stash) tea.Cmd {
+	return func() tea.Msg {
+		m.prompt("stash-rename", "New stash message...", s.Message, func(message string) {
+			if message == "" || message == s.Message {
+				return
+			}
+			if err := m.git.RenameStash(s, message); err != nil {
+				m.errorMsg = err.Error()
+				return
+			}
+			m.selectedStash = 0
+			m.successMsg = "Renamed stash"
+			m.inputCmd = m.loadData()
+		})
+		return nil
+	}
+}
+
+// renderStash renders the stash view
+func (m *Model) renderStash() string {
+	colors := m.config.Theme.Colors
+	style := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color(colors.Border)).
+		Padding(1)
+
+	mutedStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(colors.Muted))
+	branchStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(colors.Secondary))
+
+	if len(m.stashes) == 0 {
+		return style.Render(mutedStyle.Render("No stashes (press enter to stash changes)"))
+	}
+
+	var lines []string
+	for i, s := range m.stashes {
+		cursor := "  "
+		if i == m.selectedStash {
+			cursor = "▸ "
+		}
+		line := fmt.Sprintf("%sstash@{%d}: %s", cursor, s.Index, s.Message)
+		if s.Branch != "" {
+			line += " " + branchStyle.Render("on "+s.Branch)
+		}
+		if !s.Date.IsZero() {
+			line += " " + mutedStyle.Render(s.Date.Format("2006-01-02 15:04"))
+		}
+		lines = append(lines, line)
+	}
+
+	return style.Render(strings.Join(lines, "\n"))
+}
@@ -0,0 +1,148 @@
+package ui
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/gitflow/tui/internal/git"
+)
+
+// cmdSubmodules lists the submodules, nested ones included
+func cmdSubmodules(m *Model) tea.Cmd {
+	return func() tea.Msg {
+		m.showSubmodulesMenu()
+		return nil
+	}
+}
+
+// showSubmodulesMenu lists the submodules and the actions on all of them
+func (m *Model) showSubmodulesMenu() {
+	subs, err := m.git.GetSubmodules(true)
+	if err != nil {
+		m.errorMsg = err.Error()
+		return
+	}
+	parent, _ := m.git.Superproject()
+
+	var items []menuItem
+	for i, s := range subs {
+		sub := s
+		key := ""
+		if i < 9 {
+			key = fmt.Sprint(i + 1)
+		}
+		items = append(items, menuItem{key, submoduleLabel(sub), func(m *Model) tea.Cmd {
+			m.showSubmoduleMenu(sub)
+			return nil
+		}})
+	}
+	if len(subs) > 0 {
+		items = append(items,
+			menuItem{"u", "Update all (init and recurse)", func(m *Model) tea.Cmd {
+				return m.updateSubmodules(true)
+			}},
+			menuItem{"y", "Sync all URLs from .gitmodules", func(m *Model) tea.Cmd {
+				return m.syncSubmodules()
+			}},
+		)
+	}
+	if parent != "" {
+		items = append(items, menuItem{"P", "Back to parent repository " + parent, func(m *Model) tea.Cmd {
+			return m.switchRepository(parent)
+		}})
+	}
+	if len(items) == 0 {
+		m.successMsg = "No submodules"
+		return
+	}
+
+	m.openMenu("Submodules", items)
+}
+
+// submoduleLabel describes a submodule and its state, indented by depth
+func submoduleLabel(s git.Submodule) string {
+	label := strings.Repeat("  ", s.Depth) + s.Path
+	switch {
+	case !s.Initialized:
+		return label + " (not initialized)"
+	case s.Conflict:
+		return label + " (conflict)"
+	case s.OutOfSync():
+		return fmt.Sprintf("%s %s (recorded %s)", label, short(s.Current), short(s.Recorded))
+	default:
+		return fmt.Sprintf("%s %s", label, short(s.Current))
+	}
+}
+
+// short abbreviates a commit hash
+func short(hash string) string {
+	return hash[:min(len(hash), 7)]
+}
+
+// showSubmoduleMenu shows the actions for a submodule
+func (m *Model) showSubmoduleMenu(s git.Submodule) {
+	var items []menuItem
+	if s.Initialized {
+		items = append(items, menuItem{"e", "Enter as repository", func(m *Model) tea.Cmd {
+			return m.switchRepository(filepath.Join(m.repoPath, s.Path))
+		}})
+	}
+	if s.OutOfSync() {
+		items = append(items, menuItem{"d", "Show commits since the recorded one", func(m *Model) tea.Cmd {
+			m.showDiff(func() (string, error) { return m.git.GetDiff(false, "--", s.Path) })
+			return nil
+		}})
+	}
+	items = append(items, menuItem{"u", "Update to the recorded commit", func(m *Model) tea.Cmd {
+		return m.updateSubmodules(!s.Initialized, s.Path)
+	}})
+	if !s.Initialized {
+		items = append(items, menuItem{"i", "Init without cloning", func(m *Model) tea.Cmd {
+			if err := m.git.InitSubmodules(s.Path); err != nil {
+				m.errorMsg = err.Error()
+				return nil
+			}
+			m.successMsg = "Initialized " + s.Path
+			return nil
+		}})
+	}
+
+	title := s.Path
+	if s.URL != "" {
+		title += "\n" + s.URL
+	}
+	if s.Branch != "" {
+		title += " (" + s.Branch + ")"
+	}
+	m.openMenu(title, items)
+}
+
+// updateSubmodules checks out the recorded commits of the submodules at
+// paths, or all of them, recursively, streaming git's progress; with init
+// missing submodules are cloned
+func (m *Model) updateSubmodules(init bool, paths ...string) tea.Cmd {
+	return m.stream("Updating submodules", func(w io.Writer) error {
+		return m.git.UpdateSubmodules(w, init, true, paths...)
+	}, func(m *Model, err error) {
+		if err != nil {
+			m.errorMsg = "Submodule update failed: " + err.Error()
+		} else {
+			m.successMsg = "Submodules updated"
+		}
+	})
+}
+
+// syncSubmodules copies the submodule URLs from .gitmodules
+func (m *Model) syncSubmodules() tea.Cmd {
+	return func() tea.Msg {
+		if err := m.git.SyncSubmodules(true); err != nil {
+			m.errorMsg = err.Error()
+			return nil
+		}
+		m.successMsg = "Synced submodule URLs"
+		return nil
+	}
+}
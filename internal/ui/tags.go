@@ -0,0 +1,290 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/gitflow/tui/internal/git"
+)
+
+// handleTagKeys handles tags view actions
+func (m *Model) handleTagKeys(action string) (tea.Model, tea.Cmd) {
+	switch action {
+	case ActionUp:
+		if m.selectedTag > 0 {
+			m.selectedTag--
+		}
+	case ActionDown:
+		if m.selectedTag < len(m.tags)-1 {
+			m.selectedTag++
+		}
+	case ActionSelect:
+		m.showTagMenu()
+	}
+	return m, nil
+}
+
+// showTagMenu shows the actions for the selected tag
+func (m *Model) showTagMenu() {
+	remote := m.defaultRemote()
+	items := []menuItem{
+		{"n", "Create tag", cmdTag},
+		{"N", "Create signed tag", cmdTagSigned},
+	}
+
+	title := "Tags"
+	if m.selectedTag < len(m.tags) {
+		t := m.tags[m.selectedTag]
+		title = "Tag " + t.Name
+		items = append(items,
+			menuItem{"s", "Show details", func(m *Model) tea.Cmd { return m.showTag(t) }},
+			menuItem{"d", "Delete", func(m *Model) tea.Cmd { return m.deleteTag(t.Name) }},
+		)
+		if remote != "" {
+			items = append(items,
+				menuItem{"p", "Push to " + remote, func(m *Model) tea.Cmd { return m.pushTag(remote, t.Name) }},
+				menuItem{"D", "Delete from " + remote, func(m *Model) tea.Cmd { return m.deleteRemoteTag(remote, t.Name) }},
+			)
+		}
+	}
+	if remote != "" {
+		items = append(items,
+			menuItem{"P", "Push all tags to " + remote, func(m *Model) tea.Cmd { return m.pushTags(remote) }},
+			menuItem{"c", "Compare with " + remote, func(m *Model) tea.Cmd { return m.compareTags(remote) }},
+		)
+	}
+
+	m.openMenu(title, items)
+}
+
+// defaultRemote returns origin, or the first remote when there is no origin
+func (m *Model) defaultRemote() string {
+	for _, r := range m.remotes {
+		if r.Name == "origin" {
+			return r.Name
+		}
+	}
+	if len(m.remotes) > 0 {
+		return m.remotes[0].Name
+	}
+	return ""
+}
+
+// showTag shows the details of a tag, verifying its signature
+func (m *Model) showTag(t git.Tag) tea.Cmd {
+	return func() tea.Msg {
+		var b strings.Builder
+		kind := "lightweight"
+		if t.Annotated {
+			kind = "annotated"
+		}
+		fmt.Fprintf(&b, "Tag:       %s (%s)\n", t.Name, kind)
+		fmt.Fprintf(&b, "Commit:    %s\n", t.Hash)
+		if t.Tagger != "" {
+			fmt.Fprintf(&b, "Tagger:    %s <%s>\n", t.Tagger, t.Email)
+		}
+		if !t.Date.IsZero() {
+			fmt.Fprintf(&b, "Date:      %s\n", t.Date.Format("2006-01-02 15:04"))
+		}
+		var verifyErr error
+		if t.Signature.Signed() {
+			t.Signature, verifyErr = m.git.VerifyTagSignature(t.Name)
+			m.setTagSignature(t.Name, t.Signature)
+		}
+		fmt.Fprintf(&b, "Signature: %s\n", t.Signature.Description())
+		if verifyErr != nil {
+			fmt.Fprintf(&b, "\n%s\n", strings.TrimSpace(verifyErr.Error()))
+		}
+
+		if out, err := m.git.Execute("show", "--no-patch", "--format=%h %s%n%an, %ar", t.Name, "--"); err == nil {
+			fmt.Fprintf(&b, "\n%s\n", strings.TrimSpace(out))
+		}
+
+		m.outputTitle = "Tag " + t.Name
+		m.outputContent = b.String()
+		m.currentView = ViewOutput
+		return nil
+	}
+}
+
+// setTagSignature records the verified signature of a tag for the list
+func (m *Model) setTagSignature(name string, status git.SignatureStatus) {
+	for i := range m.tags {
+		if m.tags[i].Name == name {
+			m.tags[i].Signature = status
+		}
+	}
+}
+
+// deleteTag deletes a local tag after confirmation
+func (m *Model) deleteTag(name string) tea.Cmd {
+	return func() tea.Msg {
+		m.confirm("Delete tag "+name+"?", func() {
+			if err := m.git.DeleteTag(name); err != nil {
+				m.errorMsg = err.Error()
+				return
+			}
+			m.selectedTag = 0
+			m.successMsg = "Deleted tag " + name
+			m.inputCmd = m.loadData()
+		})
+		return nil
+	}
+}
+
+// pushTag pushes a single tag
+func (m *Model) pushTag(remote, name string) tea.Cmd {
+	return func() tea.Msg {
+		if err := m.git.PushTag(remote, name); err != nil {
+			m.errorMsg = err.Error()
+			return nil
+		}
+		m.successMsg = fmt.Sprintf("Pushed %s to %s", name, remote)
+		m.refreshRemoteTags()
+		return nil
+	}
+}
+
+// pushTags pushes all tags after confirmation
+func (m *Model) pushTags(remote string) tea.Cmd {
+	return func() tea.Msg {
+		m.confirm(fmt.Sprintf("Push all %d tag(s) to %s?", len(m.tags), remote), func() {
+			if err := m.git.PushTags(remote); err != nil {
+				m.errorMsg = err.Error()
+				return
+			}
+			m.successMsg = "Pushed tags to " + remote
+			m.refreshRemoteTags()
+		})
+		return nil
+	}
+}
+
+// deleteRemoteTag deletes a tag on a remote after confirmation
+func (m *Model) deleteRemoteTag(remote, name string) tea.Cmd {
+	return func() tea.Msg {
+		m.confirm(fmt.Sprintf("Delete tag %s from %s?", name, remote), func() {
+			if err := m.git.DeleteRemoteTag(remote, name); err != nil {
+				m.errorMsg = err.Error()
+				return
+			}
+			m.successMsg = fmt.Sprintf("Deleted %s from %s", name, remote)
+			m.refreshRemoteTags()
+		})
+		return nil
+	}
+}
+
+// compareTags lists the tags on remote. Until the next comparison the
+// tags view marks tags that exist only locally or only on the remote.
+func (m *Model) compareTags(remote string) tea.Cmd {
+	return func() tea.Msg {
+		tags, err := m.git.GetRemoteTags(remote)
+		if err != nil {
+			m.errorMsg = err.Error()
+			return nil
+		}
+		m.remoteTags, m.remoteTagsFrom = tags, remote
+
+		sync := git.SyncTags(remote, m.tags, tags)
+		if len(sync.LocalOnly)+len(sync.RemoteOnly)+len(sync.Differ) == 0 {
+			m.successMsg = "Tags are in sync with " + remote
+		} else {
+			m.successMsg = fmt.Sprintf("%d local only, %d on %s only, %d differ",
+				len(sync.LocalOnly), len(sync.RemoteOnly), remote, len(sync.Differ))
+		}
+		return nil
+	}
+}
+
+// refreshRemoteTags lists the remote tags again after a push or delete
+// if a comparison is being shown
+func (m *Model) refreshRemoteTags() {
+	if m.remoteTagsFrom == "" {
+		return
+	}
+	if tags, err := m.git.GetRemoteTags(m.remoteTagsFrom); err == nil {
+		m.remoteTags = tags
+	}
+}
+
+// renderTags renders the tags view
+func (m *Model) renderTags() string {
+	colors := m.config.Theme.Colors
+	style := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color(colors.Border)).
+		Padding(1)
+
+	nameStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(colors.Primary)).Bold(true)
+	mutedStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(colors.Muted))
+	goodStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(colors.Success))
+	badStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(colors.Error))
+	warnStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(colors.Warning))
+
+	var sync *git.TagSync
+	if m.remoteTagsFrom != "" {
+		sync = git.SyncTags(m.remoteTagsFrom, m.tags, m.remoteTags)
+	}
+	if len(m.tags) == 0 && (sync == nil || len(sync.RemoteOnly) == 0) {
+		return style.Render(mutedStyle.Render("No tags (press enter to create one)"))
+	}
+
+	status := make(map[string]string)
+	if s := sync; s != nil {
+		for _, name := range s.LocalOnly {
+			status[name] = warnStyle.Render("local only")
+		}
+		for _, name := range s.Differ {
+			status[name] = badStyle.Render("differs on " + s.Remote)
+		}
+	}
+
+	var content strings.Builder
+	for i, t := range m.tags {
+		cursor := "  "
+		if i == m.selectedTag {
+			cursor = "▸ "
+		}
+
+		line := cursor + nameStyle.Render(t.Name)
+		if t.Annotated {
+			line += " " + mutedStyle.Render("annotated")
+		}
+		switch {
+		case t.Signature.Verified():
+			line += " " + goodStyle.Render("✓ signed")
+		case t.Signature == git.SigUnchecked:
+			line += " " + mutedStyle.Render("signed")
+		case t.Signature.Signed():
+			line += " " + badStyle.Render("✗ "+t.Signature.Description())
+		}
+		if s, ok := status[t.Name]; ok {
+			line += " " + s
+		}
+		content.WriteString(line + "\n")
+
+		details := []string{t.Hash[:min(7, len(t.Hash))]}
+		if t.Tagger != "" {
+			details = append(details, t.Tagger)
+		}
+		if !t.Date.IsZero() {
+			details = append(details, t.Date.Format("2006-01-02"))
+		}
+		content.WriteString(mutedStyle.Render("    "+strings.Join(details, " · ")) + "\n")
+		if t.Message != "" {
+			content.WriteString("    " + t.Message + "\n")
+		}
+	}
+
+	if s := sync; s != nil && len(s.RemoteOnly) > 0 {
+		content.WriteString("\n" + nameStyle.Render("Only on "+s.Remote) + "\n")
+		for _, name := range s.RemoteOnly {
+			content.WriteString("  " + warnStyle.Render(name) + "\n")
+		}
+	}
+
+	return style.Render(strings.TrimRight(content.String(), "\n"))
+}
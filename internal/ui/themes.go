@@ -0,0 +1,149 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/gitflow/tui/internal/config"
+	"github.com/muesli/termenv"
+)
+
+// colorDepth maps the terminal color profile to a theme color depth
+func colorDepth() config.ColorDepth {
+	switch lipgloss.ColorProfile() {
+	case termenv.ANSI256:
+		return config.ANSI256
+	case termenv.ANSI, termenv.Ascii:
+		return config.ANSI16
+	default:
+		return config.TrueColor
+	}
+}
+
+// applyTheme makes theme the active theme, adapting it to the terminal
+func (m *Model) applyTheme(theme config.Theme) {
+	// Only query the terminal background when it matters
+	dark := true
+	if theme.Name == config.AutoTheme {
+		dark = lipgloss.HasDarkBackground()
+	}
+
+	resolved := config.ResolveTheme(theme, dark)
+	m.config.Theme = resolved
+	m.config.Theme.Colors = resolved.ForDepth(colorDepth())
+}
+
+// cmdTheme opens the theme picker
+func cmdTheme(m *Model) tea.Cmd {
+	return func() tea.Msg {
+		themes, errs := config.Themes()
+		if len(errs) > 0 {
+			m.errorMsg = errs[0].Error()
+		}
+
+		m.themes = themes
+		m.themeBefore = m.config.Theme
+		m.selectedTheme = 0
+		for i, t := range themes {
+			if t.Name == m.config.Theme.Name {
+				m.selectedTheme = i
+			}
+		}
+		m.currentView = ViewThemes
+		return nil
+	}
+}
+
+// handleThemeKeys handles theme picker actions. Moving the selection
+// previews the theme; select keeps it and back restores the previous one.
+func (m *Model) handleThemeKeys(action string) (tea.Model, tea.Cmd) {
+	switch action {
+	case ActionUp:
+		if m.selectedTheme > 0 {
+			m.selectedTheme--
+			m.applyTheme(m.themes[m.selectedTheme])
+		}
+	case ActionDown:
+		if m.selectedTheme < len(m.themes)-1 {
+			m.selectedTheme++
+			m.applyTheme(m.themes[m.selectedTheme])
+		}
+	case ActionSelect:
+		if m.selectedTheme < len(m.themes) {
+			theme := m.themes[m.selectedTheme]
+			m.applyTheme(theme)
+			if err := saveTheme(theme); err != nil {
+				m.errorMsg = err.Error()
+			} else if origin := m.themeOverride(); origin != "" {
+				m.successMsg = "Theme: " + theme.Name + " (saved globally, but " + origin + " overrides it)"
+			} else {
+				m.successMsg = "Theme: " + theme.Name
+			}
+			m.currentView = m.tabs[m.activeTab].View
+		}
+	}
+	return m, nil
+}
+
+// cancelThemePicker restores the theme active before the picker opened
+func (m *Model) cancelThemePicker() {
+	m.config.Theme = m.themeBefore
+	m.currentView = m.tabs[m.activeTab].View
+}
+
+// saveTheme persists the theme choice in the global config only, so
+// repository and environment overrides are not written back
+func saveTheme(theme config.Theme) error {
+	global, err := config.Load()
+	if err != nil {
+		return err
+	}
+	global.Theme = theme
+	return global.Save()
+}
+
+// themeOverride returns the layer that sets the theme above the global
+// config, e.g. "repo:/path/.gitflow-tui.json", or "" when none does
+func (m *Model) themeOverride() string {
+	eff, err := config.LoadLayered(m.repoPath)
+	if err != nil {
+		return ""
+	}
+	switch origin := eff.Origins["theme"]; origin.Source {
+	case config.SourceRepoFile, config.SourceGitConfig, config.SourceEnv:
+		return origin.String()
+	}
+	return ""
+}
+
+// renderThemes renders the theme picker with a swatch per theme
+func (m *Model) renderThemes() string {
+	style := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color(m.config.Theme.Colors.Border)).
+		Padding(1)
+
+	var lines []string
+	lines = append(lines, "Select a theme (enter to keep, esc to cancel):", "")
+	for i, t := range m.themes {
+		colors := t.ForDepth(colorDepth())
+
+		var swatch strings.Builder
+		for _, c := range []string{colors.Primary, colors.Secondary, colors.Tertiary, colors.Accent, colors.Highlight} {
+			swatch.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color(c)).Render("██"))
+		}
+
+		cursor := "  "
+		nameStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(m.config.Theme.Colors.Foreground))
+		if i == m.selectedTheme {
+			cursor = "▸ "
+			nameStyle = nameStyle.Foreground(lipgloss.Color(m.config.Theme.Colors.Highlight)).Bold(true)
+		}
+
+		lines = append(lines, fmt.Sprintf("%s%s %s", cursor, swatch.String(), nameStyle.Render(t.Name)))
+	}
+
+	return style.Render(strings.Join(lines, "\n"))
+}
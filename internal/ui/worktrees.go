@@ -0,0 +1,240 @@
+package ui
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/gitflow/tui/internal/git"
+)
+
+// cmdWorktrees lists the worktrees and ways to add or prune them
+func cmdWorktrees(m *Model) tea.Cmd {
+	return func() tea.Msg {
+		m.showWorktreesMenu()
+		return nil
+	}
+}
+
+// showWorktreesMenu lists the worktrees
+func (m *Model) showWorktreesMenu() {
+	worktrees, err := m.git.GetWorktrees()
+	if err != nil {
+		m.errorMsg = err.Error()
+		return
+	}
+
+	var items []menuItem
+	prunable := 0
+	for i, w := range worktrees {
+		wt := w
+		key := ""
+		if i < 9 {
+			key = fmt.Sprint(i + 1)
+		}
+		items = append(items, menuItem{key, m.worktreeLabel(wt), func(m *Model) tea.Cmd {
+			m.showWorktreeMenu(wt)
+			return nil
+		}})
+		if wt.Prunable {
+			prunable++
+		}
+	}
+	items = append(items, menuItem{"a", "Add worktree...", func(m *Model) tea.Cmd {
+		m.addWorktree(worktrees[0])
+		return nil
+	}})
+	if prunable > 0 {
+		items = append(items, menuItem{"p", fmt.Sprintf("Prune %d missing worktree(s)", prunable), func(m *Model) tea.Cmd {
+			if err := m.git.PruneWorktrees(); err != nil {
+				m.errorMsg = err.Error()
+				return nil
+			}
+			m.successMsg = "Pruned missing worktrees"
+			return nil
+		}})
+	}
+
+	m.openMenu("Worktrees", items)
+}
+
+// worktreeLabel describes a worktree and its state
+func (m *Model) worktreeLabel(w git.Worktree) string {
+	checkout := w.Branch
+	switch {
+	case w.Bare:
+		checkout = "bare"
+	case w.Detached:
+		checkout = "detached at " + w.Head[:min(len(w.Head), 7)]
+	}
+
+	label := fmt.Sprintf("%s [%s]", w.Path, checkout)
+	var state []string
+	if w.Path == m.repoPath {
+		state = append(state, "current")
+	}
+	if w.Main {
+		state = append(state, "main")
+	}
+	if w.Locked {
+		state = append(state, "locked")
+	}
+	if w.Prunable {
+		state = append(state, "missing")
+	}
+	if len(state) > 0 {
+		label += " (" + strings.Join(state, ", ") + ")"
+	}
+	return label
+}
+
+// showWorktreeMenu shows the actions for a worktree
+func (m *Model) showWorktreeMenu(w git.Worktree) {
+	var items []menuItem
+	if w.Path != m.repoPath && !w.Bare && !w.Prunable {
+		items = append(items, menuItem{"s", "Switch to this worktree", func(m *Model) tea.Cmd {
+			return m.switchRepository(w.Path)
+		}})
+	}
+	if !w.Main && w.Path != m.repoPath {
+		items = append(items,
+			menuItem{"r", "Remove", func(m *Model) tea.Cmd { return m.removeWorktree(w, false) }},
+			menuItem{"R", "Remove, discarding its changes", func(m *Model) tea.Cmd { return m.removeWorktree(w, true) }},
+		)
+	}
+	if !w.Main {
+		if w.Locked {
+			items = append(items, menuItem{"u", "Unlock", func(m *Model) tea.Cmd {
+				if err := m.git.UnlockWorktree(w.Path); err != nil {
+					m.errorMsg = err.Error()
+					return nil
+				}
+				m.successMsg = "Unlocked " + w.Name()
+				return nil
+			}})
+		} else {
+			items = append(items, menuItem{"l", "Lock...", func(m *Model) tea.Cmd {
+				m.prompt("worktree-lock", "Reason (optional)...", "", func(reason string) {
+					if err := m.git.LockWorktree(w.Path, reason); err != nil {
+						m.errorMsg = err.Error()
+						return
+					}
+					m.successMsg = "Locked " + w.Name()
+				})
+				return nil
+			}})
+		}
+	}
+
+	title := m.worktreeLabel(w)
+	if w.LockReason != "" {
+		title += "\nLocked: " + w.LockReason
+	}
+	if w.PrunableReason != "" {
+		title += "\nMissing: " + w.PrunableReason
+	}
+	if len(items) == 0 {
+		m.successMsg = "Nothing to do for the current worktree"
+		return
+	}
+	m.openMenu(title, items)
+}
+
+// addWorktree asks for a branch and a directory and adds a worktree.
+// Branches only on a remote are created tracking it; branches that do not
+// exist at all are created from HEAD.
+func (m *Model) addWorktree(main git.Worktree) {
+	m.prompt("worktree-branch", "Branch to check out (created if new)...", "", func(branch string) {
+		branch = strings.TrimSpace(branch)
+		if branch == "" {
+			return
+		}
+		exists := false
+		for _, b := range m.branches {
+			exists = exists || b.Name == branch
+		}
+		upstream := ""
+		if !exists {
+			upstream = m.remoteBranchFor(branch)
+		}
+
+		name := filepath.Base(main.Path) + "-" + strings.ReplaceAll(branch, "/", "-")
+		initial := filepath.Join(filepath.Dir(main.Path), name)
+		m.prompt("worktree-path", "Directory for the worktree...", initial, func(path string) {
+			path = strings.TrimSpace(path)
+			if path == "" {
+				return
+			}
+			var err error
+			if upstream != "" {
+				err = m.git.AddTrackingWorktree(path, branch, upstream)
+			} else {
+				err = m.git.AddWorktree(path, branch, !exists, "")
+			}
+			if err != nil {
+				m.errorMsg = err.Error()
+				return
+			}
+			m.successMsg = fmt.Sprintf("Added worktree %s on %s", path, branch)
+			if upstream != "" {
+				m.successMsg += " tracking " + upstream
+			}
+			m.inputCmd = m.loadData()
+		})
+	})
+}
+
+// remoteBranchFor returns the remote-tracking branch named like branch,
+// preferring the default remote, or "" when no remote has it
+func (m *Model) remoteBranchFor(branch string) string {
+	found := ""
+	for _, b := range m.remoteBranches {
+		if strings.TrimPrefix(b.Name, b.Remote+"/") != branch {
+			continue
+		}
+		if found == "" || b.Remote == m.defaultRemote() {
+			found = b.Name
+		}
+	}
+	return found
+}
+
+// removeWorktree removes a linked worktree after confirmation
+func (m *Model) removeWorktree(w git.Worktree, force bool) tea.Cmd {
+	return func() tea.Msg {
+		question := fmt.Sprintf("Remove worktree %s?", w.Path)
+		if force {
+			question = fmt.Sprintf("Remove worktree %s and discard its uncommitted changes?", w.Path)
+		}
+		m.confirm(question, func() {
+			if err := m.git.RemoveWorktree(w.Path, force); err != nil {
+				m.errorMsg = err.Error()
+				return
+			}
+			m.successMsg = "Removed worktree " + w.Name()
+		})
+		return nil
+	}
+}
+
+// switchRepository points the TUI at the repository containing path,
+// e.g. another worktree, and reloads everything
+func (m *Model) switchRepository(path string) tea.Cmd {
+	repo, err := git.FindRepository(path)
+	if err != nil {
+		m.errorMsg = err.Error()
+		return nil
+	}
+
+	m.repo = repo
+	m.git = git.New(repo.Path)
+	m.repoPath = repo.Path
+	m.selectedCommit, m.selectedBranch, m.selectedFile = 0, 0, 0
+	m.selectedStash, m.selectedRemote, m.selectedTag = 0, 0, 0
+	m.remoteTags, m.remoteTagsFrom = nil, ""
+	m.logFilter = git.LogFilter{}
+	m.selectTab(m.activeTab)
+	m.successMsg = "Switched to " + repo.Path
+	return m.loadData()
+}
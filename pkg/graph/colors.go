@@ -3,6 +3,7 @@ package graph
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/charmbracelet/lipgloss"
 	"github.com/gitflow/tui/internal/config"
@@ -15,6 +16,7 @@ type ColoredGraph struct {
 	style   GraphStyle
 	width   int
 	colors  config.ThemeColors
+	bisect  *git.Bisect
 }
 
 // NewColored creates a new colored graph
@@ -32,6 +34,11 @@ func (g *ColoredGraph) SetWidth(width int) {
 	g.width = width
 }
 
+// SetBisect marks the commits of a bisect session, nil for none
+func (g *ColoredGraph) SetBisect(b *git.Bisect) {
+	g.bisect = b
+}
+
 // Render renders the colorful commit graph
 func (g *ColoredGraph) Render() string {
 	if len(g.commits) == 0 {
@@ -83,6 +90,11 @@ func (g *ColoredGraph) renderColoredLine(commit git.Commit, index int) string {
 		connector = " "
 	}
 
+	// Commits that may still be the first bad one stand out
+	if g.bisect != nil && g.bisect.Candidate(commit.Hash) {
+		commitDot = "◆"
+	}
+
 	// Graph line
 	graphPart := graphStyle.Render(indent + connector + "─" + commitDot + "─")
 
@@ -106,6 +118,16 @@ func (g *ColoredGraph) renderColoredLine(commit git.Commit, index int) string {
 	// Add hash
 	parts = append(parts, hashStyle.Render(commit.ShortHash))
 
+	// Add bisect badge
+	if badge := g.bisectBadge(commit.Hash); badge != "" {
+		parts = append(parts, badge)
+	}
+
+	// Add signature badge
+	if badge := g.signatureBadge(commit.Signature); badge != "" {
+		parts = append(parts, badge)
+	}
+
 	// Add message
 	parts = append(parts, msgStyle.Render(commit.Message))
 
@@ -130,6 +152,53 @@ func (g *ColoredGraph) renderColoredLine(commit git.Commit, index int) string {
 	return result
 }
 
+// bisectBadge renders a badge for a commit marked or checked out in the
+// bisect session
+func (g *ColoredGraph) bisectBadge(hash string) string {
+	if g.bisect == nil {
+		return ""
+	}
+
+	style := lipgloss.NewStyle().Bold(true)
+	switch g.bisect.Mark(hash) {
+	case git.BisectBad:
+		if hash == g.bisect.Culprit {
+			return style.Foreground(lipgloss.Color(g.colors.Error)).Render("[first bad]")
+		}
+		return style.Foreground(lipgloss.Color(g.colors.Error)).Render("[bad]")
+	case git.BisectGood:
+		return style.Foreground(lipgloss.Color(g.colors.Success)).Render("[good]")
+	case git.BisectSkip:
+		return style.Foreground(lipgloss.Color(g.colors.Warning)).Render("[skip]")
+	}
+	if hash == g.bisect.Current && g.bisect.Culprit == "" {
+		return style.Foreground(lipgloss.Color(g.colors.Accent)).Render("[testing]")
+	}
+	return ""
+}
+
+// signatureBadge renders a badge for the commit's signature status
+func (g *ColoredGraph) signatureBadge(status git.SignatureStatus) string {
+	if !status.Signed() {
+		return ""
+	}
+
+	switch {
+	case status.Verified():
+		return lipgloss.NewStyle().
+			Foreground(lipgloss.Color(g.colors.Success)).
+			Render("✓")
+	case status == git.SigBad:
+		return lipgloss.NewStyle().
+			Foreground(lipgloss.Color(g.colors.Error)).
+			Render("✗")
+	default:
+		return lipgloss.NewStyle().
+			Foreground(lipgloss.Color(g.colors.Warning)).
+			Render("?")
+	}
+}
+
 // RenderCompact renders a compact colorful graph
 func (g *ColoredGraph) RenderCompact() string {
 	if len(g.commits) == 0 {
@@ -203,12 +272,60 @@ func (g *ColoredGraph) RenderBranchGraph(branches []git.Branch, currentBranch st
 			line += " " + strings.Join(infoParts, " ")
 		}
 
+		mutedStyle := lipgloss.NewStyle().
+			Foreground(lipgloss.Color(g.colors.Muted))
+		if branch.Upstream != "" {
+			line += " " + mutedStyle.Render("→ "+branch.Upstream)
+		}
+		if branch.Gone {
+			goneStyle := lipgloss.NewStyle().
+				Foreground(lipgloss.Color(g.colors.Warning))
+			line += " " + goneStyle.Render("gone")
+		}
+		if !branch.LastCommit.IsZero() {
+			line += " " + mutedStyle.Render(fmt.Sprintf("· %s, %s", relativeTime(branch.LastCommit), branch.Author))
+		}
+
 		lines = append(lines, line)
 	}
 
 	return strings.Join(lines, "\n")
 }
 
+// relativeTime formats t relative to now, e.g. "3 days ago"
+func relativeTime(t time.Time) string {
+	d := time.Since(t)
+	unit := func(n int, name string) string {
+		if n == 1 {
+			return fmt.Sprintf("1 %s ago", name)
+		}
+		return fmt.Sprintf("%d %ss ago", n, name)
+	}
+
+	switch {
+	case d < time.Minute:
+		return "just now"
+	case d < time.Hour:
+		return unit(int(d.Minutes()), "minute")
+	case d < 24*time.Hour:
+		return unit(int(d.Hours()), "hour")
+	case d < 30*24*time.Hour:
+		return unit(int(d.Hours()/24), "day")
+	case d < 365*24*time.Hour:
+		return unit(int(d.Hours()/24/30), "month")
+	default:
+		return unit(int(d.Hours()/24/365), "year")
+	}
+}
+
+// submoduleNote describes the change to a submodule, if f is one
+func submoduleNote(f git.FileStatus) string {
+	if f.Submodule == "" {
+		return ""
+	}
+	return " submodule: " + f.Submodule
+}
+
 // RenderStatusGraph renders colorful file status
 func RenderStatusGraph(status *git.Status, colors config.ThemeColors) string {
 	if status == nil {
@@ -230,7 +347,7 @@ func RenderStatusGraph(status *git.Status, colors config.ThemeColors) string {
 		for _, f := range status.Staged {
 			line := lipgloss.NewStyle().
 				Foreground(lipgloss.Color(colors.Success)).
-				Render(fmt.Sprintf("  + %s [%s]", f.Path, f.Status))
+				Render(fmt.Sprintf("  + %s [%s]%s", f.Path, f.Status, submoduleNote(f)))
 			lines = append(lines, line)
 		}
 	}
@@ -246,7 +363,7 @@ func RenderStatusGraph(status *git.Status, colors config.ThemeColors) string {
 		for _, f := range status.Unstaged {
 			line := lipgloss.NewStyle().
 				Foreground(lipgloss.Color(colors.Highlight)).
-				Render(fmt.Sprintf("  ~ %s [%s]", f.Path, f.Status))
+				Render(fmt.Sprintf("  ~ %s [%s]%s", f.Path, f.Status, submoduleNote(f)))
 			lines = append(lines, line)
 		}
 	}